@@ -0,0 +1,214 @@
+// Package aggregate 将同一个关键词并发分发到多个平台搜索，并合并同一录音的结果。
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/match"
+	"github.com/guohuiyuan/music-lib/model"
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+)
+
+// DefaultTimeout 单个平台搜索的默认超时
+const DefaultTimeout = 10 * time.Second
+
+// Source 是参与聚合搜索的一个平台
+type Source struct {
+	Name     string
+	Searcher provider.SongSearcher
+	// Timeout 该平台的超时，为 0 时使用 Aggregator.Timeout
+	Timeout time.Duration
+}
+
+// Aggregator 聚合搜索器。Sources 的顺序即优先级，合并结果时以靠前平台的歌曲作为代表。
+type Aggregator struct {
+	Sources []Source
+	// Timeout 未单独设置超时的平台使用的超时，为 0 时使用 DefaultTimeout
+	Timeout time.Duration
+	// DurationTolerance 判断同一录音时允许的时长误差 (秒)，为 0 时使用 match.DefaultDurationTolerance
+	DurationTolerance int
+}
+
+// New 创建聚合搜索器
+func New(sources ...Source) *Aggregator {
+	return &Aggregator{Sources: sources}
+}
+
+// FromRegistry 使用已注册的平台创建聚合搜索器 (不带 Cookie)，names 的顺序即优先级
+func FromRegistry(names ...string) (*Aggregator, error) {
+	sources := make([]Source, 0, len(names))
+	for _, name := range names {
+		p, err := registry.Get(name, "")
+		if err != nil {
+			return nil, err
+		}
+		searcher, ok := p.(provider.SongSearcher)
+		if !ok {
+			return nil, fmt.Errorf("aggregate: %s does not support search", name)
+		}
+		sources = append(sources, Source{Name: name, Searcher: searcher})
+	}
+	return New(sources...), nil
+}
+
+// Group 是不同平台上的同一录音
+type Group struct {
+	// Song 代表歌曲，来自优先级最高的平台
+	Song model.Song
+	// Songs 组内全部歌曲，按平台优先级排列，第一首即 Song
+	Songs []model.Song
+}
+
+// Sources 返回组内歌曲的来源平台
+func (g Group) Sources() []string {
+	sources := make([]string, 0, len(g.Songs))
+	for _, s := range g.Songs {
+		sources = append(sources, s.Source)
+	}
+	return sources
+}
+
+// Result 聚合搜索结果
+type Result struct {
+	// Groups 合并后的结果，按各平台返回的排名交错排列
+	Groups []Group
+	// Errors 各平台的错误，成功的平台不在其中
+	Errors map[string]error
+}
+
+// Songs 返回每组的代表歌曲
+func (r *Result) Songs() []model.Song {
+	songs := make([]model.Song, 0, len(r.Groups))
+	for _, g := range r.Groups {
+		songs = append(songs, g.Song)
+	}
+	return songs
+}
+
+// Search 并发搜索所有平台并合并结果。单个平台失败或超时只记录在 Result.Errors 中，
+// 不影响其他平台的结果。
+func (a *Aggregator) Search(ctx context.Context, keyword string) *Result {
+	lists := make([][]model.Song, len(a.Sources))
+	errs := make([]error, len(a.Sources))
+
+	var wg sync.WaitGroup
+	for i, src := range a.Sources {
+		wg.Add(1)
+		go func(i int, src Source) {
+			defer wg.Done()
+			lists[i], errs[i] = a.searchOne(ctx, src, keyword)
+		}(i, src)
+	}
+	wg.Wait()
+
+	result := &Result{Errors: make(map[string]error)}
+	for i, src := range a.Sources {
+		if errs[i] != nil {
+			result.Errors[src.Name] = errs[i]
+		}
+	}
+	result.Groups = a.merge(lists)
+	return result
+}
+
+func (a *Aggregator) searchOne(ctx context.Context, src Source, keyword string) ([]model.Song, error) {
+	if src.Searcher == nil {
+		return nil, errors.New("aggregate: nil searcher")
+	}
+	timeout := src.Timeout
+	if timeout <= 0 {
+		timeout = a.Timeout
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if s, ok := src.Searcher.(provider.SongSearcherContext); ok {
+		return s.SearchContext(ctx, keyword)
+	}
+
+	// 不支持 context 的实现只能放弃等待，后台请求会自行结束
+	type searchResult struct {
+		songs []model.Song
+		err   error
+	}
+	done := make(chan searchResult, 1)
+	go func() {
+		songs, err := src.Searcher.Search(keyword)
+		done <- searchResult{songs, err}
+	}()
+	select {
+	case r := <-done:
+		return r.songs, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// merge 按排名交错遍历各平台结果，把同一录音归入同一组
+func (a *Aggregator) merge(lists [][]model.Song) []Group {
+	tolerance := a.DurationTolerance
+	if tolerance <= 0 {
+		tolerance = match.DefaultDurationTolerance
+	}
+
+	maxLen := 0
+	for _, list := range lists {
+		if len(list) > maxLen {
+			maxLen = len(list)
+		}
+	}
+
+	var groups []Group
+	for rank := 0; rank < maxLen; rank++ {
+		for _, list := range lists {
+			if rank >= len(list) {
+				continue
+			}
+			song := list[rank]
+			merged := false
+			for gi := range groups {
+				if match.SameRecording(&groups[gi].Song, &song, tolerance) {
+					groups[gi].Songs = append(groups[gi].Songs, song)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				groups = append(groups, Group{Song: song, Songs: []model.Song{song}})
+			}
+		}
+	}
+
+	// 同一组内按平台优先级排列，代表歌曲取优先级最高的平台
+	order := make(map[string]int, len(a.Sources))
+	for i, src := range a.Sources {
+		if _, ok := order[src.Name]; !ok {
+			order[src.Name] = i
+		}
+	}
+	for gi := range groups {
+		songs := groups[gi].Songs
+		for i := 1; i < len(songs); i++ {
+			for j := i; j > 0 && priority(order, songs[j].Source) < priority(order, songs[j-1].Source); j-- {
+				songs[j], songs[j-1] = songs[j-1], songs[j]
+			}
+		}
+		groups[gi].Song = songs[0]
+	}
+	return groups
+}
+
+func priority(order map[string]int, source string) int {
+	if p, ok := order[source]; ok {
+		return p
+	}
+	return len(order)
+}
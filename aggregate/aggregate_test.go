@@ -0,0 +1,89 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+type fakeSearcher struct {
+	songs []model.Song
+	err   error
+	delay time.Duration
+}
+
+func (f *fakeSearcher) Search(keyword string) ([]model.Song, error) {
+	time.Sleep(f.delay)
+	return f.songs, f.err
+}
+
+type fakeContextSearcher struct {
+	fakeSearcher
+}
+
+func (f *fakeContextSearcher) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	select {
+	case <-time.After(f.delay):
+		return f.songs, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestSearchMergesSameRecording(t *testing.T) {
+	agg := New(
+		Source{Name: "netease", Searcher: &fakeSearcher{songs: []model.Song{
+			{Source: "netease", ID: "1", Name: "晴天", Artist: "周杰伦", Duration: 269},
+			{Source: "netease", ID: "2", Name: "七里香", Artist: "周杰伦", Duration: 299},
+		}}},
+		Source{Name: "qq", Searcher: &fakeContextSearcher{fakeSearcher{songs: []model.Song{
+			{Source: "qq", ID: "a", Name: "晴天", Artist: "周杰伦", Duration: 270},
+			{Source: "qq", ID: "b", Name: "晴天", Artist: "孙燕姿", Duration: 250},
+		}}}},
+		Source{Name: "kuwo", Searcher: &fakeSearcher{err: errors.New("boom")}},
+	)
+
+	res := agg.Search(context.Background(), "晴天")
+	if len(res.Groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d: %+v", len(res.Groups), res.Groups)
+	}
+	if got := res.Groups[0].Sources(); !reflect.DeepEqual(got, []string{"netease", "qq"}) {
+		t.Fatalf("unexpected first group sources: %v", got)
+	}
+	if res.Groups[0].Song.ID != "1" {
+		t.Fatalf("representative should come from the first source, got %+v", res.Groups[0].Song)
+	}
+	if res.Groups[1].Song.ID != "2" || res.Groups[2].Song.ID != "b" {
+		t.Fatalf("unexpected merge order: %+v", res.Songs())
+	}
+	if len(res.Errors) != 1 || res.Errors["kuwo"] == nil {
+		t.Fatalf("expected kuwo error, got %v", res.Errors)
+	}
+}
+
+func TestSearchPerSourceTimeout(t *testing.T) {
+	agg := New(
+		Source{Name: "slow", Searcher: &fakeContextSearcher{fakeSearcher{delay: time.Second}}, Timeout: 20 * time.Millisecond},
+		Source{Name: "legacy", Searcher: &fakeSearcher{delay: time.Second}},
+		Source{Name: "fast", Searcher: &fakeSearcher{songs: []model.Song{{Source: "fast", ID: "1", Name: "x"}}}},
+	)
+	agg.Timeout = 30 * time.Millisecond
+
+	start := time.Now()
+	res := agg.Search(context.Background(), "x")
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("search should not wait for slow sources, took %v", elapsed)
+	}
+	for _, name := range []string{"slow", "legacy"} {
+		if !errors.Is(res.Errors[name], context.DeadlineExceeded) {
+			t.Fatalf("expected deadline error for %s, got %v", name, res.Errors[name])
+		}
+	}
+	if len(res.Groups) != 1 || res.Groups[0].Song.Source != "fast" {
+		t.Fatalf("unexpected groups: %+v", res.Groups)
+	}
+}
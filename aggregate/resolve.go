@@ -0,0 +1,134 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/guohuiyuan/music-lib/match"
+	"github.com/guohuiyuan/music-lib/model"
+	"github.com/guohuiyuan/music-lib/provider"
+)
+
+// ErrNoPlayableSource 所有平台都没有得到可用的下载地址
+var ErrNoPlayableSource = errors.New("no playable source found")
+
+// DefaultCandidatesPerSource 每个平台最多尝试的候选歌曲数量
+const DefaultCandidatesPerSource = 3
+
+// Resolution 是 Resolve 找到的可播放版本
+type Resolution struct {
+	// Song 实际使用的歌曲，可能来自与原曲不同的平台
+	Song model.Song
+	URL  string
+	// Score 与原曲的匹配得分，使用原曲本身时为 1
+	Score float64
+}
+
+// Match 在除 s.Source 以外的平台上搜索 "歌名 歌手"，返回得分不低于 minScore 的候选，
+// minScore 为 0 时使用 match.DefaultMinScore。候选按得分从高到低排列，
+// 第二个返回值是搜索失败的平台。
+func (a *Aggregator) Match(ctx context.Context, s *model.Song, minScore float64) ([]match.Candidate, map[string]error) {
+	if minScore <= 0 {
+		minScore = match.DefaultMinScore
+	}
+	others := &Aggregator{Timeout: a.Timeout, DurationTolerance: a.DurationTolerance}
+	for _, src := range a.Sources {
+		if src.Name != s.Source {
+			others.Sources = append(others.Sources, src)
+		}
+	}
+
+	res := others.Search(ctx, searchKeyword(s))
+	var songs []model.Song
+	for _, g := range res.Groups {
+		songs = append(songs, g.Songs...)
+	}
+	return match.Rank(s, songs, minScore), res.Errors
+}
+
+// Resolve 获取 s 的下载地址，原平台失败 (VIP、版权限制等) 时自动换源：
+// 先尝试 s 所在平台，再按 Sources 的顺序依次尝试各平台得分最高的几个候选，
+// 直到拿到下载地址为止。
+func (a *Aggregator) Resolve(ctx context.Context, s *model.Song) (*Resolution, error) {
+	var failures []string
+
+	if src, ok := a.source(s.Source); ok {
+		url, err := downloadURL(ctx, src, s)
+		if err == nil {
+			return &Resolution{Song: *s, URL: url, Score: 1}, nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", src.Name, err))
+	}
+
+	candidates, searchErrs := a.Match(ctx, s, 0)
+	for _, src := range a.Sources {
+		if src.Name == s.Source {
+			continue
+		}
+		if err, ok := searchErrs[src.Name]; ok {
+			failures = append(failures, fmt.Sprintf("%s: %v", src.Name, err))
+			continue
+		}
+		tried := 0
+		for _, c := range candidates {
+			if c.Song.Source != src.Name || tried >= DefaultCandidatesPerSource {
+				continue
+			}
+			tried++
+			song := c.Song
+			url, err := downloadURL(ctx, src, &song)
+			if err == nil {
+				return &Resolution{Song: song, URL: url, Score: c.Score}, nil
+			}
+			failures = append(failures, fmt.Sprintf("%s/%s: %v", src.Name, song.ID, err))
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil, ErrNoPlayableSource
+	}
+	return nil, fmt.Errorf("%w (%s)", ErrNoPlayableSource, strings.Join(failures, "; "))
+}
+
+func (a *Aggregator) source(name string) (Source, bool) {
+	for _, src := range a.Sources {
+		if src.Name == name {
+			return src, true
+		}
+	}
+	return Source{}, false
+}
+
+func downloadURL(ctx context.Context, src Source, s *model.Song) (string, error) {
+	var (
+		url string
+		err error
+	)
+	switch d := src.Searcher.(type) {
+	case provider.SongDownloaderContext:
+		url, err = d.GetDownloadURLContext(ctx, s)
+	case provider.SongDownloader:
+		url, err = d.GetDownloadURL(s)
+	default:
+		return "", errors.New("download not supported")
+	}
+	if err != nil {
+		return "", err
+	}
+	if url == "" {
+		return "", errors.New("empty download url")
+	}
+	return url, nil
+}
+
+func searchKeyword(s *model.Song) string {
+	if s.Artist == "" {
+		return s.Name
+	}
+	return s.Name + " " + s.Artist
+}
@@ -0,0 +1,65 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+type fakeProvider struct {
+	fakeSearcher
+	urls map[string]string
+}
+
+func (f *fakeProvider) GetDownloadURL(s *model.Song) (string, error) {
+	if url, ok := f.urls[s.ID]; ok {
+		return url, nil
+	}
+	return "", errors.New("vip required")
+}
+
+func TestResolveFallsBackToOtherSource(t *testing.T) {
+	orig := model.Song{Source: "qq", ID: "q1", Name: "晴天", Artist: "周杰伦", Duration: 269}
+	agg := New(
+		Source{Name: "kugou", Searcher: &fakeProvider{
+			fakeSearcher: fakeSearcher{songs: []model.Song{
+				{Source: "kugou", ID: "k1", Name: "晴天", Artist: "周杰伦", Duration: 269},
+			}},
+		}},
+		Source{Name: "qq", Searcher: &fakeProvider{}},
+		Source{Name: "kuwo", Searcher: &fakeProvider{
+			fakeSearcher: fakeSearcher{songs: []model.Song{
+				{Source: "kuwo", ID: "w0", Name: "晴天", Artist: "孙燕姿", Duration: 260},
+				{Source: "kuwo", ID: "w1", Name: "晴天", Artist: "周杰伦", Duration: 270},
+			}},
+			urls: map[string]string{"w0": "http://kuwo/w0", "w1": "http://kuwo/w1"},
+		}},
+	)
+
+	res, err := agg.Resolve(context.Background(), &orig)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if res.Song.ID != "w1" || res.URL != "http://kuwo/w1" {
+		t.Fatalf("unexpected resolution %+v", res)
+	}
+
+	agg.Sources = agg.Sources[:2]
+	if _, err := agg.Resolve(context.Background(), &orig); !errors.Is(err, ErrNoPlayableSource) {
+		t.Fatalf("expected ErrNoPlayableSource, got %v", err)
+	}
+}
+
+func TestResolveUsesOriginalSourceFirst(t *testing.T) {
+	orig := model.Song{Source: "qq", ID: "q1", Name: "晴天", Artist: "周杰伦"}
+	agg := New(
+		Source{Name: "kuwo", Searcher: &fakeProvider{urls: map[string]string{}}},
+		Source{Name: "qq", Searcher: &fakeProvider{urls: map[string]string{"q1": "http://qq/q1"}}},
+	)
+	res, err := agg.Resolve(context.Background(), &orig)
+	if err != nil || res.URL != "http://qq/q1" || res.Score != 1 {
+		t.Fatalf("unexpected resolution %+v, %v", res, err)
+	}
+}
@@ -1,6 +1,7 @@
 package apple
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -8,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/guohuiyuan/music-lib/lyrics"
 	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
 )
@@ -22,10 +24,11 @@ type Apple struct {
 	cookie     string // media-user-token
 	token      string // bearer token
 	storefront string
+	client *utils.Client
 }
 
-func New(cookie string) *Apple {
-	a := &Apple{cookie: cookie, storefront: defaultStorefront}
+func New(cookie string, opts ...utils.ClientOption) *Apple {
+	a := &Apple{cookie: cookie, storefront: defaultStorefront, client: utils.NewClient(opts...)}
 	// cookie format: "media-user-token=xxx" or "token=xxx;media-user-token=xxx;storefront=cn"
 	if cookie != "" {
 		parts := parseCookieParts(cookie)
@@ -57,10 +60,29 @@ func GetPlaylistSongs(id string) ([]model.Song, error)                 { return
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error)    { return defaultApple.ParseAlbum(link) }
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) { return defaultApple.ParsePlaylist(link) }
 func GetPlaylistCategories() ([]model.PlaylistCategory, error)         { return defaultApple.GetPlaylistCategories() }
+func GetLyricsData(s *model.Song) (*lyrics.Lyrics, error)              { return defaultApple.GetLyricsData(s) }
+
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error)                      { return defaultApple.SearchContext(ctx, keyword) }
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error)                     { return defaultApple.GetDownloadURLContext(ctx, s) }
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error)                          { return defaultApple.GetLyricsContext(ctx, s) }
+func ParseContext(ctx context.Context, link string) (*model.Song, error)                           { return defaultApple.ParseContext(ctx, link) }
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error)             { return defaultApple.SearchAlbumContext(ctx, keyword) }
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error)          { return defaultApple.SearchPlaylistContext(ctx, keyword) }
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error)                    { return defaultApple.GetAlbumSongsContext(ctx, id) }
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error)                 { return defaultApple.GetPlaylistSongsContext(ctx, id) }
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error)    { return defaultApple.ParseAlbumContext(ctx, link) }
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) { return defaultApple.ParsePlaylistContext(ctx, link) }
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error)           { return defaultApple.GetPlaylistCategoriesContext(ctx) }
+func GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error)              { return defaultApple.GetLyricsDataContext(ctx, s) }
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultApple.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultApple.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 // Apple Music genre curators (extracted from public search-landing recommendations).
 var appleCuratorCategories = []model.PlaylistCategory{
 	{ID: "1526756058", Name: "热门", Source: "apple"},
@@ -96,11 +118,21 @@ var appleCuratorCategories = []model.PlaylistCategory{
 
 // GetPlaylistCategories returns Apple Music genre categories.
 func (a *Apple) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return a.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext is like GetPlaylistCategories but carries ctx through its requests.
+func (a *Apple) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	return appleCuratorCategories, nil
 }
 
 // GetCategoryPlaylists returns playlists for a given Apple Music curator category.
 func (a *Apple) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return a.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext is like GetCategoryPlaylists but carries ctx through its requests.
+func (a *Apple) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -123,7 +155,7 @@ func (a *Apple) GetCategoryPlaylists(categoryID string, page, limit int) ([]mode
 		params.Set("l", "zh-Hans-CN")
 
 		uri := fmt.Sprintf("/v1/catalog/%s/apple-curators/%s/playlists", storefront, categoryID)
-		body, err := a.ampGet(uri, params)
+		body, err := a.ampGet(ctx, uri, params)
 		if err != nil {
 			if len(all) > 0 {
 				break // return what we have
@@ -149,11 +181,11 @@ func (a *Apple) GetCategoryPlaylists(categoryID string, page, limit int) ([]mode
 	return all, nil
 }
 
-func (a *Apple) ensureToken() error {
+func (a *Apple) ensureToken(ctx context.Context) error {
 	if a.token != "" {
 		return nil
 	}
-	token, err := fetchAppleToken()
+	token, err := a.fetchAppleToken(ctx)
 	if err != nil {
 		return err
 	}
@@ -172,16 +204,16 @@ func (a *Apple) ampHeaders() []utils.RequestOption {
 	return opts
 }
 
-func (a *Apple) ampGet(uri string, params url.Values) ([]byte, error) {
+func (a *Apple) ampGet(ctx context.Context, uri string, params url.Values) ([]byte, error) {
 	fullURL := appleAmpAPIURL + uri
 	if len(params) > 0 {
 		fullURL += "?" + params.Encode()
 	}
-	return a.ampGetRaw(fullURL)
+	return a.ampGetRaw(ctx, fullURL)
 }
 
-func (a *Apple) ampGetRaw(uri string) ([]byte, error) {
-	if err := a.ensureToken(); err != nil {
+func (a *Apple) ampGetRaw(ctx context.Context, uri string) ([]byte, error) {
+	if err := a.ensureToken(ctx); err != nil {
 		return nil, err
 	}
 
@@ -189,18 +221,23 @@ func (a *Apple) ampGetRaw(uri string) ([]byte, error) {
 	if strings.HasPrefix(uri, "/") {
 		fullURL = appleAmpAPIURL + uri
 	}
-	return utils.Get(fullURL, a.ampHeaders()...)
+	return a.client.GetContext(ctx, fullURL, a.ampHeaders()...)
 }
 
 // Search searches Apple Music catalog for songs.
 func (a *Apple) Search(keyword string) ([]model.Song, error) {
+	return a.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but carries ctx through its requests.
+func (a *Apple) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("term", keyword)
 	params.Set("types", "songs")
 	params.Set("limit", "30")
 
 	uri := fmt.Sprintf("/v1/catalog/%s/search", a.storefront)
-	body, err := a.ampGet(uri, params)
+	body, err := a.ampGet(ctx, uri, params)
 	if err != nil {
 		return nil, err
 	}
@@ -219,13 +256,18 @@ func (a *Apple) Search(keyword string) ([]model.Song, error) {
 
 // SearchAlbum searches Apple Music catalog for albums.
 func (a *Apple) SearchAlbum(keyword string) ([]model.Playlist, error) {
+	return a.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext is like SearchAlbum but carries ctx through its requests.
+func (a *Apple) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("term", keyword)
 	params.Set("types", "albums")
 	params.Set("limit", "20")
 
 	uri := fmt.Sprintf("/v1/catalog/%s/search", a.storefront)
-	body, err := a.ampGet(uri, params)
+	body, err := a.ampGet(ctx, uri, params)
 	if err != nil {
 		return nil, err
 	}
@@ -244,13 +286,18 @@ func (a *Apple) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 // SearchPlaylist searches Apple Music catalog for playlists.
 func (a *Apple) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return a.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext is like SearchPlaylist but carries ctx through its requests.
+func (a *Apple) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("term", keyword)
 	params.Set("types", "playlists")
 	params.Set("limit", "20")
 
 	uri := fmt.Sprintf("/v1/catalog/%s/search", a.storefront)
-	body, err := a.ampGet(uri, params)
+	body, err := a.ampGet(ctx, uri, params)
 	if err != nil {
 		return nil, err
 	}
@@ -269,18 +316,33 @@ func (a *Apple) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 // GetAlbumSongs returns songs from an album.
 func (a *Apple) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := a.ParseAlbum(id)
+	return a.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext is like GetAlbumSongs but carries ctx through its requests.
+func (a *Apple) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := a.ParseAlbumContext(ctx, id)
 	return songs, err
 }
 
 // GetPlaylistSongs returns songs from a playlist.
 func (a *Apple) GetPlaylistSongs(id string) ([]model.Song, error) {
-	_, songs, err := a.ParsePlaylist(id)
+	return a.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext is like GetPlaylistSongs but carries ctx through its requests.
+func (a *Apple) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := a.ParsePlaylistContext(ctx, id)
 	return songs, err
 }
 
 // ParseAlbum fetches album details.
 func (a *Apple) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return a.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext is like ParseAlbum but carries ctx through its requests.
+func (a *Apple) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	albumID := extractAppleID(link, "album")
 	if albumID == "" {
 		return nil, nil, fmt.Errorf("invalid apple music album link: %s", link)
@@ -290,7 +352,7 @@ func (a *Apple) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	params.Set("extend", "extendedAssetUrls")
 
 	uri := fmt.Sprintf("/v1/catalog/%s/albums/%s", a.storefront, albumID)
-	body, err := a.ampGet(uri, params)
+	body, err := a.ampGet(ctx, uri, params)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -300,7 +362,7 @@ func (a *Apple) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 		return nil, nil, fmt.Errorf("apple album json error: %w", err)
 	}
 	if len(resp.Data) == 0 {
-		return nil, nil, fmt.Errorf("apple album not found: %s", albumID)
+		return nil, nil, model.Errorf(model.ErrNotFound, "apple album not found: %s", albumID)
 	}
 
 	album := resp.Data[0]
@@ -315,25 +377,30 @@ func (a *Apple) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 
 // ParsePlaylist fetches playlist details.
 func (a *Apple) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return a.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext is like ParsePlaylist but carries ctx through its requests.
+func (a *Apple) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	playlistID := extractAppleID(link, "playlist")
 	if playlistID == "" {
 		return nil, nil, fmt.Errorf("invalid apple music playlist link: %s", link)
 	}
 
-	pl, songs, err := a.fetchPlaylistDetail(playlistID)
+	pl, songs, err := a.fetchPlaylistDetail(ctx, playlistID)
 	if err != nil {
 		return nil, nil, err
 	}
 	return &pl, songs, nil
 }
 
-func (a *Apple) fetchPlaylistDetail(playlistID string) (model.Playlist, []model.Song, error) {
+func (a *Apple) fetchPlaylistDetail(ctx context.Context, playlistID string) (model.Playlist, []model.Song, error) {
 	params := url.Values{}
 	params.Set("limit[tracks]", "300")
 	params.Set("extend", "extendedAssetUrls")
 
 	uri := fmt.Sprintf("/v1/catalog/%s/playlists/%s", a.storefront, playlistID)
-	body, err := a.ampGet(uri, params)
+	body, err := a.ampGet(ctx, uri, params)
 	if err != nil {
 		return model.Playlist{}, nil, err
 	}
@@ -343,11 +410,11 @@ func (a *Apple) fetchPlaylistDetail(playlistID string) (model.Playlist, []model.
 		return model.Playlist{}, nil, fmt.Errorf("apple playlist json error: %w", err)
 	}
 	if len(resp.Data) == 0 {
-		return model.Playlist{}, nil, fmt.Errorf("apple playlist not found: %s", playlistID)
+		return model.Playlist{}, nil, model.Errorf(model.ErrNotFound, "apple playlist not found: %s", playlistID)
 	}
 
 	item := resp.Data[0]
-	tracks, err := a.fetchAllTrackResources(item.Relationships.Tracks)
+	tracks, err := a.fetchAllTrackResources(ctx, item.Relationships.Tracks)
 	if err != nil {
 		return model.Playlist{}, nil, err
 	}
@@ -365,11 +432,11 @@ func (a *Apple) fetchPlaylistDetail(playlistID string) (model.Playlist, []model.
 	return pl, songs, nil
 }
 
-func (a *Apple) fetchAllTrackResources(tracks appleTrackRelationship) ([]appleResource, error) {
+func (a *Apple) fetchAllTrackResources(ctx context.Context, tracks appleTrackRelationship) ([]appleResource, error) {
 	all := append([]appleResource(nil), tracks.Data...)
 	nextURI := strings.TrimSpace(tracks.Next)
 	for nextURI != "" {
-		body, err := a.ampGetRaw(nextURI)
+		body, err := a.ampGetRaw(ctx, nextURI)
 		if err != nil {
 			return nil, err
 		}
@@ -386,6 +453,11 @@ func (a *Apple) fetchAllTrackResources(tracks appleTrackRelationship) ([]appleRe
 
 // Parse parses a single song link.
 func (a *Apple) Parse(link string) (*model.Song, error) {
+	return a.ParseContext(context.Background(), link)
+}
+
+// ParseContext is like Parse but carries ctx through its requests.
+func (a *Apple) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	songID := extractAppleID(link, "song")
 	if songID == "" {
 		return nil, fmt.Errorf("invalid apple music song link: %s", link)
@@ -396,7 +468,7 @@ func (a *Apple) Parse(link string) (*model.Song, error) {
 	params.Set("include", "lyrics,albums")
 
 	uri := fmt.Sprintf("/v1/catalog/%s/songs/%s", a.storefront, songID)
-	body, err := a.ampGet(uri, params)
+	body, err := a.ampGet(ctx, uri, params)
 	if err != nil {
 		return nil, err
 	}
@@ -406,7 +478,7 @@ func (a *Apple) Parse(link string) (*model.Song, error) {
 		return nil, fmt.Errorf("apple song json error: %w", err)
 	}
 	if len(resp.Data) == 0 {
-		return nil, fmt.Errorf("apple song not found: %s", songID)
+		return nil, model.Errorf(model.ErrNotFound, "apple song not found: %s", songID)
 	}
 
 	song := appleSongFromCatalog(resp.Data[0])
@@ -415,6 +487,11 @@ func (a *Apple) Parse(link string) (*model.Song, error) {
 
 // GetDownloadURL returns the preview URL (full download requires DRM decryption via gamdl).
 func (a *Apple) GetDownloadURL(s *model.Song) (string, error) {
+	return a.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext is like GetDownloadURL but carries ctx through its requests.
+func (a *Apple) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s == nil {
 		return "", fmt.Errorf("song is nil")
 	}
@@ -422,24 +499,44 @@ func (a *Apple) GetDownloadURL(s *model.Song) (string, error) {
 		return s.URL, nil
 	}
 	// Fetch song to get preview URL
-	song, err := a.Parse(s.ID)
+	song, err := a.ParseContext(ctx, s.ID)
 	if err != nil {
 		return "", err
 	}
 	if song.URL == "" {
-		return "", fmt.Errorf("apple music: no preview URL available (full download requires gamdl)")
+		return "", model.Errorf(model.ErrNotFound, "apple music: no preview URL available (full download requires gamdl)")
 	}
 	return song.URL, nil
 }
 
 // GetLyrics fetches lyrics for a song.
+// Apple Music 返回的 TTML 会转换为与其他平台相同的逐字 LRC。
 func (a *Apple) GetLyrics(s *model.Song) (string, error) {
+	return a.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (a *Apple) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	l, err := a.GetLyricsDataContext(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	return l.LRC(), nil
+}
+
+// GetLyricsData 获取结构化歌词，保留 TTML 中的逐字时间轴、演唱者以及翻译、音译轨道
+func (a *Apple) GetLyricsData(s *model.Song) (*lyrics.Lyrics, error) {
+	return a.GetLyricsDataContext(context.Background(), s)
+}
+
+// GetLyricsDataContext is like GetLyricsData but carries ctx through its requests.
+func (a *Apple) GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error) {
 	if s == nil {
-		return "", fmt.Errorf("song is nil")
+		return nil, fmt.Errorf("song is nil")
 	}
 	songID := s.ID
 	if songID == "" {
-		return "", fmt.Errorf("song id is empty")
+		return nil, fmt.Errorf("song id is empty")
 	}
 
 	params := url.Values{}
@@ -447,31 +544,41 @@ func (a *Apple) GetLyrics(s *model.Song) (string, error) {
 	params.Set("extend", "extendedAssetUrls")
 
 	uri := fmt.Sprintf("/v1/catalog/%s/songs/%s", a.storefront, songID)
-	body, err := a.ampGet(uri, params)
+	body, err := a.ampGet(ctx, uri, params)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var resp appleResourceResponse
 	if err := json.Unmarshal(body, &resp); err != nil {
-		return "", err
+		return nil, err
 	}
 	if len(resp.Data) == 0 {
-		return "", nil
+		return nil, model.Errorf(model.ErrNotFound, "apple music: lyric is empty or not found")
 	}
 
 	for _, rel := range resp.Data[0].Relationships.Lyrics.Data {
-		if rel.Attributes.Text != "" {
-			return rel.Attributes.Text, nil
+		if rel.Attributes.Text == "" {
+			continue
+		}
+		l, err := lyrics.ParseTTML(rel.Attributes.Text)
+		if err != nil {
+			return nil, fmt.Errorf("apple music: parse ttml: %w", err)
+		}
+		for k, v := range map[string]string{"ti": s.Name, "ar": s.Artist, "al": s.Album} {
+			if v != "" {
+				l.Tags[k] = v
+			}
 		}
+		return l, nil
 	}
-	return "", nil
+	return nil, model.Errorf(model.ErrNotFound, "apple music: lyric is empty or not found")
 }
 
 // --- Token fetching ---
 
-func fetchAppleToken() (string, error) {
-	body, err := utils.Get(appleHomepageURL,
+func (a *Apple) fetchAppleToken(ctx context.Context) (string, error) {
+	body, err := a.client.GetContext(ctx, appleHomepageURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 	)
 	if err != nil {
@@ -486,7 +593,7 @@ func fetchAppleToken() (string, error) {
 	}
 
 	jsURL := appleHomepageURL + "/" + string(match[1])
-	jsBody, err := utils.Get(jsURL,
+	jsBody, err := a.client.GetContext(ctx, jsURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 	)
 	if err != nil {
@@ -1,6 +1,7 @@
 package apple
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"testing"
@@ -61,7 +62,7 @@ func TestAppleCuratorPlaylistFields(t *testing.T) {
 	params.Set("limit", "2")
 	params.Set("offset", "0")
 	params.Set("l", "zh-Hans-CN")
-	body, err := client.ampGet("/v1/catalog/cn/apple-curators/1019399551/playlists", params)
+	body, err := client.ampGet(context.Background(), "/v1/catalog/cn/apple-curators/1019399551/playlists", params)
 	if err != nil {
 		t.Fatalf("failed: %v", err)
 	}
@@ -0,0 +1,46 @@
+package apple
+
+import (
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func init() {
+	registry.Register(registry.Entry{
+		Name: "apple",
+		New: func(cookie string, opts ...utils.ClientOption) interface{} {
+			return New(cookie, opts...)
+		},
+		Capabilities: []provider.Capability{
+			provider.CapSearch,
+			provider.CapParse,
+			provider.CapDownload,
+			provider.CapLyrics,
+			provider.CapAlbum,
+			provider.CapPlaylist,
+			provider.CapPlaylistCategory,
+		},
+		Descriptor: provider.Descriptor{
+			Source: "apple",
+			Name:   "Apple Music",
+			Note:   "下载仅 preview，完整需 gamdl 解密",
+			Features: map[provider.Feature]provider.FeatureSupport{
+				provider.FeatureSearch:            {Level: provider.Supported},
+				provider.FeatureDownload:          {Level: provider.Unstable, Note: "仅 preview，完整需 gamdl 解密"},
+				provider.FeatureLyrics:            {Level: provider.Supported},
+				provider.FeatureSongParse:         {Level: provider.Supported},
+				provider.FeaturePlaylistSearch:    {Level: provider.Supported},
+				provider.FeaturePlaylistRecommend: {Level: provider.Unsupported},
+				provider.FeaturePlaylistSongs:     {Level: provider.Supported},
+				provider.FeaturePlaylistParse:     {Level: provider.Supported},
+				provider.FeatureAlbumSearch:       {Level: provider.Supported},
+				provider.FeatureAlbumSongs:        {Level: provider.Supported},
+				provider.FeatureAlbumParse:        {Level: provider.Supported},
+				provider.FeatureUserPlaylist:      {Level: provider.Unsupported},
+				provider.FeatureQRLogin:           {Level: provider.Unsupported},
+				provider.FeaturePlaylistCategory:  {Level: provider.Supported},
+			},
+		},
+	})
+}
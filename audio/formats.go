@@ -0,0 +1,434 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/guohuiyuan/music-lib/mp4"
+)
+
+// parseStreamInfo 解析 FLAC 的 STREAMINFO 块 (34 字节)
+func (p *prober) parseStreamInfo(b []byte) {
+	if len(b) < 18 {
+		return
+	}
+	rate := int(b[10])<<12 | int(b[11])<<4 | int(b[12])>>4
+	p.info.SampleRate = rate
+	p.info.Channels = int(b[12]>>1&0x7) + 1
+	p.info.BitDepth = int(b[12]&0x1)<<4 | int(b[13]>>4) + 1
+	samples := uint64(b[13]&0xF)<<32 | uint64(binary.BigEndian.Uint32(b[14:18]))
+	p.setDuration(samples, rate)
+}
+
+func (p *prober) flac() {
+	// "fLaC" 之后的第一个元数据块必须是 STREAMINFO
+	if len(p.head) >= 8 && p.head[4]&0x7F == 0 {
+		p.parseStreamInfo(p.head[8:])
+	}
+}
+
+var (
+	mpegBitrates = [2][3][16]int{
+		{ // MPEG-1 Layer I/II/III
+			{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+			{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+		},
+		{ // MPEG-2/2.5 Layer I/II/III
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		},
+	}
+	mpegSampleRates = [3][3]int{
+		{44100, 48000, 32000}, // MPEG-1
+		{22050, 24000, 16000}, // MPEG-2
+		{11025, 12000, 8000},  // MPEG-2.5
+	}
+)
+
+type mpegHeader struct {
+	mpeg1      bool
+	layer      int // 1-3
+	bitrate    int // kbps
+	sampleRate int
+	channels   int
+	samples    int // 每帧的采样数
+}
+
+// parseMPEGHeader 解析 MPEG 音频帧头，b 必须以帧同步字开始
+func parseMPEGHeader(b []byte) (mpegHeader, bool) {
+	var h mpegHeader
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return h, false
+	}
+	version := b[1] >> 3 & 0x3 // 0: 2.5, 2: 2, 3: 1
+	layerBits := b[1] >> 1 & 0x3
+	brIndex := b[2] >> 4
+	srIndex := b[2] >> 2 & 0x3
+	if version == 1 || layerBits == 0 || brIndex == 0xF || srIndex == 3 {
+		return h, false
+	}
+
+	h.mpeg1 = version == 3
+	h.layer = 4 - int(layerBits)
+	table := 1
+	if h.mpeg1 {
+		table = 0
+	}
+	h.bitrate = mpegBitrates[table][h.layer-1][brIndex]
+	switch version {
+	case 3:
+		h.sampleRate = mpegSampleRates[0][srIndex]
+	case 2:
+		h.sampleRate = mpegSampleRates[1][srIndex]
+	default:
+		h.sampleRate = mpegSampleRates[2][srIndex]
+	}
+	h.channels = 2
+	if b[3]>>6 == 3 {
+		h.channels = 1
+	}
+	switch {
+	case h.layer == 1:
+		h.samples = 384
+	case h.layer == 3 && !h.mpeg1:
+		h.samples = 576
+	default:
+		h.samples = 1152
+	}
+	return h, true
+}
+
+func (p *prober) mp3() {
+	// 跳过 ID3 标签之后可能存在的填充
+	var h mpegHeader
+	pos, ok := 0, false
+	for ; pos+4 <= len(p.head); pos++ {
+		if h, ok = parseMPEGHeader(p.head[pos:]); ok {
+			break
+		}
+	}
+	if !ok {
+		return
+	}
+	p.info.SampleRate = h.sampleRate
+	p.info.Channels = h.channels
+
+	frame := p.head[pos:]
+	// Xing/Info 位于 side info 之后，VBRI 固定位于帧头后 32 字节
+	side := 17
+	if h.mpeg1 && h.channels == 2 {
+		side = 32
+	} else if !h.mpeg1 && h.channels == 1 {
+		side = 9
+	}
+	if x := frame[minInt(len(frame), 4+side):]; len(x) >= 8 && (bytes.HasPrefix(x, []byte("Xing")) || bytes.HasPrefix(x, []byte("Info"))) {
+		flags := u32be(x[4:])
+		x = x[8:]
+		var frames, size int
+		if flags&0x1 != 0 && len(x) >= 4 {
+			frames = u32be(x)
+			x = x[4:]
+		}
+		if flags&0x2 != 0 && len(x) >= 4 {
+			size = u32be(x)
+		}
+		p.setVBR(h, frames, size)
+		return
+	}
+	if v := frame[minInt(len(frame), 36):]; len(v) >= 18 && bytes.HasPrefix(v, []byte("VBRI")) {
+		p.setVBR(h, u32be(v[14:]), u32be(v[10:]))
+		return
+	}
+
+	// 没有 VBR 头时按 CBR 计算
+	p.info.Bitrate = h.bitrate
+	if h.bitrate > 0 {
+		size := p.audioSize() - int64(pos)
+		if tag := readAt(p.r, p.size-128, 3, p.size); bytes.Equal(tag, []byte("TAG")) {
+			size -= 128
+		}
+		p.setDuration(uint64(size*8/int64(h.bitrate)), 1000)
+	}
+}
+
+func (p *prober) setVBR(h mpegHeader, frames, size int) {
+	if frames == 0 {
+		return
+	}
+	p.setDuration(uint64(frames)*uint64(h.samples), h.sampleRate)
+	if size > 0 {
+		p.dataSize = int64(size)
+	}
+}
+
+// oggCodec 按第一个数据包识别 Ogg 中的编码
+func oggCodec(head []byte) string {
+	packet := oggFirstPacket(head)
+	switch {
+	case bytes.HasPrefix(packet, []byte("OpusHead")):
+		return "opus"
+	case bytes.HasPrefix(packet, []byte("\x7fFLAC")):
+		return "flac"
+	case bytes.HasPrefix(packet, []byte("Speex   ")):
+		return "speex"
+	default:
+		return "vorbis"
+	}
+}
+
+func oggFirstPacket(head []byte) []byte {
+	if len(head) < 27 {
+		return nil
+	}
+	start := 27 + int(head[26])
+	if start > len(head) {
+		return nil
+	}
+	return head[start:]
+}
+
+func (p *prober) ogg() {
+	packet := oggFirstPacket(p.head)
+	preSkip := 0
+	switch p.info.Codec {
+	case "vorbis":
+		if len(packet) < 24 || !bytes.HasPrefix(packet, []byte("\x01vorbis")) {
+			return
+		}
+		p.info.Channels = int(packet[11])
+		p.info.SampleRate = u32le(packet[12:])
+	case "opus":
+		if len(packet) < 16 {
+			return
+		}
+		// Opus 的时间戳固定以 48kHz 计，SampleRate 返回编码前的原始采样率
+		p.info.Channels = int(packet[9])
+		preSkip = u16le(packet[10:])
+		p.info.SampleRate = u32le(packet[12:])
+	case "flac":
+		if len(packet) >= 51 {
+			p.parseStreamInfo(packet[17:])
+		}
+		return
+	default:
+		return
+	}
+
+	// 最后一页的 granule position 就是总采样数
+	start := p.size - headSize
+	if start < 0 {
+		start = 0
+	}
+	tail := readAt(p.r, start, headSize, p.size)
+	i := bytes.LastIndex(tail, []byte("OggS"))
+	if i < 0 || i+14 > len(tail) {
+		return
+	}
+	granule := int64(binary.LittleEndian.Uint64(tail[i+6:]))
+	if p.info.Codec == "opus" {
+		if granule -= int64(preSkip); granule > 0 {
+			p.setDuration(uint64(granule), 48000)
+		}
+		return
+	}
+	if granule > 0 {
+		p.setDuration(uint64(granule), p.info.SampleRate)
+	}
+}
+
+// mp4MaxMoov 是读取 moov 的上限，正常的音频文件远小于这个值
+const mp4MaxMoov = 64 << 20
+
+func (p *prober) mp4() {
+	var moov []byte
+	for pos := int64(0); pos+8 <= p.size; {
+		typ, size, hs, ok := mp4.Header(readAt(p.r, pos, 16, p.size))
+		if !ok {
+			return
+		}
+		if size == 0 {
+			size = uint64(p.size - pos)
+		}
+		switch typ {
+		case "moov":
+			if size > mp4MaxMoov {
+				return
+			}
+			moov = readAt(p.r, pos, int(size), p.size)
+		case "mdat":
+			p.dataSize = int64(size) - int64(hs)
+		}
+		pos += int64(size)
+	}
+	if moov == nil {
+		return
+	}
+
+	trak := soundTrack(moov)
+	if trak == nil {
+		return
+	}
+	if mdhd, err := mp4.FindPath(moov, trak.Offset, trak.End(), "trak", "mdia", "mdhd"); err == nil && len(mdhd.Data) >= 20 {
+		d := mdhd.Data
+		if d[0] == 1 {
+			if len(d) >= 32 {
+				p.setDuration(binary.BigEndian.Uint64(d[24:]), u32be(d[20:]))
+			}
+		} else {
+			p.setDuration(uint64(binary.BigEndian.Uint32(d[16:])), u32be(d[12:]))
+		}
+	}
+
+	stsd, err := mp4.FindPath(moov, trak.Offset, trak.End(), "trak", "mdia", "minf", "stbl", "stsd")
+	if err != nil || len(stsd.Data) < 8+36 {
+		return
+	}
+	entry := stsd.Data[8:]
+	entryType := string(entry[4:8])
+	switch entryType {
+	case "mp4a", "enca":
+		p.info.Codec = "aac"
+	case "alac":
+		p.info.Codec = "alac"
+	case "fLaC":
+		p.info.Codec = "flac"
+	default:
+		p.info.Codec = strings.ToLower(strings.TrimSpace(entryType))
+	}
+	p.info.Channels = u16be(entry[24:])
+	p.info.SampleRate = u32be(entry[32:]) >> 16
+	if p.info.Lossless() {
+		p.info.BitDepth = u16be(entry[26:])
+	}
+	// ALAC 的配置中记录了完整的采样率，16.16 定点数放不下 96kHz 以上的采样率
+	if alac, err := mp4.FindBox(entry, "alac", 36, len(entry)); p.info.Codec == "alac" && err == nil && len(alac.Data) >= 28 {
+		p.info.BitDepth = int(alac.Data[9])
+		p.info.Channels = int(alac.Data[13])
+		p.info.SampleRate = u32be(alac.Data[24:])
+	}
+}
+
+// soundTrack 返回 handler 为 soun 的 trak，找不到时返回第一个 trak
+func soundTrack(moov []byte) *mp4.Box {
+	var first *mp4.Box
+	for pos := 8; pos < len(moov); {
+		trak, err := mp4.FindBox(moov, "trak", pos, len(moov))
+		if err != nil {
+			break
+		}
+		if first == nil {
+			first = trak
+		}
+		if hdlr, err := mp4.FindPath(moov, trak.Offset, trak.End(), "trak", "mdia", "hdlr"); err == nil && len(hdlr.Data) >= 12 && string(hdlr.Data[8:12]) == "soun" {
+			return trak
+		}
+		pos = trak.End()
+	}
+	return first
+}
+
+func (p *prober) wav() {
+	byteRate := 0
+	for pos := 12; pos+8 <= len(p.head); {
+		id := string(p.head[pos : pos+4])
+		size := u32le(p.head[pos+4:])
+		body := p.head[pos+8:]
+		switch id {
+		case "fmt ":
+			if len(body) >= 16 {
+				p.info.Channels = u16le(body[2:])
+				p.info.SampleRate = u32le(body[4:])
+				byteRate = u32le(body[8:])
+				p.info.BitDepth = u16le(body[14:])
+				if format := u16le(body); format == 3 {
+					p.info.Codec = "pcm_float"
+				}
+			}
+		case "data":
+			p.dataSize = int64(size)
+			if byteRate > 0 {
+				p.info.Bitrate = byteRate * 8 / 1000
+				p.setDuration(uint64(size), byteRate)
+			}
+			return
+		}
+		pos += 8 + size + size&1
+	}
+}
+
+func (p *prober) ape() {
+	h := p.head
+	if len(h) < 6 || u16le(h[4:]) < 3980 {
+		// 旧版本的文件头布局不同，只识别格式
+		return
+	}
+	if len(h) < 52 {
+		return
+	}
+	desc := u32le(h[8:])
+	if desc+24 > len(h) {
+		return
+	}
+	hdr := h[desc:]
+	blocksPerFrame := uint64(u32le(hdr[4:]))
+	finalBlocks := uint64(u32le(hdr[8:]))
+	frames := uint64(u32le(hdr[12:]))
+	p.info.BitDepth = u16le(hdr[16:])
+	p.info.Channels = u16le(hdr[18:])
+	p.info.SampleRate = u32le(hdr[20:])
+	if frames > 0 {
+		p.setDuration((frames-1)*blocksPerFrame+finalBlocks, p.info.SampleRate)
+	}
+}
+
+var adtsSampleRates = []int{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+
+func isADTS(b []byte) bool {
+	return len(b) >= 7 && b[0] == 0xFF && b[1]&0xF6 == 0xF0 && int(b[2]>>2&0xF) < len(adtsSampleRates)
+}
+
+func (p *prober) adts() {
+	h := p.head
+	p.info.SampleRate = adtsSampleRates[h[2]>>2&0xF]
+	p.info.Channels = int(h[2]&0x1)<<2 | int(h[3]>>6)
+
+	// 按文件头中的帧估算平均码率，每帧 1024 个采样
+	frames, bytesRead := 0, 0
+	for pos := 0; pos+7 <= len(h) && isADTS(h[pos:]); {
+		n := int(h[pos+3]&0x3)<<11 | int(h[pos+4])<<3 | int(h[pos+5]>>5)
+		if n < 7 {
+			break
+		}
+		frames++
+		bytesRead += n
+		pos += n
+	}
+	if frames == 0 {
+		return
+	}
+	p.info.Bitrate = bytesRead * 8 * p.info.SampleRate / 1024 / frames / 1000
+	if p.info.Bitrate > 0 {
+		p.setDuration(uint64(p.audioSize()*8), p.info.Bitrate*1000)
+	}
+}
+
+func (p *prober) dsf() {
+	h := p.head
+	if len(h) < 80 || string(h[28:32]) != "fmt " {
+		return
+	}
+	p.info.Channels = u32le(h[52:])
+	p.info.SampleRate = u32le(h[56:])
+	p.info.BitDepth = 1
+	p.setDuration(binary.LittleEndian.Uint64(h[64:]), p.info.SampleRate)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,217 @@
+// Package audio 按文件内容识别音频格式，并读取采样率、位深、声道数、时长和码率等流信息，
+// 可以用来核对下载或解密得到的文件是否与 model.Song 中的 Ext、Bitrate 一致。
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+// ErrUnknownFormat 表示无法从文件内容识别音频格式
+var ErrUnknownFormat = errors.New("unknown audio format")
+
+// ErrMismatch 表示实际文件与歌曲信息中的格式或码率不符
+var ErrMismatch = errors.New("audio does not match song")
+
+// Info 是从文件内容中读取的音频信息，无法读取的字段为零值
+type Info struct {
+	// Ext 推荐的扩展名：mp3、flac、ogg、m4a、wav、ape、aac、dsf
+	Ext string
+	// Codec 编码：mp3、flac、vorbis、opus、aac、alac、pcm、ape、dsd 等
+	Codec      string
+	SampleRate int
+	// BitDepth 采样位深，有损编码为 0
+	BitDepth int
+	Channels int
+	Duration time.Duration
+	// Bitrate 平均码率 (kbps)，与 model.Song.Bitrate 单位相同
+	Bitrate int
+}
+
+// Lossless 判断编码是否为无损格式
+func (i *Info) Lossless() bool {
+	switch i.Codec {
+	case "flac", "alac", "pcm", "ape", "dsd":
+		return true
+	}
+	return false
+}
+
+// Verify 核对实际文件与歌曲信息：扩展名不同，或有损格式的实际码率明显低于标称码率时返回 ErrMismatch。
+// 歌曲信息中为空的字段不参与比较。
+func (i *Info) Verify(s *model.Song) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ext != "" && s.Ext != i.Ext {
+		return fmt.Errorf("%w: got %s, want %s", ErrMismatch, i.Ext, s.Ext)
+	}
+	// 标称码率通常是档位 (128/320)，VBR 文件的平均码率会有浮动，只检查明显的降级
+	if s.Bitrate > 0 && i.Bitrate > 0 && !i.Lossless() && i.Bitrate*10 < s.Bitrate*8 {
+		return fmt.Errorf("%w: got %d kbps, want %d kbps", ErrMismatch, i.Bitrate, s.Bitrate)
+	}
+	return nil
+}
+
+// DetectExt 按文件头识别扩展名，无法识别时返回空字符串。
+// head 越长越可靠：MP3 开头的 ID3 标签较大时，需要包含标签之后的第一帧才能区分 ID3 + FLAC。
+func DetectExt(head []byte) string {
+	if bytes.HasPrefix(head, []byte("ID3")) {
+		n := id3Size(head)
+		if n > 0 && n+4 <= len(head) && bytes.HasPrefix(head[n:], []byte("fLaC")) {
+			return "flac"
+		}
+		return "mp3"
+	}
+	ext, _ := detect(head)
+	return ext
+}
+
+// headSize 是读取流信息时从音频开头读取的数据量
+const headSize = 64 << 10
+
+// Probe 识别 data 的格式并读取流信息
+func Probe(data []byte) (*Info, error) {
+	return ProbeReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// ProbeFile 与 Probe 相同，只读取文件头尾和 MP4 的 moov，不会把整个文件读入内存
+func ProbeFile(path string) (*Info, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ProbeReader(f, st.Size())
+}
+
+// ProbeReader 从大小为 size 的 r 中识别格式并读取流信息
+func ProbeReader(r io.ReaderAt, size int64) (*Info, error) {
+	offset := int64(0)
+	if head := readAt(r, 0, 10, size); bytes.HasPrefix(head, []byte("ID3")) {
+		offset = int64(id3Size(head))
+	}
+	head := readAt(r, offset, headSize, size)
+
+	ext, codec := detect(head)
+	if ext == "" && offset > 0 {
+		// ID3 标签后面不是能识别的帧头，仍按 MP3 处理
+		ext, codec = "mp3", "mp3"
+	}
+	if ext == "" {
+		return nil, ErrUnknownFormat
+	}
+
+	info := &Info{Ext: ext, Codec: codec}
+	p := &prober{r: r, size: size, offset: offset, head: head, info: info}
+	switch ext {
+	case "flac":
+		p.flac()
+	case "mp3":
+		p.mp3()
+	case "ogg":
+		p.ogg()
+	case "m4a":
+		p.mp4()
+	case "wav":
+		p.wav()
+	case "ape":
+		p.ape()
+	case "aac":
+		p.adts()
+	case "dsf":
+		p.dsf()
+	}
+	if info.Bitrate == 0 && info.Duration > 0 {
+		info.Bitrate = int(float64(p.audioSize()) * 8 / info.Duration.Seconds() / 1000)
+	}
+	return info, nil
+}
+
+// detect 识别不带 ID3 标签的文件头，返回扩展名和编码
+func detect(head []byte) (string, string) {
+	switch {
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return "flac", "flac"
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return "ogg", oggCodec(head)
+	case len(head) >= 8 && bytes.Equal(head[4:8], []byte("ftyp")):
+		return "m4a", "aac"
+	case len(head) >= 12 && bytes.HasPrefix(head, []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		return "wav", "pcm"
+	case bytes.HasPrefix(head, []byte("MAC ")):
+		return "ape", "ape"
+	case bytes.HasPrefix(head, []byte("DSD ")):
+		return "dsf", "dsd"
+	case isADTS(head):
+		return "aac", "aac"
+	}
+	if _, ok := parseMPEGHeader(head); ok {
+		return "mp3", "mp3"
+	}
+	return "", ""
+}
+
+// id3Size 返回 ID3v2 标签的总长度 (含标签头和可选的标签尾)
+func id3Size(head []byte) int {
+	if len(head) < 10 || !bytes.HasPrefix(head, []byte("ID3")) {
+		return 0
+	}
+	n := int(head[6]&0x7F)<<21 | int(head[7]&0x7F)<<14 | int(head[8]&0x7F)<<7 | int(head[9]&0x7F)
+	n += 10
+	if head[5]&0x10 != 0 {
+		n += 10
+	}
+	return n
+}
+
+func readAt(r io.ReaderAt, offset int64, n int, size int64) []byte {
+	if offset >= size {
+		return nil
+	}
+	if rest := size - offset; int64(n) > rest {
+		n = int(rest)
+	}
+	buf := make([]byte, n)
+	n, _ = r.ReadAt(buf, offset)
+	return buf[:n]
+}
+
+type prober struct {
+	r      io.ReaderAt
+	size   int64
+	offset int64 // 音频数据 (ID3 标签之后) 的起始位置
+	head   []byte
+	info   *Info
+	// dataSize 音频数据的实际大小，格式中没有记录时为 0
+	dataSize int64
+}
+
+func (p *prober) audioSize() int64 {
+	if p.dataSize > 0 {
+		return p.dataSize
+	}
+	return p.size - p.offset
+}
+
+func (p *prober) setDuration(samples uint64, rate int) {
+	if rate > 0 && samples > 0 {
+		p.info.Duration = time.Duration(float64(samples) / float64(rate) * float64(time.Second))
+	}
+}
+
+func u16be(b []byte) int { return int(binary.BigEndian.Uint16(b)) }
+func u32be(b []byte) int { return int(binary.BigEndian.Uint32(b)) }
+func u16le(b []byte) int { return int(binary.LittleEndian.Uint16(b)) }
+func u32le(b []byte) int { return int(binary.LittleEndian.Uint32(b)) }
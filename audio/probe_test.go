@@ -0,0 +1,223 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+func le32(v int) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(v))
+	return b
+}
+
+func be32(v int) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(v))
+	return b
+}
+
+func box(typ string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	return append(append(be32(len(body)+8), typ...), body...)
+}
+
+// buildFLAC 生成 44.1kHz/24bit/2 声道、时长 10 秒的 FLAC 文件头
+func buildFLAC() []byte {
+	info := make([]byte, 34)
+	rate, samples := 44100, 441000
+	info[10] = byte(rate >> 12)
+	info[11] = byte(rate >> 4)
+	info[12] = byte(rate<<4) | 1<<1 | (24-1)>>4
+	info[13] = byte((24-1)&0xF) << 4
+	binary.BigEndian.PutUint32(info[14:], uint32(samples))
+	data := append([]byte("fLaC\x80\x00\x00\x22"), info...)
+	return append(data, make([]byte, 1000000)...)
+}
+
+func TestDetectExt(t *testing.T) {
+	id3 := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x02\x00\x00"), "fLaC"...)
+	cases := []struct {
+		head []byte
+		want string
+	}{
+		{[]byte("fLaC\x00\x00\x00\x22"), "flac"},
+		{[]byte("ID3\x04\x00\x00\x00\x00\x00\x00"), "mp3"},
+		{id3, "flac"},
+		{[]byte{0xFF, 0xFB, 0x90, 0x64}, "mp3"},
+		{[]byte{0xFF, 0xF1, 0x50, 0x80, 0x2E, 0x7F, 0xFC}, "aac"},
+		{[]byte("\x00\x00\x00\x20ftypM4A "), "m4a"},
+		{[]byte("RIFF\x24\x00\x00\x00WAVEfmt "), "wav"},
+		{[]byte("MAC \x96\x0f"), "ape"},
+		{[]byte("DSD \x1c\x00\x00\x00"), "dsf"},
+		{[]byte("OggS"), "ogg"},
+		{[]byte("<html>"), ""},
+	}
+	for _, c := range cases {
+		if got := DetectExt(c.head); got != c.want {
+			t.Errorf("DetectExt(%q) = %q, want %q", c.head, got, c.want)
+		}
+	}
+}
+
+func TestProbeFLAC(t *testing.T) {
+	info, err := Probe(buildFLAC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Ext != "flac" || info.SampleRate != 44100 || info.BitDepth != 24 || info.Channels != 2 || info.Duration != 10*time.Second {
+		t.Fatalf("unexpected info %+v", info)
+	}
+	if info.Bitrate != 800 {
+		t.Fatalf("bitrate = %d, want 800", info.Bitrate)
+	}
+}
+
+func TestProbeMP3(t *testing.T) {
+	// MPEG-1 Layer III, 320kbps, 44.1kHz, 立体声
+	frame := make([]byte, 1044)
+	copy(frame, []byte{0xFF, 0xFB, 0xE0, 0x00})
+	cbr := append([]byte("ID3\x04\x00\x00\x00\x00\x01\x00"), make([]byte, 128)...)
+	for i := 0; i < 100; i++ {
+		cbr = append(cbr, frame...)
+	}
+	info, err := Probe(cbr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Ext != "mp3" || info.Bitrate != 320 || info.SampleRate != 44100 || info.Channels != 2 {
+		t.Fatalf("unexpected cbr info %+v", info)
+	}
+	if want := time.Duration(104400*8/320) * time.Millisecond; info.Duration != want {
+		t.Fatalf("cbr duration = %v, want %v", info.Duration, want)
+	}
+
+	// 带 Xing 头的 VBR：1000 帧 * 1152 / 44100 ≈ 26.1 秒，共 2MB
+	xing := append([]byte(nil), frame...)
+	copy(xing[4+32:], "Xing")
+	copy(xing[4+32+4:], be32(3))
+	copy(xing[4+32+8:], be32(1000))
+	copy(xing[4+32+12:], be32(2<<20))
+	info, err = Probe(xing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Duration != time.Duration(1000*1152*int64(time.Second)/44100) || info.Bitrate != 642 {
+		t.Fatalf("unexpected vbr info %+v", info)
+	}
+}
+
+func TestProbeMP4(t *testing.T) {
+	mdhd := make([]byte, 24)
+	copy(mdhd[12:], be32(44100))
+	copy(mdhd[16:], be32(44100*60))
+
+	entry := make([]byte, 28)
+	copy(entry[16:], []byte{0, 2, 0, 16})
+	copy(entry[24:], be32(44100<<16))
+	alac := make([]byte, 28)
+	alac[9] = 24
+	alac[13] = 2
+	copy(alac[24:], be32(96000))
+	stsd := box("stsd", make([]byte, 4), be32(1), box("alac", entry, box("alac", alac)))
+
+	hdlr := append(make([]byte, 8), "soun"...)
+	trak := box("trak", box("mdia", box("mdhd", mdhd), box("hdlr", hdlr, make([]byte, 12)), box("minf", box("stbl", stsd))))
+	data := append(box("ftyp", []byte("M4A \x00\x00\x00\x00")), box("moov", trak)...)
+	data = append(data, box("mdat", make([]byte, 7500000))...)
+
+	info, err := Probe(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Ext != "m4a" || info.Codec != "alac" || info.SampleRate != 96000 || info.BitDepth != 24 || info.Channels != 2 {
+		t.Fatalf("unexpected info %+v", info)
+	}
+	if info.Duration != time.Minute || info.Bitrate != 1000 {
+		t.Fatalf("duration %v, bitrate %d", info.Duration, info.Bitrate)
+	}
+}
+
+func TestProbeWAV(t *testing.T) {
+	fmtChunk := []byte{1, 0, 2, 0}
+	fmtChunk = append(fmtChunk, le32(48000)...)
+	fmtChunk = append(fmtChunk, le32(48000*4)...)
+	fmtChunk = append(fmtChunk, 4, 0, 16, 0)
+	data := append([]byte("RIFF\x00\x00\x00\x00WAVEfmt "), le32(len(fmtChunk))...)
+	data = append(data, fmtChunk...)
+	data = append(append(data, "data"...), le32(48000*4*2)...)
+
+	info, err := Probe(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Codec != "pcm" || info.SampleRate != 48000 || info.BitDepth != 16 || info.Duration != 2*time.Second || info.Bitrate != 1536 {
+		t.Fatalf("unexpected info %+v", info)
+	}
+}
+
+func TestProbeOpus(t *testing.T) {
+	page := func(granule int, packet []byte) []byte {
+		p := append([]byte("OggS\x00\x02"), make([]byte, 8)...)
+		binary.LittleEndian.PutUint64(p[6:], uint64(granule))
+		p = append(p, make([]byte, 12)...)
+		p = append(p, 1, byte(len(packet)))
+		return append(p, packet...)
+	}
+	head := append([]byte("OpusHead\x01\x02"), 0x38, 0x01)
+	head = append(head, le32(44100)...)
+	data := append(page(0, append(head, 0, 0, 0)), page(48000*3+312, []byte("audio"))...)
+
+	info, err := Probe(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Ext != "ogg" || info.Codec != "opus" || info.Channels != 2 || info.SampleRate != 44100 || info.Duration != 3*time.Second {
+		t.Fatalf("unexpected info %+v", info)
+	}
+}
+
+func TestProbeDSF(t *testing.T) {
+	data := append([]byte("DSD "), make([]byte, 24)...)
+	fmtChunk := append([]byte("fmt "), make([]byte, 48)...)
+	copy(fmtChunk[24:], le32(2))
+	copy(fmtChunk[28:], le32(2822400))
+	binary.LittleEndian.PutUint64(fmtChunk[36:], 2822400*5)
+	data = append(data, fmtChunk...)
+
+	info, err := Probe(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Codec != "dsd" || info.Channels != 2 || info.SampleRate != 2822400 || info.Duration != 5*time.Second {
+		t.Fatalf("unexpected info %+v", info)
+	}
+}
+
+func TestProbeUnknown(t *testing.T) {
+	if _, err := Probe([]byte("<html>403</html>")); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestInfoVerify(t *testing.T) {
+	mp3 := &Info{Ext: "mp3", Codec: "mp3", Bitrate: 128}
+	if err := mp3.Verify(&model.Song{Ext: "mp3", Bitrate: 320}); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("expected bitrate mismatch, got %v", err)
+	}
+	if err := mp3.Verify(&model.Song{Ext: "flac"}); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("expected ext mismatch, got %v", err)
+	}
+	if err := mp3.Verify(&model.Song{Ext: "mp3", Bitrate: 128}); err != nil {
+		t.Fatal(err)
+	}
+	flac := &Info{Ext: "flac", Codec: "flac", Bitrate: 700}
+	if err := flac.Verify(&model.Song{Ext: "flac", Bitrate: 1411}); err != nil {
+		t.Fatalf("lossless bitrate should not be compared: %v", err)
+	}
+}
@@ -1,12 +1,18 @@
 package bilibili
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/guohuiyuan/music-lib/utils"
 )
 
 // IsVipAccount 检测 Bilibili 账号是否为大会员
 func (b *Bilibili) IsVipAccount() (bool, error) {
+	return b.IsVipAccountContext(context.Background())
+}
+
+// IsVipAccountContext is like IsVipAccount but carries ctx through its requests.
+func (b *Bilibili) IsVipAccountContext(ctx context.Context) (bool, error) {
 	if b.isVipCache != nil {
 		return *b.isVipCache, nil
 	}
@@ -18,7 +24,7 @@ func (b *Bilibili) IsVipAccount() (bool, error) {
 	}
 
 	apiURL := "https://api.bilibili.com/x/web-interface/nav"
-	body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
+	body, err := b.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
 	if err != nil {
 		return false, err
 	}
@@ -1,6 +1,7 @@
 package bilibili
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,20 +20,34 @@ const (
 type Bilibili struct {
 	cookie     string
 	isVipCache *bool
+	client     *utils.Client
 }
 
 // New 初始化函数
-func New(cookie string) *Bilibili {
+func New(cookie string, opts ...utils.ClientOption) *Bilibili {
 	if cookie == "" {
 		cookie = "buvid3=2E109C72-251F-3827-FA8E-921FA0D7EC5291319infoc; SESSDATA=your_sessdata;"
 	}
 	return &Bilibili{
 		cookie: cookie,
+		client: utils.NewClient(opts...),
 	}
 }
 
 var defaultBilibili = New("buvid3=2E109C72-251F-3827-FA8E-921FA0D7EC5291319infoc; SESSDATA=your_sessdata;")
 
+// apiErrorKinds B 站接口 code 字段对应的错误分类
+var apiErrorKinds = map[int]error{
+	-101:   model.ErrAuthExpired,   // 账号未登录
+	-404:   model.ErrNotFound,      // 啥都木有
+	-412:   model.ErrRateLimited,   // 请求被拦截
+	-10403: model.ErrRegionBlocked, // 地区限制
+	62002:  model.ErrNotFound,      // 稿件不可见
+	62004:  model.ErrNotFound,      // 稿件审核中
+	87007:  model.ErrVIPRequired,   // 充电专属
+	87008:  model.ErrVIPRequired,
+}
+
 type bilibiliViewResponse struct {
 	Data struct {
 		BVID  string `json:"bvid"`
@@ -215,7 +230,7 @@ func needsSeasonArchiveFallback(sections []bilibiliSeasonSection) bool {
 	return false
 }
 
-func (b *Bilibili) fetchSeasonArchiveIndex(mid, seasonID int64) (map[string]bilibiliSeasonArchiveMeta, string, string, error) {
+func (b *Bilibili) fetchSeasonArchiveIndex(ctx context.Context, mid, seasonID int64) (map[string]bilibiliSeasonArchiveMeta, string, string, error) {
 	if seasonID == 0 || mid == 0 {
 		return nil, "", "", errors.New("invalid season info")
 	}
@@ -228,7 +243,7 @@ func (b *Bilibili) fetchSeasonArchiveIndex(mid, seasonID int64) (map[string]bili
 	pageSize := 30
 	for {
 		apiURL := fmt.Sprintf("https://api.bilibili.com/x/space/ugc/season?mid=%d&season_id=%d&page_num=%d&page_size=%d", mid, seasonID, pageNum, pageSize)
-		body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
+		body, err := b.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
 		if err != nil {
 			return nil, "", "", err
 		}
@@ -238,7 +253,7 @@ func (b *Bilibili) fetchSeasonArchiveIndex(mid, seasonID int64) (map[string]bili
 			return nil, "", "", err
 		}
 		if resp.Code != 0 {
-			return nil, "", "", fmt.Errorf("bilibili season api error: %d", resp.Code)
+			return nil, "", "", model.CodeError(apiErrorKinds, resp.Code, "bilibili season api error: %d", resp.Code)
 		}
 		if seasonTitle == "" {
 			seasonTitle = resp.Data.Season.Title
@@ -281,9 +296,9 @@ func normalizeCover(cover string) string {
 	return cover
 }
 
-func (b *Bilibili) fetchView(bvid string) (*bilibiliViewResponse, error) {
+func (b *Bilibili) fetchView(ctx context.Context, bvid string) (*bilibiliViewResponse, error) {
 	viewURL := fmt.Sprintf("https://api.bilibili.com/x/web-interface/view?bvid=%s", bvid)
-	viewBody, err := utils.Get(viewURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", b.cookie))
+	viewBody, err := b.client.GetContext(ctx, viewURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", b.cookie))
 	if err != nil {
 		return nil, err
 	}
@@ -295,9 +310,9 @@ func (b *Bilibili) fetchView(bvid string) (*bilibiliViewResponse, error) {
 	return &viewResp, nil
 }
 
-func (b *Bilibili) fetchPageList(bvid string) ([]bilibiliPage, error) {
+func (b *Bilibili) fetchPageList(ctx context.Context, bvid string) ([]bilibiliPage, error) {
 	pageURL := fmt.Sprintf("https://api.bilibili.com/x/player/pagelist?bvid=%s", bvid)
-	body, err := utils.Get(pageURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
+	body, err := b.client.GetContext(ctx, pageURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
 	if err != nil {
 		return nil, err
 	}
@@ -307,7 +322,7 @@ func (b *Bilibili) fetchPageList(bvid string) ([]bilibiliPage, error) {
 		return nil, err
 	}
 	if resp.Code != 0 {
-		return nil, fmt.Errorf("bilibili pagelist api error: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "bilibili pagelist api error: %d", resp.Code)
 	}
 	return resp.Data, nil
 }
@@ -342,7 +357,7 @@ func (b *Bilibili) buildSongsFromPages(bvid, rootTitle, author, cover string, pa
 	return songs
 }
 
-func (b *Bilibili) fetchSeasonSongs(mid, seasonID int64) ([]model.Song, error) {
+func (b *Bilibili) fetchSeasonSongs(ctx context.Context, mid, seasonID int64) ([]model.Song, error) {
 	if seasonID == 0 || mid == 0 {
 		return nil, errors.New("invalid season info")
 	}
@@ -355,7 +370,7 @@ func (b *Bilibili) fetchSeasonSongs(mid, seasonID int64) ([]model.Song, error) {
 	pageSize := 30
 	for {
 		apiURL := fmt.Sprintf("https://api.bilibili.com/x/space/ugc/season?mid=%d&season_id=%d&page_num=%d&page_size=%d", mid, seasonID, pageNum, pageSize)
-		body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
+		body, err := b.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
 		if err != nil {
 			return nil, err
 		}
@@ -365,7 +380,7 @@ func (b *Bilibili) fetchSeasonSongs(mid, seasonID int64) ([]model.Song, error) {
 			return nil, err
 		}
 		if resp.Code != 0 {
-			return nil, fmt.Errorf("bilibili season api error: %d", resp.Code)
+			return nil, model.CodeError(apiErrorKinds, resp.Code, "bilibili season api error: %d", resp.Code)
 		}
 		if seasonTitle == "" {
 			seasonTitle = resp.Data.Season.Title
@@ -412,7 +427,7 @@ func (b *Bilibili) fetchSeasonSongs(mid, seasonID int64) ([]model.Song, error) {
 }
 
 // fetchAudioURL 内部逻辑提取
-func (b *Bilibili) fetchAudioURL(bvid, cid string, isVip bool) (string, error) {
+func (b *Bilibili) fetchAudioURL(ctx context.Context, bvid, cid string, isVip bool) (string, error) {
 	fnval := 80
 	if isVip {
 		// 4048 allows requesting FLAC/Hi-Res/Dolby formats instead of default standard 80
@@ -420,13 +435,15 @@ func (b *Bilibili) fetchAudioURL(bvid, cid string, isVip bool) (string, error) {
 	}
 
 	apiURL := fmt.Sprintf("https://api.bilibili.com/x/player/playurl?fnval=%d&qn=127&bvid=%s&cid=%s", fnval, bvid, cid)
-	body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
+	body, err := b.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
 	if err != nil {
 		return "", err
 	}
 
 	var resp struct {
-		Data struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
 			Durl []struct {
 				URL string `json:"url"`
 			} `json:"durl"`
@@ -451,6 +468,9 @@ func (b *Bilibili) fetchAudioURL(bvid, cid string, isVip bool) (string, error) {
 		} `json:"data"`
 	}
 	json.Unmarshal(body, &resp)
+	if resp.Code != 0 {
+		return "", model.CodeError(apiErrorKinds, resp.Code, "bilibili playurl api error: %s (code %d)", resp.Message, resp.Code)
+	}
 
 	// 1. Highest Priority: Hi-Res FLAC format specifically marked by id 30251
 	if resp.Data.Dash.Flac.Audio.ID == 30251 && resp.Data.Dash.Flac.Audio.BaseURL != "" {
@@ -482,5 +502,5 @@ func (b *Bilibili) fetchAudioURL(bvid, cid string, isVip bool) (string, error) {
 		return resp.Data.Durl[0].URL, nil
 	}
 
-	return "", errors.New("no audio found")
+	return "", model.Errorf(model.ErrNotFound, "no audio found")
 }
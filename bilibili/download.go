@@ -1,6 +1,7 @@
 package bilibili
 
 import (
+	"context"
 	"errors"
 	"github.com/guohuiyuan/music-lib/model"
 	"strings"
@@ -8,10 +9,19 @@ import (
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultBilibili.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultBilibili.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL 获取下载链接
 func (b *Bilibili) GetDownloadURL(s *model.Song) (string, error) {
+	return b.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext 与 GetDownloadURL 相同，但请求受 ctx 控制。
+func (b *Bilibili) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "bilibili" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	if s.URL != "" {
@@ -34,6 +44,6 @@ func (b *Bilibili) GetDownloadURL(s *model.Song) (string, error) {
 		}
 	}
 
-	isVip, _ := b.IsVipAccount()
-	return b.fetchAudioURL(bvid, cid, isVip)
+	isVip, _ := b.IsVipAccountContext(ctx)
+	return b.fetchAudioURL(ctx, bvid, cid, isVip)
 }
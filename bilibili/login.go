@@ -1,6 +1,7 @@
 package bilibili
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,10 +22,23 @@ const (
 
 func CreateQRLogin() (*model.QRLoginSession, error) { return defaultBilibili.CreateQRLogin() }
 
+func CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
+	return defaultBilibili.CreateQRLoginContext(ctx)
+}
+
 func CheckQRLogin(key string) (*model.QRLoginResult, error) { return defaultBilibili.CheckQRLogin(key) }
 
+func CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
+	return defaultBilibili.CheckQRLoginContext(ctx, key)
+}
+
 func (b *Bilibili) CreateQRLogin() (*model.QRLoginSession, error) {
-	body, err := utils.Get(bilibiliQRGenerateAPI,
+	return b.CreateQRLoginContext(context.Background())
+}
+
+// CreateQRLoginContext is like CreateQRLogin but carries ctx through its requests.
+func (b *Bilibili) CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
+	body, err := b.client.GetContext(ctx, bilibiliQRGenerateAPI,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithRandomIPHeader(),
@@ -44,7 +58,7 @@ func (b *Bilibili) CreateQRLogin() (*model.QRLoginSession, error) {
 		return nil, fmt.Errorf("bilibili qr generate json parse error: %w", err)
 	}
 	if resp.Code != 0 || strings.TrimSpace(resp.Data.QRCodeKey) == "" {
-		return nil, fmt.Errorf("bilibili qr generate api error: code=%d message=%s", resp.Code, resp.Message)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "bilibili qr generate api error: code=%d message=%s", resp.Code, resp.Message)
 	}
 	return &model.QRLoginSession{
 		Source:    "bilibili",
@@ -55,19 +69,24 @@ func (b *Bilibili) CreateQRLogin() (*model.QRLoginSession, error) {
 }
 
 func (b *Bilibili) CheckQRLogin(key string) (*model.QRLoginResult, error) {
+	return b.CheckQRLoginContext(context.Background(), key)
+}
+
+// CheckQRLoginContext is like CheckQRLogin but carries ctx through its requests.
+func (b *Bilibili) CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
 	key = strings.TrimSpace(key)
 	if key == "" {
 		return nil, fmt.Errorf("bilibili qr login key is empty")
 	}
 	params := url.Values{}
 	params.Set("qrcode_key", key)
-	req, err := http.NewRequest("GET", bilibiliQRPollAPI+"?"+params.Encode(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", bilibiliQRPollAPI+"?"+params.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", UserAgent)
 	req.Header.Set("Referer", Referer)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := b.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
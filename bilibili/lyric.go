@@ -1,15 +1,24 @@
 package bilibili
 
 import (
-	"errors"
+	"context"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
 func GetLyrics(s *model.Song) (string, error) { return defaultBilibili.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultBilibili.GetLyricsContext(ctx, s)
+}
+
 func (b *Bilibili) GetLyrics(s *model.Song) (string, error) {
+	return b.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (b *Bilibili) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "bilibili" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	return "", nil
 }
@@ -1,6 +1,7 @@
 package bilibili
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,30 +17,65 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultBilibili.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultBilibili.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) { return defaultBilibili.GetPlaylistSongs(id) }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultBilibili.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultBilibili.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultBilibili.ParsePlaylistContext(ctx, link)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultBilibili.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultBilibili.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultBilibili.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultBilibili.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (b *Bilibili) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return b.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext is like GetPlaylistCategories but carries ctx through its requests.
+func (b *Bilibili) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 func (b *Bilibili) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return b.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext is like GetCategoryPlaylists but carries ctx through its requests.
+func (b *Bilibili) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 // SearchPlaylist 搜索合集/分P
 func (b *Bilibili) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return b.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext is like SearchPlaylist but carries ctx through its requests.
+func (b *Bilibili) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("search_type", "video")
 	params.Set("keyword", keyword)
@@ -47,7 +83,7 @@ func (b *Bilibili) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	params.Set("page_size", "20")
 
 	searchURL := "https://api.bilibili.com/x/web-interface/search/type?" + params.Encode()
-	body, err := utils.Get(searchURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
+	body, err := b.client.GetContext(ctx, searchURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +105,7 @@ func (b *Bilibili) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	plMap := make(map[string]bool)
 	var playlists []model.Playlist
 	for _, item := range searchResp.Data.Result {
-		viewResp, err := b.fetchView(item.BVID)
+		viewResp, err := b.fetchView(ctx, item.BVID)
 		if err != nil {
 			continue
 		}
@@ -95,7 +131,7 @@ func (b *Bilibili) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 			trackCount := countSeasonEpisodes(viewResp.Data.UgcSeason.Sections)
 			if trackCount == 0 && seasonID != 0 && mid != 0 {
-				seasonSongs, err := b.fetchSeasonSongs(mid, seasonID)
+				seasonSongs, err := b.fetchSeasonSongs(ctx, mid, seasonID)
 				if err == nil {
 					trackCount = len(seasonSongs)
 				}
@@ -147,6 +183,11 @@ func (b *Bilibili) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 // GetPlaylistSongs 获取合集/分P所有歌曲
 func (b *Bilibili) GetPlaylistSongs(id string) ([]model.Song, error) {
+	return b.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext is like GetPlaylistSongs but carries ctx through its requests.
+func (b *Bilibili) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
 	if strings.HasPrefix(id, "season:") {
 		parts := strings.Split(id, ":")
 		if len(parts) < 3 {
@@ -159,13 +200,13 @@ func (b *Bilibili) GetPlaylistSongs(id string) ([]model.Song, error) {
 			bvid = parts[3]
 		}
 		if bvid != "" {
-			viewResp, err := b.fetchView(bvid)
+			viewResp, err := b.fetchView(ctx, bvid)
 			if err == nil && viewResp.Data.UgcSeason != nil {
 				sections := viewResp.Data.UgcSeason.Sections
 				archiveIndex := map[string]bilibiliSeasonArchiveMeta{}
 				seasonTitle := viewResp.Data.UgcSeason.Title
 				seasonCover := viewResp.Data.UgcSeason.Cover
-				idx, sTitle, sCover, idxErr := b.fetchSeasonArchiveIndex(mid, seasonID)
+				idx, sTitle, sCover, idxErr := b.fetchSeasonArchiveIndex(ctx, mid, seasonID)
 				if idxErr == nil {
 					archiveIndex = idx
 					if seasonTitle == "" {
@@ -181,37 +222,42 @@ func (b *Bilibili) GetPlaylistSongs(id string) ([]model.Song, error) {
 				}
 			}
 		}
-		return b.fetchSeasonSongs(mid, seasonID)
+		return b.fetchSeasonSongs(ctx, mid, seasonID)
 	}
 
 	bvid := strings.TrimPrefix(id, "bvid:")
 	if bvid == "" {
 		return nil, errors.New("invalid playlist id")
 	}
-	viewResp, err := b.fetchView(bvid)
+	viewResp, err := b.fetchView(ctx, bvid)
 	if err != nil {
 		return nil, err
 	}
 	rootTitle := viewResp.Data.Title
 	pages := viewResp.Data.Pages
 	if len(pages) <= 1 {
-		if pageList, err := b.fetchPageList(bvid); err == nil && len(pageList) > 0 {
+		if pageList, err := b.fetchPageList(ctx, bvid); err == nil && len(pageList) > 0 {
 			pages = pageList
 		}
 	}
 	if len(pages) == 0 {
-		return nil, errors.New("no video pages found")
+		return nil, model.Errorf(model.ErrNotFound, "no video pages found")
 	}
 	return b.buildSongsFromPages(bvid, rootTitle, viewResp.Data.Owner.Name, viewResp.Data.Pic, pages), nil
 }
 
 // ParsePlaylist 解析合集/分P链接
 func (b *Bilibili) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return b.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext is like ParsePlaylist but carries ctx through its requests.
+func (b *Bilibili) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	bvidRe := regexp.MustCompile(`(BV\w+)`)
 	bvidMatches := bvidRe.FindStringSubmatch(link)
 	if len(bvidMatches) >= 2 {
 		bvid := bvidMatches[1]
-		viewResp, err := b.fetchView(bvid)
+		viewResp, err := b.fetchView(ctx, bvid)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -240,7 +286,7 @@ func (b *Bilibili) ParsePlaylist(link string) (*model.Playlist, []model.Song, er
 			archiveIndex := map[string]bilibiliSeasonArchiveMeta{}
 			seasonTitle := viewResp.Data.UgcSeason.Title
 			seasonCover := viewResp.Data.UgcSeason.Cover
-			idx, sTitle, sCover, idxErr := b.fetchSeasonArchiveIndex(mid, seasonID)
+			idx, sTitle, sCover, idxErr := b.fetchSeasonArchiveIndex(ctx, mid, seasonID)
 			if idxErr == nil {
 				archiveIndex = idx
 				if seasonTitle == "" {
@@ -258,7 +304,7 @@ func (b *Bilibili) ParsePlaylist(link string) (*model.Playlist, []model.Song, er
 			if len(songs) > 0 {
 				return playlist, songs, nil
 			}
-			songs, err := b.fetchSeasonSongs(mid, seasonID)
+			songs, err := b.fetchSeasonSongs(ctx, mid, seasonID)
 			return playlist, songs, err
 		}
 
@@ -0,0 +1,56 @@
+package bilibili
+
+import (
+	"net/http"
+
+	"github.com/guohuiyuan/music-lib/download"
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func init() {
+	registry.Register(registry.Entry{
+		Name: "bilibili",
+		New: func(cookie string, opts ...utils.ClientOption) interface{} {
+			return New(cookie, opts...)
+		},
+		Capabilities: []provider.Capability{
+			provider.CapSearch,
+			provider.CapParse,
+			provider.CapDownload,
+			provider.CapLyrics,
+			provider.CapPlaylist,
+			provider.CapQRLogin,
+		},
+		Descriptor: provider.Descriptor{
+			Source: "bilibili",
+			Name:   "Bilibili",
+			Note:   "支持 FLAC 无损",
+			Features: map[provider.Feature]provider.FeatureSupport{
+				provider.FeatureSearch:            {Level: provider.Supported},
+				provider.FeatureDownload:          {Level: provider.Supported},
+				provider.FeatureLyrics:            {Level: provider.Supported},
+				provider.FeatureSongParse:         {Level: provider.Supported},
+				provider.FeaturePlaylistSearch:    {Level: provider.Supported},
+				provider.FeaturePlaylistRecommend: {Level: provider.Unsupported},
+				provider.FeaturePlaylistSongs:     {Level: provider.Supported},
+				provider.FeaturePlaylistParse:     {Level: provider.Supported},
+				provider.FeatureAlbumSearch:       {Level: provider.Unsupported},
+				provider.FeatureAlbumSongs:        {Level: provider.Unsupported},
+				provider.FeatureAlbumParse:        {Level: provider.Unsupported},
+				provider.FeatureUserPlaylist:      {Level: provider.Unsupported},
+				provider.FeatureQRLogin:           {Level: provider.Supported},
+				provider.FeaturePlaylistCategory:  {Level: provider.Unsupported},
+			},
+		},
+	})
+
+	// B 站 CDN 会校验 Referer 和 UA，缺少时返回 403
+	download.RegisterProfile("bilibili", download.Profile{
+		Header: http.Header{
+			"User-Agent": {UserAgent},
+			"Referer":    {Referer},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package bilibili
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,10 +15,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultBilibili.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultBilibili.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultBilibili.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultBilibili.ParseContext(ctx, link)
+}
+
 // Search 搜索歌曲
 func (b *Bilibili) Search(keyword string) ([]model.Song, error) {
+	return b.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext 与 Search 相同，但请求受 ctx 控制。
+func (b *Bilibili) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("search_type", "video")
 	params.Set("keyword", keyword)
@@ -25,7 +39,7 @@ func (b *Bilibili) Search(keyword string) ([]model.Song, error) {
 	params.Set("page_size", "20")
 
 	searchURL := "https://api.bilibili.com/x/web-interface/search/type?" + params.Encode()
-	body, err := utils.Get(searchURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
+	body, err := b.client.GetContext(ctx, searchURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Referer", Referer), utils.WithHeader("Cookie", b.cookie))
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +62,7 @@ func (b *Bilibili) Search(keyword string) ([]model.Song, error) {
 	var songs []model.Song
 	for _, item := range searchResp.Data.Result {
 		rootTitle := cleanTitle(item.Title)
-		viewResp, err := b.fetchView(item.BVID)
+		viewResp, err := b.fetchView(ctx, item.BVID)
 		if err != nil || len(viewResp.Data.Pages) == 0 {
 			continue
 		}
@@ -82,6 +96,11 @@ func (b *Bilibili) Search(keyword string) ([]model.Song, error) {
 
 // Parse 解析链接并获取完整信息（包括下载链接）
 func (b *Bilibili) Parse(link string) (*model.Song, error) {
+	return b.ParseContext(context.Background(), link)
+}
+
+// ParseContext 与 Parse 相同，但请求受 ctx 控制。
+func (b *Bilibili) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	// 1. 提取 BVID
 	bvidRe := regexp.MustCompile(`(BV\w+)`)
 	bvidMatches := bvidRe.FindStringSubmatch(link)
@@ -101,7 +120,7 @@ func (b *Bilibili) Parse(link string) (*model.Song, error) {
 	}
 
 	// 3. 调用 View 接口获取元数据
-	viewResp, err := b.fetchView(bvid)
+	viewResp, err := b.fetchView(ctx, bvid)
 	if err != nil {
 		return nil, err
 	}
@@ -109,12 +128,12 @@ func (b *Bilibili) Parse(link string) (*model.Song, error) {
 		return nil, errors.New("playlist link detected")
 	}
 	if len(viewResp.Data.Pages) <= 1 {
-		if pages, err := b.fetchPageList(bvid); err == nil && len(pages) > 1 {
+		if pages, err := b.fetchPageList(ctx, bvid); err == nil && len(pages) > 1 {
 			return nil, errors.New("playlist link detected")
 		}
 	}
 	if len(viewResp.Data.Pages) == 0 {
-		return nil, errors.New("no video pages found")
+		return nil, model.Errorf(model.ErrNotFound, "no video pages found")
 	}
 
 	if page > len(viewResp.Data.Pages) {
@@ -137,8 +156,8 @@ func (b *Bilibili) Parse(link string) (*model.Song, error) {
 	cidStr := strconv.FormatInt(targetPage.CID, 10)
 
 	// 4. 立即获取下载链接
-	isVip, _ := b.IsVipAccount()
-	audioURL, _ := b.fetchAudioURL(bvid, cidStr, isVip) // 忽略错误，尽可能返回元数据
+	isVip, _ := b.IsVipAccountContext(ctx)
+	audioURL, _ := b.fetchAudioURL(ctx, bvid, cidStr, isVip) // 忽略错误，尽可能返回元数据
 
 	return &model.Song{
 		Source:   "bilibili",
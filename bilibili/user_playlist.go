@@ -1,6 +1,7 @@
 package bilibili
 
 import (
+	"context"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
@@ -8,6 +9,15 @@ func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultBilibili.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultBilibili.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 func (b *Bilibili) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return b.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (b *Bilibili) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrUserPlaylistsUnsupported
 }
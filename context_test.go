@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/guohuiyuan/music-lib/apple"
+	"github.com/guohuiyuan/music-lib/bilibili"
+	"github.com/guohuiyuan/music-lib/fivesing"
+	"github.com/guohuiyuan/music-lib/jamendo"
+	"github.com/guohuiyuan/music-lib/joox"
+	"github.com/guohuiyuan/music-lib/kugou"
+	"github.com/guohuiyuan/music-lib/kuwo"
+	"github.com/guohuiyuan/music-lib/migu"
+	"github.com/guohuiyuan/music-lib/netease"
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/qianqian"
+	"github.com/guohuiyuan/music-lib/qq"
+	"github.com/guohuiyuan/music-lib/soda"
+)
+
+var _ provider.MusicProviderContext = (*netease.Netease)(nil)
+var _ provider.MusicProviderContext = (*qq.QQ)(nil)
+var _ provider.MusicProviderContext = (*kugou.Kugou)(nil)
+var _ provider.MusicProviderContext = (*kuwo.Kuwo)(nil)
+var _ provider.MusicProviderContext = (*migu.Migu)(nil)
+var _ provider.MusicProviderContext = (*qianqian.Qianqian)(nil)
+var _ provider.MusicProviderContext = (*soda.Soda)(nil)
+var _ provider.MusicProviderContext = (*fivesing.Fivesing)(nil)
+var _ provider.MusicProviderContext = (*jamendo.Jamendo)(nil)
+var _ provider.MusicProviderContext = (*joox.Joox)(nil)
+var _ provider.MusicProviderContext = (*bilibili.Bilibili)(nil)
+var _ provider.MusicProviderContext = (*apple.Apple)(nil)
+
+var _ provider.PlaylistProviderContext = (*netease.Netease)(nil)
+var _ provider.PlaylistProviderContext = (*qq.QQ)(nil)
+var _ provider.PlaylistProviderContext = (*kugou.Kugou)(nil)
+var _ provider.PlaylistProviderContext = (*kuwo.Kuwo)(nil)
+var _ provider.PlaylistProviderContext = (*migu.Migu)(nil)
+var _ provider.PlaylistProviderContext = (*qianqian.Qianqian)(nil)
+var _ provider.PlaylistProviderContext = (*soda.Soda)(nil)
+var _ provider.PlaylistProviderContext = (*fivesing.Fivesing)(nil)
+var _ provider.PlaylistProviderContext = (*jamendo.Jamendo)(nil)
+var _ provider.PlaylistProviderContext = (*joox.Joox)(nil)
+var _ provider.PlaylistProviderContext = (*bilibili.Bilibili)(nil)
+var _ provider.PlaylistProviderContext = (*apple.Apple)(nil)
+
+var _ provider.AlbumProviderContext = (*netease.Netease)(nil)
+var _ provider.AlbumProviderContext = (*qq.QQ)(nil)
+var _ provider.AlbumProviderContext = (*kugou.Kugou)(nil)
+var _ provider.AlbumProviderContext = (*kuwo.Kuwo)(nil)
+var _ provider.AlbumProviderContext = (*migu.Migu)(nil)
+var _ provider.AlbumProviderContext = (*qianqian.Qianqian)(nil)
+var _ provider.AlbumProviderContext = (*soda.Soda)(nil)
+var _ provider.AlbumProviderContext = (*jamendo.Jamendo)(nil)
+var _ provider.AlbumProviderContext = (*joox.Joox)(nil)
+var _ provider.AlbumProviderContext = (*apple.Apple)(nil)
+
+var _ provider.PlaylistCategoryProviderContext = (*netease.Netease)(nil)
+var _ provider.UserPlaylistProviderContext = (*netease.Netease)(nil)
+var _ provider.QRLoginProviderContext = (*netease.Netease)(nil)
+var _ provider.QRLoginProviderContext = (*qq.QQ)(nil)
+var _ provider.QRLoginProviderContext = (*kugou.Kugou)(nil)
+var _ provider.QRLoginProviderContext = (*bilibili.Bilibili)(nil)
+var _ provider.FullMusicProviderContext = (*netease.Netease)(nil)
+var _ provider.FullMusicProviderContext = (*qq.QQ)(nil)
+var _ provider.FullMusicProviderContext = (*kugou.Kugou)(nil)
+var _ provider.FullMusicProviderContext = (*kuwo.Kuwo)(nil)
+
+var _ provider.StructuredLyricProvider = (*netease.Netease)(nil)
+var _ provider.StructuredLyricProvider = (*qq.QQ)(nil)
+var _ provider.StructuredLyricProvider = (*kugou.Kugou)(nil)
+var _ provider.StructuredLyricProvider = (*apple.Apple)(nil)
+var _ provider.StructuredLyricProviderContext = (*netease.Netease)(nil)
+var _ provider.StructuredLyricProviderContext = (*qq.QQ)(nil)
+var _ provider.StructuredLyricProviderContext = (*kugou.Kugou)(nil)
+var _ provider.StructuredLyricProviderContext = (*apple.Apple)(nil)
+
+func TestSearchContextCanceled(t *testing.T) {
+	searchers := map[string]provider.SongSearcherContext{
+		"netease":  netease.New(""),
+		"qq":       qq.New(""),
+		"kugou":    kugou.New(""),
+		"kuwo":     kuwo.New(""),
+		"migu":     migu.New(""),
+		"qianqian": qianqian.New(""),
+		"soda":     soda.New(""),
+		"fivesing": fivesing.New(""),
+		"jamendo":  jamendo.New(""),
+		"joox":     joox.New(""),
+		"bilibili": bilibili.New(""),
+		"apple":    apple.New(""),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for name, searcher := range searchers {
+		songs, err := searcher.SearchContext(ctx, "周杰伦")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got songs=%d err=%v", name, len(songs), err)
+		}
+	}
+}
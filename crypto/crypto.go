@@ -1,51 +1,194 @@
 package crypto
 
 import (
-	"bytes"
+	"bufio"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
 
+	"github.com/guohuiyuan/music-lib/audio"
+	"github.com/guohuiyuan/music-lib/kugou"
+	"github.com/guohuiyuan/music-lib/kuwo"
+	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/netease"
 	"github.com/guohuiyuan/music-lib/qq"
 )
 
+// Result 是解密后的音频
+type Result struct {
+	Data   []byte
+	Ext    string // 推荐输出扩展名
+	Source string // 来源平台
+	// Song 文件内嵌的歌曲信息 (目前只有 NCM 提供)，可用于补全标签或回查平台接口
+	Song *model.Song
+	// Cover 文件内嵌的封面图片，可能为空
+	Cover []byte
+}
+
 // DecryptByFilename 根据文件扩展名解密已购加密音频。
 // 返回值：解密后的音频数据、推荐输出扩展名、来源平台。
 func DecryptByFilename(filename string, encrypted []byte) ([]byte, string, string, error) {
+	res, err := Decrypt(filename, encrypted)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return res.Data, res.Ext, res.Source, nil
+}
+
+// Decrypt 与 DecryptByFilename 相同，但同时返回文件内嵌的歌曲信息和封面
+func Decrypt(filename string, encrypted []byte) (*Result, error) {
 	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
 
-	switch ext {
+	switch detectFormat(ext, encrypted) {
 	case "ncm":
-		plain, outExt, err := netease.DecryptNCM(encrypted)
+		return decryptNCM(encrypted)
+
+	case "kgm":
+		plain, outExt, err := kugou.DecryptKGM(encrypted)
 		if err != nil {
-			return nil, "", "", err
+			return nil, err
 		}
-		if outExt == "" {
-			outExt = "mp3"
+		return &Result{Data: plain, Ext: outExt, Source: "kugou"}, nil
+
+	case "kwm":
+		plain, outExt, err := kuwo.DecryptKWM(encrypted)
+		if err != nil {
+			return nil, err
 		}
-		return plain, outExt, "netease", nil
+		return &Result{Data: plain, Ext: outExt, Source: "kuwo"}, nil
 
-	case "qmc0", "qmc3", "qmcflac", "qmcogg", "bkcmp3", "bkcflac", "tkm", "mflac", "mgg":
+	case "qq":
 		plain, outExt, err := qq.DecryptQQ(encrypted, ext)
 		if err != nil {
-			return nil, "", "", err
+			return nil, err
+		}
+		return &Result{Data: plain, Ext: outExt, Source: "qq"}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported encrypted format: %s", ext)
+}
+
+// formatHeaderSize 是识别文件格式需要的文件头长度
+const formatHeaderSize = 16
+
+// detectFormat 优先按文件头识别格式，文件被改名后也能解密；
+// QQ 音乐的文件没有固定的文件头，只能按扩展名判断
+func detectFormat(ext string, head []byte) string {
+	switch {
+	case len(head) >= 8 && string(head[:8]) == "CTENFDAM":
+		return "ncm"
+	case kugou.IsKGM(head):
+		return "kgm"
+	case kuwo.IsKWM(head):
+		return "kwm"
+	}
+
+	switch ext {
+	case "ncm":
+		return "ncm"
+	case "kgm", "kgma", "vpr":
+		return "kgm"
+	case "kwm":
+		return "kwm"
+	case "qmc0", "qmc3", "qmcflac", "qmcogg", "bkcmp3", "bkcflac", "tkm", "mflac", "mflac0", "mgg", "mgg1", "mmp4":
+		return "qq"
+	}
+	return ""
+}
+
+func decryptNCM(encrypted []byte) (*Result, error) {
+	f, err := netease.DecryptNCMFile(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Data: f.Audio, Ext: f.Ext, Source: "netease", Cover: f.Cover}
+	if res.Ext == "" {
+		res.Ext = "mp3"
+	}
+	if f.Meta != nil {
+		res.Song = f.Meta.Song()
+		res.Song.Ext = res.Ext
+	}
+	return res, nil
+}
+
+// Stream 是边读边解密的音频流，字段含义与 Result 相同
+type Stream struct {
+	io.Reader
+	Ext    string
+	Source string
+	Song   *model.Song
+	Cover  []byte
+}
+
+// NewReader 与 Decrypt 相同，但不会把整个文件读入内存，适合解密大体积的无损文件。
+// 只有文件头会被缓存，其余数据在读取时解密。
+func NewReader(filename string, r io.Reader) (*Stream, error) {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+
+	var head []byte
+	if rs, ok := r.(io.ReadSeeker); ok {
+		// QQ 音乐需要读取文件末尾的密钥，识别格式后恢复读取位置，保留原始的 io.ReadSeeker
+		pos, err := rs.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		head = make([]byte, formatHeaderSize)
+		n, _ := io.ReadFull(rs, head)
+		head = head[:n]
+		if _, err := rs.Seek(pos, io.SeekStart); err != nil {
+			return nil, err
 		}
-		return plain, outExt, "qq", nil
+	} else {
+		br := bufio.NewReader(r)
+		head, _ = br.Peek(formatHeaderSize)
+		r = br
 	}
 
-	if len(encrypted) >= 8 && string(encrypted[:8]) == "CTENFDAM" {
-		plain, outExt, err := netease.DecryptNCM(encrypted)
+	switch detectFormat(ext, head) {
+	case "ncm":
+		return newNCMStream(r)
+
+	case "qq":
+		plain, outExt, err := qq.NewQQReader(r, ext)
+		if err != nil {
+			return nil, err
+		}
+		return &Stream{Reader: plain, Ext: outExt, Source: "qq"}, nil
+
+	case "kgm":
+		plain, outExt, err := kugou.NewKGMReader(r)
 		if err != nil {
-			return nil, "", "", err
+			return nil, err
 		}
-		if outExt == "" {
-			outExt = "mp3"
+		return &Stream{Reader: plain, Ext: outExt, Source: "kugou"}, nil
+
+	case "kwm":
+		plain, outExt, err := kuwo.NewKWMReader(r)
+		if err != nil {
+			return nil, err
 		}
-		return plain, outExt, "netease", nil
+		return &Stream{Reader: plain, Ext: outExt, Source: "kuwo"}, nil
 	}
 
-	return nil, "", "", fmt.Errorf("unsupported encrypted format: %s", ext)
+	return nil, fmt.Errorf("unsupported encrypted format: %s", ext)
+}
+
+func newNCMStream(r io.Reader) (*Stream, error) {
+	nr, err := netease.NewNCMReader(r)
+	if err != nil {
+		return nil, err
+	}
+	st := &Stream{Reader: nr, Ext: nr.Ext, Source: "netease", Cover: nr.Cover}
+	if st.Ext == "" {
+		st.Ext = "mp3"
+	}
+	if nr.Meta != nil {
+		st.Song = nr.Meta.Song()
+		st.Song.Ext = st.Ext
+	}
+	return st, nil
 }
 
 func MimeByExt(ext string) string {
@@ -61,18 +204,11 @@ func MimeByExt(ext string) string {
 	}
 }
 
+// DetectAudioExt 按文件头识别扩展名，无法识别时返回 "mp3"。
+// 需要采样率、码率等信息时使用 audio.Probe。
 func DetectAudioExt(data []byte) string {
-	if len(data) >= 4 && bytes.Equal(data[:4], []byte{'f', 'L', 'a', 'C'}) {
-		return "flac"
-	}
-	if len(data) >= 3 && bytes.Equal(data[:3], []byte{'I', 'D', '3'}) {
-		return "mp3"
-	}
-	if len(data) >= 4 && bytes.Equal(data[:4], []byte{'O', 'g', 'g', 'S'}) {
-		return "ogg"
-	}
-	if len(data) >= 8 && bytes.Equal(data[4:8], []byte{'f', 't', 'y', 'p'}) {
-		return "m4a"
+	if ext := audio.DetectExt(data); ext != "" {
+		return ext
 	}
 	return "mp3"
 }
\ No newline at end of file
@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	kgm := []byte{0x7C, 0xD5, 0x32, 0xEB, 0x86, 0x02, 0x7F, 0x4B, 0xA8, 0xAF, 0xA6, 0x8E, 0x0F, 0xFF, 0x99, 0x14}
+	cases := []struct {
+		ext  string
+		head []byte
+		want string
+	}{
+		{"mp3", []byte("CTENFDAM\x01\x70"), "ncm"},
+		{"flac", kgm, "kgm"},
+		{"", []byte("yeelion-kuwo-tme"), "kwm"},
+		{"kgma", nil, "kgm"},
+		{"vpr", nil, "kgm"},
+		{"kwm", nil, "kwm"},
+		{"mflac", []byte("random bytes...."), "qq"},
+		{"mp3", []byte("ID3\x04"), ""},
+	}
+	for _, c := range cases {
+		if got := detectFormat(c.ext, c.head); got != c.want {
+			t.Errorf("detectFormat(%q, %q) = %q, want %q", c.ext, c.head, got, c.want)
+		}
+	}
+}
+
+func TestDecryptRenamedKWM(t *testing.T) {
+	audio := append([]byte("ID3\x04\x00"), bytes.Repeat([]byte("kuwo"), 100)...)
+	head := make([]byte, 0x400)
+	copy(head, "yeelion-kuwo-tme")
+	binary.LittleEndian.PutUint32(head[0x10:], 1)
+	binary.LittleEndian.PutUint64(head[0x18:], 42)
+	copy(head[0x30:], "320kmp3")
+
+	const keyBase = "MoOtOiTvINGwd2E6n0E1i7L5t2IoOoNk"
+	enc := append([]byte(nil), audio...)
+	for i := range enc {
+		enc[i] ^= keyBase[i&0x1F] ^ "42"[i%2]
+	}
+	data := append(head, enc...)
+
+	res, err := Decrypt("song.mp3", data)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if res.Source != "kuwo" || res.Ext != "mp3" || !bytes.Equal(res.Data, audio) {
+		t.Fatalf("unexpected result source=%q ext=%q", res.Source, res.Ext)
+	}
+
+	st, err := NewReader("song.mp3", io.MultiReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+	if got, _ := io.ReadAll(st); st.Source != "kuwo" || !bytes.Equal(got, audio) {
+		t.Fatal("NewReader stream mismatch")
+	}
+}
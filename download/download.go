@@ -0,0 +1,413 @@
+// Package download 把歌曲流式下载到本地文件，支持断点续传、进度回调和原子写入。
+//
+// 下载中的数据写在 "<目标路径>.part"，完成后才重命名为目标文件，
+// 中断后再次下载同一路径会通过 HTTP Range 从 .part 的末尾继续。
+// 服务端返回的 ETag 或 Last-Modified 记录在 "<目标路径>.part.validator" 中，
+// 续传时作为 If-Range 发送，文件已经变化或没有记录时从头下载。
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/model"
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+// PartSuffix 未下载完成的临时文件后缀
+const PartSuffix = ".part"
+
+// validatorSuffix 追加在 .part 之后，保存 .part 数据来源的 ETag 或 Last-Modified
+const validatorSuffix = ".validator"
+
+// errSourceChanged 表示服务端忽略了 If-Range，返回的续传数据来自另一个文件
+var errSourceChanged = errors.New("download source changed since the partial download")
+
+// Progress 是一次进度回调的内容
+type Progress struct {
+	Song *model.Song
+	// Downloaded 已写入的字节数，包括续传前已有的部分
+	Downloaded int64
+	// Total 文件总大小，服务端未返回时为 -1
+	Total int64
+}
+
+// ProgressFunc 下载进度回调，每写入一块数据调用一次，需要自行控制刷新频率
+type ProgressFunc func(Progress)
+
+// Result 是一次成功下载的结果
+type Result struct {
+	Path string
+	URL  string
+	Size int64
+	// SHA256 保存到磁盘的文件内容的十六进制摘要
+	SHA256 string
+	// Resumed 是否从上次中断的位置继续下载
+	Resumed bool
+	// Duration 按文件内容读取的实际时长，无法识别格式时为 0
+	Duration time.Duration
+	// Preview 实际时长明显短于 Song.Duration，只有设置了 Downloader.KeepPreview 才会保存
+	Preview bool
+}
+
+// Downloader 下载器，零值可用
+type Downloader struct {
+	// Client 发起请求使用的网络栈，为 nil 时使用默认客户端
+	Client *utils.Client
+	// Providers 按来源名称指定获取下载地址的平台实例 (例如带 Cookie 的实例)，
+	// 未指定的来源通过 registry 创建不带 Cookie 的实例
+	Providers map[string]provider.SongDownloader
+	// Progress 进度回调，可为 nil
+	Progress ProgressFunc
+	// KeepPreview 下载到试听片段时仍然保存并在 Result.Preview 中标记，
+	// 默认删除文件并返回 ErrPreview
+	KeepPreview bool
+}
+
+// Download 使用默认配置下载歌曲，见 Downloader.Download
+func Download(ctx context.Context, s *model.Song, path string) (*Result, error) {
+	return (&Downloader{}).Download(ctx, s, path)
+}
+
+// Download 通过平台的 GetDownloadURL 获取地址并把歌曲保存到 path
+func (d *Downloader) Download(ctx context.Context, s *model.Song, path string) (*Result, error) {
+	if s == nil {
+		return nil, errors.New("song is nil")
+	}
+	downloadURL, err := d.resolveURL(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return d.DownloadURL(ctx, s, downloadURL, path)
+}
+
+// DownloadURL 把已经获取到的 downloadURL 保存到 path，s 用于匹配平台的下载要求和进度回调
+func (d *Downloader) DownloadURL(ctx context.Context, s *model.Song, downloadURL, path string) (*Result, error) {
+	if strings.TrimSpace(downloadURL) == "" {
+		return nil, model.Errorf(model.ErrNotFound, "download url is empty")
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	var profile Profile
+	if s != nil {
+		profile, _ = LookupProfile(s.Source)
+	}
+
+	partPath := path + PartSuffix
+	size, resumed, sum, err := d.fetch(ctx, s, downloadURL, partPath, profile.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	plainPath := partPath
+	if profile.Decrypt != nil || profile.DecryptReader != nil {
+		// 解密结果写到另一个临时文件，.part 始终保持服务端的原始数据，保证可以续传
+		plainPath = path + ".tmp"
+		if size, sum, err = decryptFile(partPath, plainPath, downloadURL, profile); err != nil {
+			os.Remove(plainPath)
+			return nil, fmt.Errorf("decrypt failed: %w", err)
+		}
+	}
+
+	res := &Result{Path: path, URL: downloadURL, Size: size, SHA256: sum, Resumed: resumed}
+	res.Duration = probeDuration(plainPath)
+	if res.Preview = IsPreview(res.Duration, s); res.Preview && !d.KeepPreview {
+		os.Remove(plainPath)
+		os.Remove(partPath)
+		os.Remove(partPath + validatorSuffix)
+		return nil, previewError(s, res.Duration)
+	}
+
+	if err := os.Rename(plainPath, path); err != nil {
+		return nil, err
+	}
+	if plainPath != partPath {
+		os.Remove(partPath)
+	}
+	os.Remove(partPath + validatorSuffix)
+	return res, nil
+}
+
+func (d *Downloader) resolveURL(ctx context.Context, s *model.Song) (string, error) {
+	p := d.Providers[s.Source]
+	if p == nil {
+		v, err := registry.Get(s.Source, "")
+		if err != nil {
+			return "", model.Errorf(model.ErrUnsupported, "%v", err)
+		}
+		var ok bool
+		if p, ok = v.(provider.SongDownloader); !ok {
+			return "", model.Errorf(model.ErrUnsupported, "%s does not support download", s.Source)
+		}
+	}
+	if pc, ok := p.(provider.SongDownloaderContext); ok {
+		return pc.GetDownloadURLContext(ctx, s)
+	}
+	return p.GetDownloadURL(s)
+}
+
+// fetch 把 downloadURL 的内容追加到 partPath，返回文件大小、是否续传和整个文件的摘要
+func (d *Downloader) fetch(ctx context.Context, s *model.Song, downloadURL, partPath string, header http.Header) (int64, bool, string, error) {
+	size, resumed, sum, err := d.fetchOnce(ctx, s, downloadURL, partPath, header)
+	if errors.Is(err, errSourceChanged) {
+		// .part 已经清空，重新从头下载
+		return d.fetchOnce(ctx, s, downloadURL, partPath, header)
+	}
+	return size, resumed, sum, err
+}
+
+func (d *Downloader) fetchOnce(ctx context.Context, s *model.Song, downloadURL, partPath string, header http.Header) (int64, bool, string, error) {
+	f, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return 0, false, "", err
+	}
+	defer f.Close()
+
+	// 续传前先把已有部分计入摘要，同时得到续传的起点
+	h := sha256.New()
+	offset, err := io.Copy(h, f)
+	if err != nil {
+		return 0, false, "", err
+	}
+	validatorPath := partPath + validatorSuffix
+	validator := readValidator(validatorPath)
+	if offset > 0 && validator == "" {
+		// 无法确认 .part 与当前地址是同一个文件，不能续传
+		if err := restart(f, h); err != nil {
+			return 0, false, "", err
+		}
+		offset = 0
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return 0, false, "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		// 文件已经变化时服务端会忽略 Range 并返回完整的新文件
+		req.Header.Set("If-Range", validator)
+	}
+
+	client := d.client().HTTPClient()
+	// 整首歌的下载时间不可预估，超时交给 ctx 控制
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, false, "", err
+	}
+	defer resp.Body.Close()
+
+	total := int64(-1)
+	resumed := false
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// 服务端不支持 Range 或者 If-Range 不匹配 (文件已经变化)，从头开始
+		if offset > 0 {
+			if err := restart(f, h); err != nil {
+				return 0, false, "", err
+			}
+			offset = 0
+		}
+		total = resp.ContentLength
+	case http.StatusPartialContent:
+		start, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return 0, false, "", fmt.Errorf("unexpected content range %q", resp.Header.Get("Content-Range"))
+		}
+		if v := responseValidator(resp); offset > 0 && v != "" && v != validator {
+			// 服务端不支持 If-Range，续传的数据来自另一个文件
+			if err := restart(f, h); err != nil {
+				return 0, false, "", err
+			}
+			os.Remove(validatorPath)
+			return 0, false, "", errSourceChanged
+		}
+		total = size
+		resumed = offset > 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// .part 已经完整，上次只是没来得及重命名
+		if _, size, ok := parseContentRange(resp.Header.Get("Content-Range")); ok && size == offset {
+			return offset, true, hex.EncodeToString(h.Sum(nil)), nil
+		}
+		return 0, false, "", &utils.StatusError{Method: http.MethodGet, StatusCode: resp.StatusCode}
+	default:
+		return 0, false, "", &utils.StatusError{Method: http.MethodGet, StatusCode: resp.StatusCode}
+	}
+
+	if offset == 0 {
+		if err := writeValidator(validatorPath, responseValidator(resp)); err != nil {
+			return 0, false, "", err
+		}
+	}
+
+	w := &progressWriter{w: io.MultiWriter(f, h), song: s, done: offset, total: total, fn: d.Progress}
+	w.report()
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return 0, false, "", err
+	}
+	if total >= 0 && w.done != total {
+		return 0, false, "", fmt.Errorf("incomplete download: got %d of %d bytes", w.done, total)
+	}
+	if err := f.Sync(); err != nil {
+		return 0, false, "", err
+	}
+	return w.done, resumed, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (d *Downloader) client() *utils.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return utils.NewClient()
+}
+
+func restart(f *os.File, h hash.Hash) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h.Reset()
+	return nil
+}
+
+// responseValidator 返回可以用作 If-Range 的强 ETag，没有时使用 Last-Modified
+func responseValidator(resp *http.Response) string {
+	if etag := strings.TrimSpace(resp.Header.Get("ETag")); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return strings.TrimSpace(resp.Header.Get("Last-Modified"))
+}
+
+func readValidator(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// writeValidator 保存 .part 的来源，v 为空时删除旧记录，下次只能从头下载
+func writeValidator(path, v string) error {
+	if v == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(v), 0644)
+}
+
+// decryptFile 解密已下载完成的 src 并写入 dst
+func decryptFile(src, dst, downloadURL string, profile Profile) (int64, string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, "", err
+	}
+	defer in.Close()
+
+	var plain io.Reader
+	if profile.DecryptReader != nil {
+		plain, err = profile.DecryptReader(in, downloadURL)
+	} else {
+		var data []byte
+		if data, err = io.ReadAll(in); err == nil {
+			data, err = profile.Decrypt(data, downloadURL)
+			plain = bytes.NewReader(data)
+		}
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, "", err
+	}
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, h), plain)
+	if err == nil {
+		err = out.Sync()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parseContentRange 解析 "bytes 100-199/200" 或 "bytes */200"，返回起始位置和总大小 (未知为 -1)
+func parseContentRange(v string) (start, size int64, ok bool) {
+	v = strings.TrimSpace(v)
+	if !strings.HasPrefix(v, "bytes ") {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(v, "bytes "), "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	size = -1
+	if parts[1] != "*" {
+		n, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		size = n
+	}
+	if parts[0] == "*" {
+		return 0, size, true
+	}
+	bounds := strings.SplitN(parts[0], "-", 2)
+	start, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, size, true
+}
+
+type progressWriter struct {
+	w     io.Writer
+	song  *model.Song
+	done  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.report()
+	return n, err
+}
+
+func (p *progressWriter) report() {
+	if p.fn != nil {
+		p.fn(Progress{Song: p.song, Downloaded: p.done, Total: p.total})
+	}
+}
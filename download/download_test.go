@@ -0,0 +1,266 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/model"
+	"github.com/guohuiyuan/music-lib/provider"
+)
+
+var payload = bytes.Repeat([]byte("0123456789abcdef"), 4096)
+
+type fakeProvider struct {
+	url string
+}
+
+func (f *fakeProvider) GetDownloadURL(s *model.Song) (string, error) {
+	return f.url, nil
+}
+
+const payloadETag = `"v2"`
+
+func newRangeServer(t *testing.T, ranges *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ranges != nil {
+			*ranges = append(*ranges, r.Header.Get("Range")+";"+r.Header.Get("If-Range"))
+		}
+		w.Header().Set("ETag", payloadETag)
+		http.ServeContent(w, r, "song.mp3", time.Time{}, bytes.NewReader(payload))
+	}))
+}
+
+func writePart(t *testing.T, path string, data []byte, validator string) {
+	t.Helper()
+	if err := os.WriteFile(path+PartSuffix, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if validator != "" {
+		if err := os.WriteFile(path+PartSuffix+validatorSuffix, []byte(validator), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDownloadWithProgress(t *testing.T) {
+	server := newRangeServer(t, nil)
+	defer server.Close()
+
+	var last Progress
+	calls := 0
+	d := &Downloader{
+		Providers: map[string]provider.SongDownloader{"fake": &fakeProvider{url: server.URL}},
+		Progress: func(p Progress) {
+			calls++
+			last = p
+		},
+	}
+	path := filepath.Join(t.TempDir(), "out", "song.mp3")
+	song := &model.Song{Source: "fake", Name: "song"}
+
+	res, err := d.Download(context.Background(), song, path)
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	if !bytes.Equal(got, payload) {
+		t.Fatal("downloaded content mismatch")
+	}
+	if res.Size != int64(len(payload)) || res.SHA256 != checksum(payload) || res.Resumed {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if _, err := os.Stat(path + PartSuffix); !os.IsNotExist(err) {
+		t.Fatalf("temp file should be renamed, stat err = %v", err)
+	}
+	if calls < 2 || last.Downloaded != int64(len(payload)) || last.Total != int64(len(payload)) || last.Song != song {
+		t.Fatalf("unexpected progress %+v after %d calls", last, calls)
+	}
+}
+
+func TestDownloadResumesPartialFile(t *testing.T) {
+	var ranges []string
+	server := newRangeServer(t, &ranges)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "song.mp3")
+	writePart(t, path, payload[:1000], payloadETag)
+
+	res, err := (&Downloader{}).DownloadURL(context.Background(), &model.Song{}, server.URL, path)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=1000-;"+payloadETag {
+		t.Fatalf("expected range request, got %q", ranges)
+	}
+	got, _ := os.ReadFile(path)
+	if !bytes.Equal(got, payload) || !res.Resumed || res.SHA256 != checksum(payload) {
+		t.Fatalf("resume produced wrong file, result %+v", res)
+	}
+	if _, err := os.Stat(path + PartSuffix + validatorSuffix); !os.IsNotExist(err) {
+		t.Fatalf("validator should be removed, stat err = %v", err)
+	}
+}
+
+func TestDownloadRestartsWhenSourceChanged(t *testing.T) {
+	stale := bytes.Repeat([]byte("x"), 1000)
+	for name, validator := range map[string]string{"missing": "", "changed": `"v1"`} {
+		var ranges []string
+		server := newRangeServer(t, &ranges)
+		path := filepath.Join(t.TempDir(), "song.mp3")
+		writePart(t, path, stale, validator)
+
+		res, err := (&Downloader{}).DownloadURL(context.Background(), &model.Song{}, server.URL, path)
+		server.Close()
+		if err != nil {
+			t.Fatalf("%s: DownloadURL failed: %v", name, err)
+		}
+		got, _ := os.ReadFile(path)
+		if !bytes.Equal(got, payload) || res.Resumed || res.SHA256 != checksum(payload) {
+			t.Fatalf("%s: expected a fresh download, result %+v", name, res)
+		}
+		if validator == "" && (len(ranges) != 1 || ranges[0] != ";") {
+			t.Fatalf("%s: should not send a range without validator, got %q", name, ranges)
+		}
+	}
+}
+
+func TestDownloadRestartsWhenIfRangeIgnored(t *testing.T) {
+	var ranges []string
+	// 服务端忽略 If-Range，总是按 Range 返回新文件的片段
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		r.Header.Del("If-Range")
+		w.Header().Set("ETag", payloadETag)
+		http.ServeContent(w, r, "song.mp3", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "song.mp3")
+	writePart(t, path, bytes.Repeat([]byte("x"), 1000), `"v1"`)
+
+	res, err := (&Downloader{}).DownloadURL(context.Background(), &model.Song{}, server.URL, path)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	if len(ranges) != 2 || ranges[0] != "bytes=1000-" || ranges[1] != "" {
+		t.Fatalf("expected a range request and then a full request, got %q", ranges)
+	}
+	got, _ := os.ReadFile(path)
+	if !bytes.Equal(got, payload) || res.Resumed {
+		t.Fatalf("expected a fresh download, result %+v", res)
+	}
+}
+
+func TestDownloadRestartsWhenRangeIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "song.mp3")
+	os.WriteFile(path+PartSuffix, []byte("stale data"), 0644)
+
+	res, err := (&Downloader{}).DownloadURL(context.Background(), nil, server.URL, path)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	if !bytes.Equal(got, payload) || res.Resumed {
+		t.Fatalf("expected a fresh download, result %+v", res)
+	}
+}
+
+func TestDownloadAppliesProfile(t *testing.T) {
+	var referer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		referer = r.Header.Get("Referer")
+		w.Write([]byte("ENCRYPTED"))
+	}))
+	defer server.Close()
+
+	RegisterProfile("profile-test", Profile{
+		Header: http.Header{"Referer": {"https://example.com/"}},
+		Decrypt: func(data []byte, downloadURL string) ([]byte, error) {
+			if !strings.HasSuffix(downloadURL, "#key") {
+				t.Errorf("decrypt should receive the original url, got %q", downloadURL)
+			}
+			return bytes.ToLower(data), nil
+		},
+	})
+
+	path := filepath.Join(t.TempDir(), "song.mp3")
+	res, err := (&Downloader{}).DownloadURL(context.Background(), &model.Song{Source: "profile-test"}, server.URL+"#key", path)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	if referer != "https://example.com/" || string(got) != "encrypted" || res.SHA256 != checksum([]byte("encrypted")) {
+		t.Fatalf("profile not applied: referer=%q content=%q", referer, got)
+	}
+	if _, err := os.Stat(path + PartSuffix); !os.IsNotExist(err) {
+		t.Fatal("temp file should be removed after decrypting")
+	}
+}
+
+func TestDownloadStreamsDecryptReader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("STREAMED"))
+	}))
+	defer server.Close()
+
+	RegisterProfile("stream-test", Profile{
+		Decrypt: func(data []byte, downloadURL string) ([]byte, error) {
+			t.Error("Decrypt should not be used when DecryptReader is set")
+			return data, nil
+		},
+		DecryptReader: func(r io.Reader, downloadURL string) (io.Reader, error) {
+			data, err := io.ReadAll(r)
+			return bytes.NewReader(bytes.ToLower(data)), err
+		},
+	})
+
+	path := filepath.Join(t.TempDir(), "song.m4a")
+	res, err := (&Downloader{}).DownloadURL(context.Background(), &model.Song{Source: "stream-test"}, server.URL, path)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != "streamed" || res.Size != int64(len(got)) || res.SHA256 != checksum(got) {
+		t.Fatalf("unexpected result %+v, content %q", res, got)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	cases := []struct {
+		in          string
+		start, size int64
+		ok          bool
+	}{
+		{"bytes 100-199/200", 100, 200, true},
+		{"bytes 0-9/*", 0, -1, true},
+		{"bytes */500", 0, 500, true},
+		{"items 0-1/2", 0, 0, false},
+	}
+	for _, c := range cases {
+		start, size, ok := parseContentRange(c.in)
+		if start != c.start || size != c.size || ok != c.ok {
+			t.Errorf("parseContentRange(%q) = %d, %d, %v", c.in, start, size, ok)
+		}
+	}
+}
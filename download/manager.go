@@ -0,0 +1,346 @@
+package download
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+// 默认并发数。单个平台的并发需要压得更低，避免批量下载时触发风控
+const (
+	DefaultWorkers   = 4
+	DefaultPerSource = 2
+)
+
+// JobStatus 是下载任务的状态
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed"
+	// JobExists 目标文件已存在，未重新下载
+	JobExists JobStatus = "exists"
+	// JobVIP 需要会员，已跳过
+	JobVIP JobStatus = "vip"
+)
+
+// Job 是队列中的一首歌
+type Job struct {
+	Song   model.Song `json:"song"`
+	Path   string     `json:"path"`
+	Status JobStatus  `json:"status"`
+	Error  string     `json:"error,omitempty"`
+	// Attempts 已经尝试下载的次数，跨重启累计
+	Attempts int    `json:"attempts,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	SHA256   string `json:"sha256,omitempty"`
+}
+
+// Report 汇总 Run 结束时队列中各任务的状态
+type Report struct {
+	Succeeded  []Job
+	Failed     []Job
+	VIPSkipped []Job
+	// Existing 目标文件已存在而跳过的任务
+	Existing []Job
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("succeeded %d, failed %d, vip skipped %d, already exists %d",
+		len(r.Succeeded), len(r.Failed), len(r.VIPSkipped), len(r.Existing))
+}
+
+// Manager 批量下载专辑、歌单等歌曲列表。
+// 队列保存在 JSON 状态文件中，进程重启后调用 Run 会继续处理未完成和失败的任务。
+type Manager struct {
+	// Downloader 实际执行下载，为 nil 时使用零值 Downloader
+	Downloader *Downloader
+	// Dir 保存目录，文件名为 Song.Filename()
+	Dir string
+	// Workers 总并发数，为 0 时使用 DefaultWorkers
+	Workers int
+	// PerSource 单个平台的并发上限，为 0 时使用 DefaultPerSource
+	PerSource int
+	// SourceLimits 单独指定某些平台的并发上限，优先于 PerSource
+	SourceLimits map[string]int
+
+	statePath string
+	mu        sync.Mutex
+	jobs      []*Job
+}
+
+// NewManager 创建下载管理器，statePath 已存在时恢复其中的队列，为空时队列只保存在内存中
+func NewManager(dir, statePath string) (*Manager, error) {
+	m := &Manager{Dir: dir, statePath: statePath}
+	if statePath == "" {
+		return m, nil
+	}
+	data, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &m.jobs); err != nil {
+		return nil, fmt.Errorf("invalid download state %s: %w", statePath, err)
+	}
+	return m, nil
+}
+
+// Add 把歌曲加入队列，目标路径相同的歌曲只保留一首，返回实际新增的数量
+func (m *Manager) Add(songs ...model.Song) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen := make(map[string]bool, len(m.jobs))
+	for _, job := range m.jobs {
+		seen[job.Path] = true
+	}
+	added := 0
+	for _, s := range songs {
+		path := filepath.Join(m.Dir, s.Filename())
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		m.jobs = append(m.jobs, &Job{Song: s, Path: path, Status: JobPending})
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, m.saveLocked()
+}
+
+// Jobs 返回队列中所有任务的快照
+func (m *Manager) Jobs() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// Run 下载所有待处理和上次失败的任务，直到队列处理完或 ctx 结束。
+// 单个任务失败不会中断其他任务，返回的 error 只表示状态文件写入失败或 ctx 结束。
+func (m *Manager) Run(ctx context.Context) (*Report, error) {
+	m.mu.Lock()
+	var queue []*Job
+	for _, job := range m.jobs {
+		if job.Status == JobPending || job.Status == JobFailed {
+			queue = append(queue, job)
+		}
+	}
+	m.mu.Unlock()
+
+	workers := m.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	sched := newJobScheduler(queue, m.sourceLimit)
+	// ctx 结束时唤醒正在等待空闲平台的 worker
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			sched.wake()
+		case <-stop:
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var saveErr error
+	var saveOnce sync.Once
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				job := sched.next(ctx)
+				if job == nil {
+					return
+				}
+				m.runJob(ctx, job)
+				sched.done(job)
+				if err := m.save(); err != nil {
+					saveOnce.Do(func() { saveErr = err })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := m.report()
+	if saveErr != nil {
+		return report, saveErr
+	}
+	return report, ctx.Err()
+}
+
+// jobScheduler 按平台分队列分发任务：worker 只会取到仍有空闲并发的平台的任务，
+// 某个平台达到上限时不会占着 worker 等待，其他平台的任务可以继续下载。
+type jobScheduler struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	sources []string // 按首次出现的顺序轮流分发
+	queues  map[string][]*Job
+	running map[string]int
+	limit   func(string) int
+	pending int
+}
+
+func newJobScheduler(queue []*Job, limit func(string) int) *jobScheduler {
+	s := &jobScheduler{queues: map[string][]*Job{}, running: map[string]int{}, limit: limit, pending: len(queue)}
+	s.cond = sync.NewCond(&s.mu)
+	for _, job := range queue {
+		source := job.Song.Source
+		if _, ok := s.queues[source]; !ok {
+			s.sources = append(s.sources, source)
+		}
+		s.queues[source] = append(s.queues[source], job)
+	}
+	return s
+}
+
+// next 返回下一个可以开始的任务，没有剩余任务或 ctx 结束时返回 nil
+func (s *jobScheduler) next(ctx context.Context) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		if s.pending == 0 || ctx.Err() != nil {
+			return nil
+		}
+		for i, source := range s.sources {
+			if len(s.queues[source]) == 0 || s.running[source] >= s.limit(source) {
+				continue
+			}
+			job := s.queues[source][0]
+			s.queues[source] = s.queues[source][1:]
+			s.running[source]++
+			s.pending--
+			// 把刚分发的平台移到末尾，让各平台轮流占用 worker
+			s.sources = append(append(s.sources[:i:i], s.sources[i+1:]...), source)
+			return job
+		}
+		s.cond.Wait()
+	}
+}
+
+func (s *jobScheduler) done(job *Job) {
+	s.mu.Lock()
+	s.running[job.Song.Source]--
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
+
+func (s *jobScheduler) wake() {
+	s.mu.Lock()
+	s.cond.Broadcast()
+	s.mu.Unlock()
+}
+
+func (m *Manager) sourceLimit(source string) int {
+	if n := m.SourceLimits[source]; n > 0 {
+		return n
+	}
+	if m.PerSource > 0 {
+		return m.PerSource
+	}
+	return DefaultPerSource
+}
+
+func (m *Manager) runJob(ctx context.Context, job *Job) {
+	m.mu.Lock()
+	song, path := job.Song, job.Path
+	m.mu.Unlock()
+
+	status, errMsg := JobDone, ""
+	var res *Result
+	if _, err := os.Stat(path); err == nil {
+		status = JobExists
+	} else {
+		d := m.Downloader
+		if d == nil {
+			d = &Downloader{}
+		}
+		var err error
+		res, err = d.Download(ctx, &song, path)
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			// 被取消的任务保持原状态，下次 Run 继续
+			return
+		case errors.Is(err, model.ErrVIPRequired):
+			status, errMsg = JobVIP, err.Error()
+		default:
+			status, errMsg = JobFailed, err.Error()
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if status != JobExists {
+		job.Attempts++
+	}
+	job.Status, job.Error = status, errMsg
+	if res != nil {
+		job.Size, job.SHA256 = res.Size, res.SHA256
+	}
+}
+
+func (m *Manager) report() *Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := &Report{}
+	for _, job := range m.jobs {
+		switch job.Status {
+		case JobDone:
+			r.Succeeded = append(r.Succeeded, *job)
+		case JobFailed:
+			r.Failed = append(r.Failed, *job)
+		case JobVIP:
+			r.VIPSkipped = append(r.VIPSkipped, *job)
+		case JobExists:
+			r.Existing = append(r.Existing, *job)
+		}
+	}
+	return r
+}
+
+func (m *Manager) save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saveLocked()
+}
+
+// saveLocked 先写临时文件再重命名，进程在写入途中退出也不会损坏状态文件
+func (m *Manager) saveLocked() error {
+	if m.statePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m.jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(m.statePath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	tmp := m.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.statePath)
+}
@@ -0,0 +1,210 @@
+package download
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/model"
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+type routeProvider struct {
+	base string
+}
+
+func (p *routeProvider) GetDownloadURL(s *model.Song) (string, error) {
+	if s.IsVIP {
+		return "", model.Errorf(model.ErrVIPRequired, "vip required")
+	}
+	return p.base + "/" + s.Source + "/" + s.ID, nil
+}
+
+func TestManagerRunAndResume(t *testing.T) {
+	var mu sync.Mutex
+	inflight := map[string]int{}
+	maxInflight := map[string]int{}
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		source := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+		mu.Lock()
+		inflight[source]++
+		if inflight[source] > maxInflight[source] {
+			maxInflight[source] = inflight[source]
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inflight[source]--
+		mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("audio " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.json")
+	p := &routeProvider{base: server.URL}
+	providers := map[string]provider.SongDownloader{"qq": p, "kugou": p}
+
+	m, err := NewManager(filepath.Join(dir, "music"), statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Downloader = &Downloader{Providers: providers}
+	m.Workers = 8
+	m.SourceLimits = map[string]int{"kugou": 1}
+
+	var songs []model.Song
+	for i := 0; i < 6; i++ {
+		songs = append(songs,
+			model.Song{Source: "qq", ID: string(rune('a' + i)), Name: "qq" + string(rune('a'+i)), Artist: "x"},
+			model.Song{Source: "kugou", ID: string(rune('a' + i)), Name: "kg" + string(rune('a'+i)), Artist: "x"},
+		)
+	}
+	songs = append(songs,
+		model.Song{Source: "qq", ID: "vip", Name: "vip", Artist: "x", IsVIP: true},
+		model.Song{Source: "qq", ID: "missing", Name: "missing", Artist: "x"},
+		model.Song{Source: "qq", ID: "exists", Name: "exists", Artist: "x"},
+		model.Song{Source: "qq", ID: "a", Name: "qqa", Artist: "x"}, // 与第一首重复
+	)
+	os.MkdirAll(m.Dir, 0755)
+	os.WriteFile(filepath.Join(m.Dir, "exists - x.mp3"), []byte("old"), 0644)
+
+	if n, err := m.Add(songs...); err != nil || n != 15 {
+		t.Fatalf("Add = %d, %v", n, err)
+	}
+
+	report, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Succeeded) != 12 || len(report.Failed) != 1 || len(report.VIPSkipped) != 1 || len(report.Existing) != 1 {
+		t.Fatalf("unexpected report: %s", report)
+	}
+	if maxInflight["kugou"] > 1 || maxInflight["qq"] > DefaultPerSource {
+		t.Fatalf("per-source limit exceeded: %v", maxInflight)
+	}
+	if got, _ := os.ReadFile(filepath.Join(m.Dir, "kga - x.mp3")); string(got) != "audio /kugou/a" {
+		t.Fatalf("unexpected file content %q", got)
+	}
+
+	// 重新加载状态文件后只重试失败的任务
+	atomic.StoreInt32(&requests, 0)
+	m2, err := NewManager(m.Dir, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2.Downloader = m.Downloader
+	if len(m2.Jobs()) != 15 {
+		t.Fatalf("state not restored: %d jobs", len(m2.Jobs()))
+	}
+	report, err = m2.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 || len(report.Failed) != 1 || report.Failed[0].Attempts != 2 {
+		t.Fatalf("expected only the failed job to be retried, requests=%d report=%s", requests, report)
+	}
+}
+
+func TestManagerRunsSourcesConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	started, finished := map[string]int{}, 0
+	var firstRound map[string]int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		source := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+		mu.Lock()
+		started[source]++
+		mu.Unlock()
+		time.Sleep(100 * time.Millisecond)
+		mu.Lock()
+		if finished == 0 {
+			// 第一首下载完成之前已经开始的请求
+			firstRound = map[string]int{"qq": started["qq"], "kugou": started["kugou"]}
+		}
+		finished++
+		mu.Unlock()
+		w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	m, err := NewManager(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &routeProvider{base: server.URL}
+	// 测试服务器都在同一个域名下，使用单独的限流器避免共享的默认限流把请求串行化
+	client := utils.NewClient(utils.WithRateLimiter(utils.NewRateLimiter(1000, 100)))
+	m.Downloader = &Downloader{Client: client, Providers: map[string]provider.SongDownloader{"qq": p, "kugou": p}}
+	m.Workers = 4
+	m.PerSource = 2
+
+	// 队列开头全是 qq 的歌，qq 达到上限时空闲的 worker 应该直接去下载 kugou
+	var songs []model.Song
+	for _, source := range []string{"qq", "kugou"} {
+		for i := 0; i < 4; i++ {
+			id := string(rune('a' + i))
+			songs = append(songs, model.Song{Source: source, ID: id, Name: source + id, Artist: "x"})
+		}
+	}
+	if _, err := m.Add(songs...); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Succeeded) != 8 {
+		t.Fatalf("unexpected report: %s", report)
+	}
+	if firstRound["qq"] != 2 || firstRound["kugou"] != 2 {
+		t.Fatalf("expected 2 qq and 2 kugou downloads in the first round, got %v", firstRound)
+	}
+}
+
+func TestManagerRunCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	m, err := NewManager(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Downloader = &Downloader{Providers: map[string]provider.SongDownloader{"qq": &routeProvider{base: server.URL}}}
+	m.PerSource = 1
+	var songs []model.Song
+	for i := 0; i < 3; i++ {
+		songs = append(songs, model.Song{Source: "qq", ID: string(rune('a' + i)), Name: string(rune('a' + i)), Artist: "x"})
+	}
+	m.Add(songs...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := m.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	for _, job := range m.Jobs() {
+		if job.Status != JobPending {
+			t.Fatalf("canceled job should stay pending: %+v", job)
+		}
+	}
+}
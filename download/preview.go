@@ -0,0 +1,40 @@
+package download
+
+import (
+	"errors"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/audio"
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+// ErrPreview 表示下载到的是试听片段而不是完整歌曲。
+// 返回的错误同时属于 model.ErrVIPRequired，可以直接换平台或账号重试。
+var ErrPreview = errors.New("preview clip")
+
+// previewTolerance 实际时长比歌曲信息短多少以内不算试听，与汽水音乐接口的判断一致
+const previewTolerance = 5 * time.Second
+
+// IsPreview 比较实际时长与 s.Duration，明显偏短时认为是试听片段。
+// 任意一方时长未知时返回 false。
+func IsPreview(actual time.Duration, s *model.Song) bool {
+	if s == nil || s.Duration <= 0 || actual <= 0 {
+		return false
+	}
+	expected := time.Duration(s.Duration) * time.Second
+	// 试听通常是 30~60 秒，同时要求明显短于完整时长，避免把不同剪辑版本当成试听
+	return actual+previewTolerance < expected && actual*5 < expected*4
+}
+
+// probeDuration 读取文件的实际时长，无法识别格式时返回 0
+func probeDuration(path string) time.Duration {
+	info, err := audio.ProbeFile(path)
+	if err != nil {
+		return 0
+	}
+	return info.Duration
+}
+
+func previewError(s *model.Song, actual time.Duration) error {
+	return model.Errorf(model.ErrVIPRequired, "%w: got %v of %ds", ErrPreview, actual.Round(time.Second), s.Duration)
+}
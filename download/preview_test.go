@@ -0,0 +1,84 @@
+package download
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+// flacWithDuration 生成只有 STREAMINFO 的 FLAC 文件，时长为 seconds 秒
+func flacWithDuration(seconds int) []byte {
+	info := make([]byte, 34)
+	rate := 44100
+	info[10] = byte(rate >> 12)
+	info[11] = byte(rate >> 4)
+	info[12] = byte(rate<<4) | 1<<1
+	info[13] = 15 << 4
+	binary.BigEndian.PutUint32(info[14:], uint32(rate*seconds))
+	return append([]byte("fLaC\x80\x00\x00\x22"), info...)
+}
+
+func TestIsPreview(t *testing.T) {
+	song := &model.Song{Duration: 240}
+	cases := []struct {
+		actual time.Duration
+		song   *model.Song
+		want   bool
+	}{
+		{30 * time.Second, song, true},
+		{60 * time.Second, song, true},
+		{237 * time.Second, song, false},
+		{220 * time.Second, song, false},
+		{0, song, false},
+		{30 * time.Second, &model.Song{}, false},
+		{30 * time.Second, nil, false},
+	}
+	for _, c := range cases {
+		if got := IsPreview(c.actual, c.song); got != c.want {
+			t.Errorf("IsPreview(%v, %+v) = %v", c.actual, c.song, got)
+		}
+	}
+}
+
+func TestDownloadRejectsPreview(t *testing.T) {
+	clip := flacWithDuration(30)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(clip)
+	}))
+	defer server.Close()
+
+	song := &model.Song{Source: "fake", Duration: 240}
+	path := filepath.Join(t.TempDir(), "song.flac")
+
+	_, err := (&Downloader{}).DownloadURL(context.Background(), song, server.URL, path)
+	if !errors.Is(err, ErrPreview) || !errors.Is(err, model.ErrVIPRequired) {
+		t.Fatalf("expected preview error, got %v", err)
+	}
+	for _, p := range []string{path, path + PartSuffix} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("%s should be removed, stat err = %v", p, err)
+		}
+	}
+
+	res, err := (&Downloader{KeepPreview: true}).DownloadURL(context.Background(), song, server.URL, path)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	if !res.Preview || res.Duration != 30*time.Second {
+		t.Fatalf("expected preview result, got %+v", res)
+	}
+
+	song.Duration = 30
+	res, err = (&Downloader{}).DownloadURL(context.Background(), song, server.URL, path)
+	if err != nil || res.Preview {
+		t.Fatalf("full-length file rejected: %+v, %v", res, err)
+	}
+}
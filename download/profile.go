@@ -0,0 +1,39 @@
+package download
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Profile 描述某个平台下载音频文件时的特殊要求
+type Profile struct {
+	// Header 下载音频文件时必须携带的请求头，例如 B 站 CDN 校验 Referer
+	Header http.Header
+	// Decrypt 对下载完成的数据做后处理 (例如汽水音乐的加密音频)，
+	// downloadURL 是 GetDownloadURL 返回的原始地址，为 nil 时原样保存
+	Decrypt func(data []byte, downloadURL string) ([]byte, error)
+	// DecryptReader 与 Decrypt 作用相同，但以流的方式处理，解密大文件时不必整个读入内存；
+	// 两者都设置时优先使用 DecryptReader
+	DecryptReader func(r io.Reader, downloadURL string) (io.Reader, error)
+}
+
+var profiles = struct {
+	sync.RWMutex
+	m map[string]Profile
+}{m: make(map[string]Profile)}
+
+// RegisterProfile 注册平台的下载要求，各平台包在 init 中调用
+func RegisterProfile(source string, p Profile) {
+	profiles.Lock()
+	defer profiles.Unlock()
+	profiles.m[source] = p
+}
+
+// LookupProfile 返回平台的下载要求，未注册时返回零值
+func LookupProfile(source string) (Profile, bool) {
+	profiles.RLock()
+	defer profiles.RUnlock()
+	p, ok := profiles.m[source]
+	return p, ok
+}
@@ -1,6 +1,7 @@
 package fivesing
 
 import (
+	"context"
 	"errors"
 	"github.com/guohuiyuan/music-lib/model"
 	"strings"
@@ -8,10 +9,19 @@ import (
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultFivesing.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultFivesing.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL 获取下载链接
 func (f *Fivesing) GetDownloadURL(s *model.Song) (string, error) {
+	return f.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext 与 GetDownloadURL 相同，但请求受 ctx 控制。
+func (f *Fivesing) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "fivesing" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	if s.URL != "" {
 		return s.URL, nil
@@ -33,5 +43,5 @@ func (f *Fivesing) GetDownloadURL(s *model.Song) (string, error) {
 		}
 	}
 
-	return f.fetchAudioLink(songID, songType)
+	return f.fetchAudioLink(ctx, songID, songType)
 }
@@ -1,6 +1,7 @@
 package fivesing
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,18 +20,19 @@ const (
 
 type Fivesing struct {
 	cookie string
+	client *utils.Client
 }
 
-func New(cookie string) *Fivesing {
-	return &Fivesing{cookie: cookie}
+func New(cookie string, opts ...utils.ClientOption) *Fivesing {
+	return &Fivesing{cookie: cookie, client: utils.NewClient(opts...)}
 }
 
 var defaultFivesing = New("")
 
 // fetchCreatorName 辅助函数：仅获取创建者名称
-func (f *Fivesing) fetchCreatorName(id string) (string, error) {
+func (f *Fivesing) fetchCreatorName(ctx context.Context, id string) (string, error) {
 	infoURL := fmt.Sprintf("http://mobileapi.5sing.kugou.com/song/getsonglist?id=%s&songfields=user", id)
-	infoBody, err := utils.Get(infoURL, utils.WithHeader("User-Agent", UserAgent))
+	infoBody, err := f.client.GetContext(ctx, infoURL, utils.WithHeader("User-Agent", UserAgent))
 	if err != nil {
 		return "", err
 	}
@@ -62,10 +64,10 @@ func (f *Fivesing) fetchCreatorName(id string) (string, error) {
 }
 
 // fetchPlaylistDetail [核心] 获取歌单详情 (API 获取元数据 + HTML 解析歌曲)
-func (f *Fivesing) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (f *Fivesing) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	// 1. 调用 API 获取歌单元数据 (标题、封面、关键的 UserId)
 	infoURL := fmt.Sprintf("http://mobileapi.5sing.kugou.com/song/getsonglist?id=%s&songfields=ID,user", id)
-	infoBody, err := utils.Get(infoURL, utils.WithHeader("User-Agent", UserAgent))
+	infoBody, err := f.client.GetContext(ctx, infoURL, utils.WithHeader("User-Agent", UserAgent))
 	if err != nil {
 		return nil, nil, fmt.Errorf("fetch info failed: %w", err)
 	}
@@ -81,7 +83,7 @@ func (f *Fivesing) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song
 	// 检查 Data 是否是有效对象 (以 '{' 开头)。如果是 '[]' (空数组)，说明歌单不存在或无权限
 	dataStr := strings.TrimSpace(string(rawResp.Data))
 	if len(dataStr) == 0 || dataStr[0] != '{' {
-		return nil, nil, errors.New("playlist info not found or invalid (api returned empty list)")
+		return nil, nil, model.Errorf(model.ErrNotFound, "playlist info not found or invalid (api returned empty list)")
 	}
 
 	// 定义真实的数据结构
@@ -126,7 +128,7 @@ func (f *Fivesing) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song
 
 	// 2. 构造歌单页面 URL 并获取 HTML
 	pageURL := playlist.Link
-	htmlBodyBytes, err := utils.Get(pageURL,
+	htmlBodyBytes, err := f.client.GetContext(ctx, pageURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", f.cookie),
 	)
@@ -210,8 +212,8 @@ func (f *Fivesing) parseSongsFromHTML(htmlContent string) ([]model.Song, error)
 }
 
 // fetchSongInfo 获取完整的歌曲信息（Metadata + URL）
-func (f *Fivesing) fetchSongInfo(songID, songType string) (*model.Song, error) {
-	audioURL, err := f.fetchAudioLink(songID, songType)
+func (f *Fivesing) fetchSongInfo(ctx context.Context, songID, songType string) (*model.Song, error) {
+	audioURL, err := f.fetchAudioLink(ctx, songID, songType)
 	if err != nil {
 		return nil, err
 	}
@@ -221,7 +223,7 @@ func (f *Fivesing) fetchSongInfo(songID, songType string) (*model.Song, error) {
 	params.Set("songtype", songType)
 	metaURL := "http://mobileapi.5sing.kugou.com/song/newget?" + params.Encode()
 
-	metaBody, _ := utils.Get(metaURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
+	metaBody, _ := f.client.GetContext(ctx, metaURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
 
 	var name, artist, cover string
 
@@ -262,13 +264,13 @@ func (f *Fivesing) fetchSongInfo(songID, songType string) (*model.Song, error) {
 }
 
 // fetchAudioLink 仅获取音频链接
-func (f *Fivesing) fetchAudioLink(songID, songType string) (string, error) {
+func (f *Fivesing) fetchAudioLink(ctx context.Context, songID, songType string) (string, error) {
 	params := url.Values{}
 	params.Set("songid", songID)
 	params.Set("songtype", songType)
 
 	apiURL := "http://mobileapi.5sing.kugou.com/song/getSongUrl?" + params.Encode()
-	body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
+	body, err := f.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
 	if err != nil {
 		return "", err
 	}
@@ -303,7 +305,7 @@ func (f *Fivesing) fetchAudioLink(songID, songType string) (string, error) {
 		return url, nil
 	}
 
-	return "", errors.New("no valid download url found")
+	return "", model.Errorf(model.ErrNotFound, "no valid download url found")
 }
 
 func getFirstValid(urls ...string) string {
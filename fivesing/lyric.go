@@ -1,6 +1,7 @@
 package fivesing
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/guohuiyuan/music-lib/model"
@@ -11,9 +12,18 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultFivesing.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultFivesing.GetLyricsContext(ctx, s)
+}
+
 func (f *Fivesing) GetLyrics(s *model.Song) (string, error) {
+	return f.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (f *Fivesing) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "fivesing" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	var songID, songType string
@@ -37,7 +47,7 @@ func (f *Fivesing) GetLyrics(s *model.Song) (string, error) {
 	params.Set("songtype", songType)
 	apiURL := "http://mobileapi.5sing.kugou.com/song/newget?" + params.Encode()
 
-	body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
+	body, err := f.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
 	if err != nil {
 		return "", err
 	}
@@ -49,7 +59,7 @@ func (f *Fivesing) GetLyrics(s *model.Song) (string, error) {
 	}
 	json.Unmarshal(body, &resp)
 	if resp.Data.DynamicWords == "" {
-		return "", errors.New("lyrics not found")
+		return "", model.Errorf(model.ErrNotFound, "lyrics not found")
 	}
 	return resp.Data.DynamicWords, nil
 }
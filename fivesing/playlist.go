@@ -1,6 +1,7 @@
 package fivesing
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,32 +17,67 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultFivesing.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultFivesing.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) {
 	return defaultFivesing.GetPlaylistSongs(id)
 }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultFivesing.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultFivesing.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultFivesing.ParsePlaylistContext(ctx, link)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultFivesing.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultFivesing.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultFivesing.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultFivesing.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (f *Fivesing) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return f.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext 与 GetPlaylistCategories 相同，但请求受 ctx 控制。
+func (f *Fivesing) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 func (f *Fivesing) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return f.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext 与 GetCategoryPlaylists 相同，但请求受 ctx 控制。
+func (f *Fivesing) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 // SearchPlaylist 搜索歌单
 func (f *Fivesing) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return f.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext 与 SearchPlaylist 相同，但请求受 ctx 控制。
+func (f *Fivesing) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("keyword", keyword)
 	params.Set("sort", "1")
@@ -50,7 +86,7 @@ func (f *Fivesing) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	params.Set("type", "1")
 
 	apiURL := "http://search.5sing.kugou.com/home/json?" + params.Encode()
-	body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
+	body, err := f.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +148,7 @@ func (f *Fivesing) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 				sem <- struct{}{}
 				defer func() { <-sem }()
 
-				if name, err := f.fetchCreatorName(plID); err == nil && name != "" {
+				if name, err := f.fetchCreatorName(ctx, plID); err == nil && name != "" {
 					playlists[idx].Creator = name
 				}
 			}(i, item.SongListId)
@@ -125,13 +161,23 @@ func (f *Fivesing) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 // GetPlaylistSongs 获取歌单详情 (简化版：直接复用 fetchPlaylistDetail)
 func (f *Fivesing) GetPlaylistSongs(id string) ([]model.Song, error) {
+	return f.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext 与 GetPlaylistSongs 相同，但请求受 ctx 控制。
+func (f *Fivesing) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
 	// 复用核心逻辑，只返回歌曲切片
-	_, songs, err := f.fetchPlaylistDetail(id)
+	_, songs, err := f.fetchPlaylistDetail(ctx, id)
 	return songs, err
 }
 
 // ParsePlaylist 解析歌单链接并返回详情
 func (f *Fivesing) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return f.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext 与 ParsePlaylist 相同，但请求受 ctx 控制。
+func (f *Fivesing) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	re := regexp.MustCompile(`5sing\.kugou\.com/(?:(\d+)/)?dj/([a-zA-Z0-9]+)\.html`)
 	matches := re.FindStringSubmatch(link)
 	if len(matches) < 3 {
@@ -139,5 +185,5 @@ func (f *Fivesing) ParsePlaylist(link string) (*model.Playlist, []model.Song, er
 	}
 	playlistId := matches[2]
 	// userId (matches[1]) 可选，因为 API 验证更准确，这里只用 ID
-	return f.fetchPlaylistDetail(playlistId)
+	return f.fetchPlaylistDetail(ctx, playlistId)
 }
@@ -0,0 +1,43 @@
+package fivesing
+
+import (
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func init() {
+	registry.Register(registry.Entry{
+		Name: "fivesing",
+		New: func(cookie string, opts ...utils.ClientOption) interface{} {
+			return New(cookie, opts...)
+		},
+		Capabilities: []provider.Capability{
+			provider.CapSearch,
+			provider.CapParse,
+			provider.CapDownload,
+			provider.CapLyrics,
+			provider.CapPlaylist,
+		},
+		Descriptor: provider.Descriptor{
+			Source: "fivesing",
+			Name:   "5sing",
+			Features: map[provider.Feature]provider.FeatureSupport{
+				provider.FeatureSearch:            {Level: provider.Supported},
+				provider.FeatureDownload:          {Level: provider.Supported},
+				provider.FeatureLyrics:            {Level: provider.Supported},
+				provider.FeatureSongParse:         {Level: provider.Supported},
+				provider.FeaturePlaylistSearch:    {Level: provider.Supported},
+				provider.FeaturePlaylistRecommend: {Level: provider.Unsupported},
+				provider.FeaturePlaylistSongs:     {Level: provider.Supported},
+				provider.FeaturePlaylistParse:     {Level: provider.Supported},
+				provider.FeatureAlbumSearch:       {Level: provider.Unsupported},
+				provider.FeatureAlbumSongs:        {Level: provider.Unsupported},
+				provider.FeatureAlbumParse:        {Level: provider.Unsupported},
+				provider.FeatureUserPlaylist:      {Level: provider.Unsupported},
+				provider.FeatureQRLogin:           {Level: provider.Unsupported},
+				provider.FeaturePlaylistCategory:  {Level: provider.Unsupported},
+			},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package fivesing
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,10 +15,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultFivesing.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultFivesing.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultFivesing.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultFivesing.ParseContext(ctx, link)
+}
+
 // Search 搜索歌曲
 func (f *Fivesing) Search(keyword string) ([]model.Song, error) {
+	return f.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but carries ctx through its requests.
+func (f *Fivesing) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("keyword", keyword)
 	params.Set("sort", "1")
@@ -26,7 +40,7 @@ func (f *Fivesing) Search(keyword string) ([]model.Song, error) {
 	params.Set("type", "0")
 
 	apiURL := "http://search.5sing.kugou.com/home/json?" + params.Encode()
-	body, err := utils.Get(apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
+	body, err := f.client.GetContext(ctx, apiURL, utils.WithHeader("User-Agent", UserAgent), utils.WithHeader("Cookie", f.cookie))
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +88,11 @@ func (f *Fivesing) Search(keyword string) ([]model.Song, error) {
 
 // Parse 解析链接并获取完整信息
 func (f *Fivesing) Parse(link string) (*model.Song, error) {
+	return f.ParseContext(context.Background(), link)
+}
+
+// ParseContext is like Parse but carries ctx through its requests.
+func (f *Fivesing) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	re := regexp.MustCompile(`5sing\.kugou\.com/(\w+)/(\d+)\.html`)
 	matches := re.FindStringSubmatch(link)
 	if len(matches) < 3 {
@@ -82,5 +101,5 @@ func (f *Fivesing) Parse(link string) (*model.Song, error) {
 	songType := matches[1]
 	songID := matches[2]
 
-	return f.fetchSongInfo(songID, songType)
+	return f.fetchSongInfo(ctx, songID, songType)
 }
@@ -1,6 +1,7 @@
 package jamendo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,14 +14,31 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultJamendo.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultJamendo.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) { return defaultJamendo.GetAlbumSongs(id) }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultJamendo.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultJamendo.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultJamendo.ParseAlbumContext(ctx, link)
+}
+
 func (j *Jamendo) SearchAlbum(keyword string) ([]model.Playlist, error) {
-	body, err := j.searchByType(keyword, "album")
+	return j.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext is like SearchAlbum but carries ctx through its requests.
+func (j *Jamendo) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	body, err := j.searchByType(ctx, keyword, "album")
 	if err != nil {
 		return nil, err
 	}
@@ -63,16 +81,26 @@ func (j *Jamendo) SearchAlbum(keyword string) ([]model.Playlist, error) {
 }
 
 func (j *Jamendo) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := j.fetchAlbumDetail(id)
+	return j.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext is like GetAlbumSongs but carries ctx through its requests.
+func (j *Jamendo) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := j.fetchAlbumDetail(ctx, id)
 	return songs, err
 }
 
 func (j *Jamendo) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return j.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext is like ParseAlbum but carries ctx through its requests.
+func (j *Jamendo) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	re := regexp.MustCompile(`jamendo\.com/album/(\d+)`)
 	matches := re.FindStringSubmatch(link)
 	if len(matches) < 2 {
 		return nil, nil, errors.New("invalid jamendo album link")
 	}
 
-	return j.fetchAlbumDetail(matches[1])
+	return j.fetchAlbumDetail(ctx, matches[1])
 }
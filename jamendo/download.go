@@ -1,15 +1,25 @@
 package jamendo
 
 import (
+	"context"
 	"errors"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultJamendo.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultJamendo.GetDownloadURLContext(ctx, s)
+}
+
 func (j *Jamendo) GetDownloadURL(s *model.Song) (string, error) {
+	return j.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext is like GetDownloadURL but carries ctx through its requests.
+func (j *Jamendo) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "jamendo" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	if s.URL != "" {
 		return s.URL, nil
@@ -23,7 +33,7 @@ func (j *Jamendo) GetDownloadURL(s *model.Song) (string, error) {
 		return "", errors.New("id missing")
 	}
 
-	info, err := j.getTrackByID(trackID, jamendoTrackMeta{
+	info, err := j.getTrackByID(ctx, trackID, jamendoTrackMeta{
 		ArtistName: s.Artist,
 		AlbumName:  s.Album,
 		AlbumID:    s.AlbumID,
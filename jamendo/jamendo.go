@@ -1,6 +1,7 @@
 package jamendo
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
@@ -36,6 +37,7 @@ const (
 
 type Jamendo struct {
 	cookie string
+	client *utils.Client
 }
 
 type jamendoTrackItem struct {
@@ -115,19 +117,19 @@ type jamendoTrackMeta struct {
 	AlbumID    string
 }
 
-func New(cookie string) *Jamendo {
-	return &Jamendo{cookie: cookie}
+func New(cookie string, opts ...utils.ClientOption) *Jamendo {
+	return &Jamendo{cookie: cookie, client: utils.NewClient(opts...)}
 }
 
 var defaultJamendo = New("")
 
-func (j *Jamendo) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
-	albumItem, err := j.getAlbumByID(id)
+func (j *Jamendo) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
+	albumItem, err := j.getAlbumByID(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	creator, err := j.getArtistNameByID(albumItem.ArtistID)
+	creator, err := j.getArtistNameByID(ctx, albumItem.ArtistID)
 	if err != nil {
 		creator = ""
 	}
@@ -149,7 +151,7 @@ func (j *Jamendo) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, er
 		album.Extra["artist_id"] = strconv.Itoa(albumItem.ArtistID)
 	}
 
-	songs, err := j.fetchAlbumTracks(albumItem, creator)
+	songs, err := j.fetchAlbumTracks(ctx, albumItem, creator)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,18 +162,18 @@ func (j *Jamendo) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, er
 	return album, songs, nil
 }
 
-func (j *Jamendo) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (j *Jamendo) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	playlistID := strings.TrimSpace(id)
 	if playlistID == "" {
 		return nil, nil, errors.New("playlist id is empty")
 	}
 
-	playlistItem, err := j.getPlaylistByID(playlistID)
+	playlistItem, err := j.getPlaylistByID(ctx, playlistID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	songs, err := j.GetPlaylistSongs(playlistID)
+	songs, err := j.GetPlaylistSongsContext(ctx, playlistID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -194,9 +196,9 @@ func (j *Jamendo) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song,
 	return playlist, songs, nil
 }
 
-func (j *Jamendo) fetchPlaylistTracks(playlistItem *jamendoPlaylistItem) ([]model.Song, error) {
+func (j *Jamendo) fetchPlaylistTracks(ctx context.Context, playlistItem *jamendoPlaylistItem) ([]model.Song, error) {
 	if playlistItem == nil || len(playlistItem.Tracks) == 0 {
-		return nil, errors.New("playlist is empty or invalid")
+		return nil, model.Errorf(model.ErrNotFound, "playlist is empty or invalid")
 	}
 
 	songs := make([]model.Song, len(playlistItem.Tracks))
@@ -220,7 +222,7 @@ func (j *Jamendo) fetchPlaylistTracks(playlistItem *jamendoPlaylistItem) ([]mode
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			song, err := j.getTrackByID(strconv.Itoa(trackID), jamendoTrackMeta{})
+			song, err := j.getTrackByID(ctx, strconv.Itoa(trackID), jamendoTrackMeta{})
 			if err != nil {
 				errMu.Lock()
 				if firstErr == nil {
@@ -257,9 +259,9 @@ func (j *Jamendo) fetchPlaylistTracks(playlistItem *jamendoPlaylistItem) ([]mode
 	return filtered, nil
 }
 
-func (j *Jamendo) fetchAlbumTracks(albumItem *jamendoAlbumItem, creator string) ([]model.Song, error) {
+func (j *Jamendo) fetchAlbumTracks(ctx context.Context, albumItem *jamendoAlbumItem, creator string) ([]model.Song, error) {
 	if albumItem == nil || len(albumItem.Tracks) == 0 {
-		return nil, errors.New("album is empty or invalid")
+		return nil, model.Errorf(model.ErrNotFound, "album is empty or invalid")
 	}
 
 	songs := make([]model.Song, len(albumItem.Tracks))
@@ -289,7 +291,7 @@ func (j *Jamendo) fetchAlbumTracks(albumItem *jamendoAlbumItem, creator string)
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			song, err := j.getTrackByID(strconv.Itoa(trackID), meta)
+			song, err := j.getTrackByID(ctx, strconv.Itoa(trackID), meta)
 			if err != nil {
 				errMu.Lock()
 				if firstErr == nil {
@@ -326,11 +328,11 @@ func (j *Jamendo) fetchAlbumTracks(albumItem *jamendoAlbumItem, creator string)
 	return filtered, nil
 }
 
-func (j *Jamendo) getTrackByID(id string, meta jamendoTrackMeta) (*model.Song, error) {
+func (j *Jamendo) getTrackByID(ctx context.Context, id string, meta jamendoTrackMeta) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("id", id)
 
-	body, err := j.apiGet(TrackAPI+"?"+params.Encode(), TrackApiPath)
+	body, err := j.apiGet(ctx, TrackAPI+"?"+params.Encode(), TrackApiPath)
 	if err != nil {
 		return nil, err
 	}
@@ -340,23 +342,23 @@ func (j *Jamendo) getTrackByID(id string, meta jamendoTrackMeta) (*model.Song, e
 		return nil, fmt.Errorf("jamendo track json error: %w", err)
 	}
 	if len(results) == 0 {
-		return nil, errors.New("track not found")
+		return nil, model.Errorf(model.ErrNotFound, "track not found")
 	}
 
 	item := results[0]
-	resolvedMeta, err := j.resolveTrackMeta(item, meta)
+	resolvedMeta, err := j.resolveTrackMeta(ctx, item, meta)
 	if err != nil {
 		return nil, err
 	}
 
 	song := buildSong(item, resolvedMeta)
 	if song == nil {
-		return nil, errors.New("no valid stream found")
+		return nil, model.Errorf(model.ErrNotFound, "no valid stream found")
 	}
 	return song, nil
 }
 
-func (j *Jamendo) resolveTrackMeta(item jamendoTrackItem, meta jamendoTrackMeta) (jamendoTrackMeta, error) {
+func (j *Jamendo) resolveTrackMeta(ctx context.Context, item jamendoTrackItem, meta jamendoTrackMeta) (jamendoTrackMeta, error) {
 	if meta.AlbumID == "" && item.AlbumID > 0 {
 		meta.AlbumID = strconv.Itoa(item.AlbumID)
 	}
@@ -368,7 +370,7 @@ func (j *Jamendo) resolveTrackMeta(item jamendoTrackItem, meta jamendoTrackMeta)
 	}
 
 	if meta.AlbumName == "" && item.AlbumID > 0 {
-		albumItem, err := j.getAlbumByID(strconv.Itoa(item.AlbumID))
+		albumItem, err := j.getAlbumByID(ctx, strconv.Itoa(item.AlbumID))
 		if err != nil {
 			return meta, err
 		}
@@ -379,7 +381,7 @@ func (j *Jamendo) resolveTrackMeta(item jamendoTrackItem, meta jamendoTrackMeta)
 	}
 
 	if meta.ArtistName == "" && item.ArtistID > 0 {
-		artistName, err := j.getArtistNameByID(item.ArtistID)
+		artistName, err := j.getArtistNameByID(ctx, item.ArtistID)
 		if err != nil {
 			return meta, err
 		}
@@ -389,11 +391,11 @@ func (j *Jamendo) resolveTrackMeta(item jamendoTrackItem, meta jamendoTrackMeta)
 	return meta, nil
 }
 
-func (j *Jamendo) getAlbumByID(id string) (*jamendoAlbumItem, error) {
+func (j *Jamendo) getAlbumByID(ctx context.Context, id string) (*jamendoAlbumItem, error) {
 	params := url.Values{}
 	params.Set("id", id)
 
-	body, err := j.apiGet(AlbumAPI+"?"+params.Encode(), AlbumApiPath)
+	body, err := j.apiGet(ctx, AlbumAPI+"?"+params.Encode(), AlbumApiPath)
 	if err != nil {
 		return nil, err
 	}
@@ -403,17 +405,17 @@ func (j *Jamendo) getAlbumByID(id string) (*jamendoAlbumItem, error) {
 		return nil, fmt.Errorf("jamendo album json error: %w", err)
 	}
 	if len(results) == 0 {
-		return nil, errors.New("album not found")
+		return nil, model.Errorf(model.ErrNotFound, "album not found")
 	}
 
 	return &results[0], nil
 }
 
-func (j *Jamendo) getPlaylistByID(id string) (*jamendoPlaylistItem, error) {
+func (j *Jamendo) getPlaylistByID(ctx context.Context, id string) (*jamendoPlaylistItem, error) {
 	params := url.Values{}
 	params.Set("id", id)
 
-	body, err := j.apiGet(PlaylistAPI+"?"+params.Encode(), PlaylistApiPath)
+	body, err := j.apiGet(ctx, PlaylistAPI+"?"+params.Encode(), PlaylistApiPath)
 	if err != nil {
 		return nil, err
 	}
@@ -423,13 +425,13 @@ func (j *Jamendo) getPlaylistByID(id string) (*jamendoPlaylistItem, error) {
 		return nil, fmt.Errorf("jamendo playlist json error: %w", err)
 	}
 	if len(results) == 0 {
-		return nil, errors.New("playlist not found")
+		return nil, model.Errorf(model.ErrNotFound, "playlist not found")
 	}
 
 	return &results[0], nil
 }
 
-func (j *Jamendo) getArtistNameByID(id int) (string, error) {
+func (j *Jamendo) getArtistNameByID(ctx context.Context, id int) (string, error) {
 	if id == 0 {
 		return "", nil
 	}
@@ -437,7 +439,7 @@ func (j *Jamendo) getArtistNameByID(id int) (string, error) {
 	params := url.Values{}
 	params.Set("id", strconv.Itoa(id))
 
-	body, err := j.apiGet(ArtistAPI+"?"+params.Encode(), ArtistApiPath)
+	body, err := j.apiGet(ctx, ArtistAPI+"?"+params.Encode(), ArtistApiPath)
 	if err != nil {
 		return "", err
 	}
@@ -447,24 +449,24 @@ func (j *Jamendo) getArtistNameByID(id int) (string, error) {
 		return "", fmt.Errorf("jamendo artist json error: %w", err)
 	}
 	if len(results) == 0 {
-		return "", errors.New("artist not found")
+		return "", model.Errorf(model.ErrNotFound, "artist not found")
 	}
 
 	return results[0].Name, nil
 }
 
-func (j *Jamendo) searchByType(keyword, searchType string) ([]byte, error) {
+func (j *Jamendo) searchByType(ctx context.Context, keyword, searchType string) ([]byte, error) {
 	params := url.Values{}
 	params.Set("query", keyword)
 	params.Set("type", searchType)
 	params.Set("limit", "20")
 	params.Set("identities", "www")
 
-	return j.apiGet(SearchAPI+"?"+params.Encode(), SearchApiPath)
+	return j.apiGet(ctx, SearchAPI+"?"+params.Encode(), SearchApiPath)
 }
 
-func (j *Jamendo) apiGet(apiURL, path string) ([]byte, error) {
-	return utils.Get(apiURL,
+func (j *Jamendo) apiGet(ctx context.Context, apiURL, path string) ([]byte, error) {
+	return j.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("x-jam-call", makeXJamCall(path)),
@@ -1,15 +1,24 @@
 package jamendo
 
 import (
-	"errors"
+	"context"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
 func GetLyrics(s *model.Song) (string, error) { return defaultJamendo.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultJamendo.GetLyricsContext(ctx, s)
+}
+
 func (j *Jamendo) GetLyrics(s *model.Song) (string, error) {
+	return j.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (j *Jamendo) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "jamendo" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	return "", nil
 }
@@ -1,6 +1,7 @@
 package jamendo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,32 +15,67 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultJamendo.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultJamendo.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) {
 	return defaultJamendo.GetPlaylistSongs(id)
 }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultJamendo.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultJamendo.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultJamendo.ParsePlaylistContext(ctx, link)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultJamendo.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultJamendo.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultJamendo.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultJamendo.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (j *Jamendo) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return j.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext is like GetPlaylistCategories but carries ctx through its requests.
+func (j *Jamendo) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 func (j *Jamendo) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return j.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext is like GetCategoryPlaylists but carries ctx through its requests.
+func (j *Jamendo) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 func (j *Jamendo) SearchPlaylist(keyword string) ([]model.Playlist, error) {
-	body, err := j.searchByType(keyword, "playlist")
+	return j.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext is like SearchPlaylist but carries ctx through its requests.
+func (j *Jamendo) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	body, err := j.searchByType(ctx, keyword, "playlist")
 	if err != nil {
 		return nil, err
 	}
@@ -69,22 +105,32 @@ func (j *Jamendo) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 }
 
 func (j *Jamendo) GetPlaylistSongs(id string) ([]model.Song, error) {
-	playlistItem, err := j.getPlaylistByID(id)
+	return j.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext is like GetPlaylistSongs but carries ctx through its requests.
+func (j *Jamendo) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	playlistItem, err := j.getPlaylistByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return j.fetchPlaylistTracks(playlistItem)
+	return j.fetchPlaylistTracks(ctx, playlistItem)
 }
 
 func (j *Jamendo) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return j.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext is like ParsePlaylist but carries ctx through its requests.
+func (j *Jamendo) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	re := regexp.MustCompile(`jamendo\.com/playlist/(\d+)`)
 	matches := re.FindStringSubmatch(link)
 	if len(matches) >= 2 {
-		return j.fetchPlaylistDetail(matches[1])
+		return j.fetchPlaylistDetail(ctx, matches[1])
 	}
 
 	if len(link) > 0 && !strings.Contains(link, "/") {
-		return j.fetchPlaylistDetail(link)
+		return j.fetchPlaylistDetail(ctx, link)
 	}
 
 	return nil, nil, errors.New("invalid jamendo playlist link")
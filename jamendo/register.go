@@ -0,0 +1,45 @@
+package jamendo
+
+import (
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func init() {
+	registry.Register(registry.Entry{
+		Name: "jamendo",
+		New: func(cookie string, opts ...utils.ClientOption) interface{} {
+			return New(cookie, opts...)
+		},
+		Capabilities: []provider.Capability{
+			provider.CapSearch,
+			provider.CapParse,
+			provider.CapDownload,
+			provider.CapLyrics,
+			provider.CapAlbum,
+			provider.CapPlaylist,
+		},
+		Descriptor: provider.Descriptor{
+			Source: "jamendo",
+			Name:   "Jamendo",
+			Note:   "歌单搜索可能返回空，公开歌单链接可解析",
+			Features: map[provider.Feature]provider.FeatureSupport{
+				provider.FeatureSearch:            {Level: provider.Supported},
+				provider.FeatureDownload:          {Level: provider.Supported},
+				provider.FeatureLyrics:            {Level: provider.Supported},
+				provider.FeatureSongParse:         {Level: provider.Supported},
+				provider.FeaturePlaylistSearch:    {Level: provider.Unstable, Note: "可能返回空，公开歌单链接可解析"},
+				provider.FeaturePlaylistRecommend: {Level: provider.Unsupported},
+				provider.FeaturePlaylistSongs:     {Level: provider.Supported},
+				provider.FeaturePlaylistParse:     {Level: provider.Supported},
+				provider.FeatureAlbumSearch:       {Level: provider.Supported},
+				provider.FeatureAlbumSongs:        {Level: provider.Supported},
+				provider.FeatureAlbumParse:        {Level: provider.Supported},
+				provider.FeatureUserPlaylist:      {Level: provider.Unsupported},
+				provider.FeatureQRLogin:           {Level: provider.Unsupported},
+				provider.FeaturePlaylistCategory:  {Level: provider.Unsupported},
+			},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package jamendo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,10 +11,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultJamendo.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultJamendo.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultJamendo.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultJamendo.ParseContext(ctx, link)
+}
+
 func (j *Jamendo) Search(keyword string) ([]model.Song, error) {
-	body, err := j.searchByType(keyword, "track")
+	return j.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but carries ctx through its requests.
+func (j *Jamendo) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	body, err := j.searchByType(ctx, keyword, "track")
 	if err != nil {
 		return nil, err
 	}
@@ -35,11 +49,16 @@ func (j *Jamendo) Search(keyword string) ([]model.Song, error) {
 }
 
 func (j *Jamendo) Parse(link string) (*model.Song, error) {
+	return j.ParseContext(context.Background(), link)
+}
+
+// ParseContext is like Parse but carries ctx through its requests.
+func (j *Jamendo) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	re := regexp.MustCompile(`jamendo\.com/track/(\d+)`)
 	matches := re.FindStringSubmatch(link)
 	if len(matches) < 2 {
 		return nil, errors.New("invalid jamendo link")
 	}
 
-	return j.getTrackByID(matches[1], jamendoTrackMeta{})
+	return j.getTrackByID(ctx, matches[1], jamendoTrackMeta{})
 }
@@ -1,11 +1,23 @@
 package jamendo
 
-import "github.com/guohuiyuan/music-lib/model"
+import (
+	"context"
+	"github.com/guohuiyuan/music-lib/model"
+)
 
 func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultJamendo.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultJamendo.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 func (p *Jamendo) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return p.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (p *Jamendo) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrUserPlaylistsUnsupported
 }
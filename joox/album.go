@@ -1,6 +1,7 @@
 package joox
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,21 +16,38 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultJoox.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultJoox.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) { return defaultJoox.GetAlbumSongs(id) }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultJoox.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultJoox.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultJoox.ParseAlbumContext(ctx, link)
+}
+
 // SearchPlaylist 搜索歌单
 func (j *Joox) SearchAlbum(keyword string) ([]model.Playlist, error) {
+	return j.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext 与 SearchAlbum 相同，但请求受 ctx 控制。
+func (j *Joox) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("country", "sg")
 	params.Set("lang", "zh_cn")
 	params.Set("keyword", keyword)
 	apiURL := "https://cache.api.joox.com/openjoox/v3/search?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := j.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -103,11 +121,21 @@ func (j *Joox) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 // GetPlaylistSongs 获取歌单详情 (Updated to use OpenJoox v3 API)
 func (j *Joox) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := j.fetchAlbumDetail(id)
+	return j.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext 与 GetAlbumSongs 相同，但请求受 ctx 控制。
+func (j *Joox) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := j.fetchAlbumDetail(ctx, id)
 	return songs, err
 }
 
 func (j *Joox) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return j.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext 与 ParseAlbum 相同，但请求受 ctx 控制。
+func (j *Joox) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`joox\.com/.*/album/([^/?#]+)`),
 		regexp.MustCompile(`h_activity_id=([^&]+)`),
@@ -117,12 +145,12 @@ func (j *Joox) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return j.fetchAlbumDetail(matches[1])
+			return j.fetchAlbumDetail(ctx, matches[1])
 		}
 	}
 
 	if len(link) > 10 && !strings.Contains(link, "/") {
-		return j.fetchAlbumDetail(link)
+		return j.fetchAlbumDetail(ctx, link)
 	}
 
 	return nil, nil, errors.New("invalid joox album link")
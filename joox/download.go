@@ -1,16 +1,25 @@
 package joox
 
 import (
-	"errors"
+	"context"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultJoox.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultJoox.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL 获取下载链接
 func (j *Joox) GetDownloadURL(s *model.Song) (string, error) {
+	return j.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext 与 GetDownloadURL 相同，但请求受 ctx 控制。
+func (j *Joox) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "joox" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	if s.URL != "" {
 		return s.URL, nil
@@ -22,7 +31,7 @@ func (j *Joox) GetDownloadURL(s *model.Song) (string, error) {
 	}
 
 	// 复用核心逻辑
-	info, err := j.fetchSongInfo(songID)
+	info, err := j.fetchSongInfo(ctx, songID)
 	if err != nil {
 		return "", err
 	}
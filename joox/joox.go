@@ -1,6 +1,7 @@
 package joox
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,13 +21,14 @@ const (
 
 type Joox struct {
 	cookie string
+	client *utils.Client
 }
 
-func New(cookie string) *Joox {
+func New(cookie string, opts ...utils.ClientOption) *Joox {
 	if cookie == "" {
 		cookie = Cookie
 	}
-	return &Joox{cookie: cookie}
+	return &Joox{cookie: cookie, client: utils.NewClient(opts...)}
 }
 
 var defaultJoox = New(Cookie)
@@ -34,8 +36,8 @@ var defaultJoox = New(Cookie)
 // [新增]
 // [新增]
 
-func (j *Joox) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
-	playlist, songs, err := j.fetchPlaylistPageData(id)
+func (j *Joox) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
+	playlist, songs, err := j.fetchPlaylistPageData(ctx, id)
 	if err == nil {
 		return playlist, songs, nil
 	}
@@ -45,7 +47,7 @@ func (j *Joox) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, er
 		return nil, nil, errors.New("playlist id is empty")
 	}
 
-	songs, songsErr := j.GetPlaylistSongs(playlistID)
+	songs, songsErr := j.GetPlaylistSongsContext(ctx, playlistID)
 	if songsErr != nil {
 		return nil, nil, err
 	}
@@ -68,12 +70,12 @@ func (j *Joox) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, er
 	return playlist, songs, nil
 }
 
-func (j *Joox) fetchPlaylistSongsFromPage(id string) ([]model.Song, error) {
-	_, songs, err := j.fetchPlaylistPageData(id)
+func (j *Joox) fetchPlaylistSongsFromPage(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := j.fetchPlaylistPageData(ctx, id)
 	return songs, err
 }
 
-func (j *Joox) fetchPlaylistPageData(id string) (*model.Playlist, []model.Song, error) {
+func (j *Joox) fetchPlaylistPageData(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	playlistID := normalizeJooxID(id)
 	if playlistID == "" {
 		return nil, nil, errors.New("playlist id is empty")
@@ -81,7 +83,7 @@ func (j *Joox) fetchPlaylistPageData(id string) (*model.Playlist, []model.Song,
 
 	var lastErr error
 	for _, pageURL := range jooxPlaylistLinks(playlistID) {
-		playlist, songs, err := j.fetchPlaylistPageDataFromURL(playlistID, pageURL)
+		playlist, songs, err := j.fetchPlaylistPageDataFromURL(ctx, playlistID, pageURL)
 		if err == nil {
 			return playlist, songs, nil
 		}
@@ -93,8 +95,8 @@ func (j *Joox) fetchPlaylistPageData(id string) (*model.Playlist, []model.Song,
 	return nil, nil, errors.New("joox playlist page data not found")
 }
 
-func (j *Joox) fetchPlaylistPageDataFromURL(playlistID string, pageURL string) (*model.Playlist, []model.Song, error) {
-	body, err := utils.Get(pageURL,
+func (j *Joox) fetchPlaylistPageDataFromURL(ctx context.Context, playlistID string, pageURL string) (*model.Playlist, []model.Song, error) {
+	body, err := j.client.GetContext(ctx, pageURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -188,15 +190,15 @@ func (j *Joox) fetchPlaylistPageDataFromURL(playlistID string, pageURL string) (
 }
 
 // Parse 解析链接并获取完整信息
-func (j *Joox) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
-	albumData, err := j.fetchAlbumPageData(id)
+func (j *Joox) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
+	albumData, err := j.fetchAlbumPageData(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	albumID := normalizeJooxID(albumData.ID)
 	if albumID == "" {
-		return nil, nil, errors.New("album not found")
+		return nil, nil, model.Errorf(model.ErrNotFound, "album not found")
 	}
 
 	trackCount := albumData.TrackList.TotalCount
@@ -258,14 +260,14 @@ func (j *Joox) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error
 	return album, songs, nil
 }
 
-func (j *Joox) fetchAlbumPageData(id string) (*jooxAlbumPageData, error) {
+func (j *Joox) fetchAlbumPageData(ctx context.Context, id string) (*jooxAlbumPageData, error) {
 	albumID := normalizeJooxID(id)
 	if albumID == "" {
 		return nil, errors.New("album id is empty")
 	}
 
 	pageURL := jooxAlbumLink(albumID)
-	body, err := utils.Get(pageURL,
+	body, err := j.client.GetContext(ctx, pageURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -301,7 +303,7 @@ func (j *Joox) fetchAlbumPageData(id string) (*jooxAlbumPageData, error) {
 		albumData = nextData.Props.PageProps.Content.Page.AlbumData
 	}
 	if normalizeJooxID(albumData.ID) == "" {
-		return nil, errors.New("album data not found")
+		return nil, model.Errorf(model.ErrNotFound, "album data not found")
 	}
 
 	return &albumData, nil
@@ -513,7 +515,7 @@ func jooxSongFromTrack(item jooxAlbumTrack, fallbackAlbum, fallbackCover, fallba
 }
 
 // fetchSongInfo 内部函数：获取歌曲详情和下载链接
-func (j *Joox) fetchSongInfo(songID string) (*model.Song, error) {
+func (j *Joox) fetchSongInfo(ctx context.Context, songID string) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("songid", songID)
 	params.Set("lang", "zh_cn")
@@ -521,7 +523,7 @@ func (j *Joox) fetchSongInfo(songID string) (*model.Song, error) {
 
 	apiURL := "https://api.joox.com/web-fcgi-bin/web_get_songinfo?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := j.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -593,7 +595,7 @@ func (j *Joox) fetchSongInfo(songID string) (*model.Song, error) {
 	}
 
 	if downloadURL == "" {
-		return nil, errors.New("no valid download url found")
+		return nil, model.Errorf(model.ErrVIPRequired, "no valid download url found")
 	}
 
 	return &model.Song{
@@ -1,9 +1,9 @@
 package joox
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -13,10 +13,19 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultJoox.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultJoox.GetLyricsContext(ctx, s)
+}
+
 // GetLyrics 获取歌词
 func (j *Joox) GetLyrics(s *model.Song) (string, error) {
+	return j.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (j *Joox) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "joox" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	songID := s.ID
@@ -30,7 +39,7 @@ func (j *Joox) GetLyrics(s *model.Song) (string, error) {
 	params.Set("lang", "zh_cn")
 	apiURL := "https://api.joox.com/web-fcgi-bin/web_lyric?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := j.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -52,7 +61,7 @@ func (j *Joox) GetLyrics(s *model.Song) (string, error) {
 		return "", fmt.Errorf("joox lyric json parse error: %w", err)
 	}
 	if resp.Lyric == "" {
-		return "", errors.New("lyric not found or empty")
+		return "", model.Errorf(model.ErrNotFound, "lyric not found or empty")
 	}
 
 	decodedBytes, err := base64.StdEncoding.DecodeString(resp.Lyric)
@@ -1,6 +1,7 @@
 package joox
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -18,22 +19,47 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultJoox.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultJoox.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) { return defaultJoox.GetPlaylistSongs(id) }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultJoox.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultJoox.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultJoox.ParsePlaylistContext(ctx, link)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultJoox.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultJoox.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultJoox.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultJoox.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (j *Joox) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
-	data, err := j.fetchJooxPlaylistCategoriesPage()
+	return j.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext is like GetPlaylistCategories but carries ctx through its requests.
+func (j *Joox) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	data, err := j.fetchJooxPlaylistCategoriesPage(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +95,11 @@ func (j *Joox) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 }
 
 func (j *Joox) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return j.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext is like GetCategoryPlaylists but carries ctx through its requests.
+func (j *Joox) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	categoryID = strings.TrimSpace(categoryID)
 	if page < 1 {
 		page = 1
@@ -77,7 +108,7 @@ func (j *Joox) GetCategoryPlaylists(categoryID string, page, limit int) ([]model
 		limit = 20
 	}
 
-	data, err := j.fetchJooxPlaylistCategoriesPage()
+	data, err := j.fetchJooxPlaylistCategoriesPage(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -150,13 +181,18 @@ func (j *Joox) GetCategoryPlaylists(categoryID string, page, limit int) ([]model
 }
 
 func (j *Joox) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return j.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext is like SearchPlaylist but carries ctx through its requests.
+func (j *Joox) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("country", "sg")
 	params.Set("lang", "zh_cn")
 	params.Set("keyword", keyword)
 	apiURL := "https://cache.api.joox.com/openjoox/v3/search?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := j.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -243,6 +279,11 @@ func (j *Joox) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 }
 
 func (j *Joox) GetPlaylistSongs(id string) ([]model.Song, error) {
+	return j.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext is like GetPlaylistSongs but carries ctx through its requests.
+func (j *Joox) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
 	params := url.Values{}
 	// The new v3 API uses "id" instead of "playlistid"
 	params.Set("id", id)
@@ -253,13 +294,13 @@ func (j *Joox) GetPlaylistSongs(id string) ([]model.Song, error) {
 	// Guessing the endpoint is /playlist based on /search pattern
 	apiURL := "https://cache.api.joox.com/openjoox/v3/playlist?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := j.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
 	)
 	if err != nil {
-		if fallbackSongs, fallbackErr := j.fetchPlaylistSongsFromPage(id); fallbackErr == nil && len(fallbackSongs) > 0 {
+		if fallbackSongs, fallbackErr := j.fetchPlaylistSongsFromPage(ctx, id); fallbackErr == nil && len(fallbackSongs) > 0 {
 			return fallbackSongs, nil
 		}
 		return nil, err
@@ -293,7 +334,7 @@ func (j *Joox) GetPlaylistSongs(id string) ([]model.Song, error) {
 	}
 
 	if err := json.Unmarshal(body, &resp); err != nil {
-		if fallbackSongs, fallbackErr := j.fetchPlaylistSongsFromPage(id); fallbackErr == nil && len(fallbackSongs) > 0 {
+		if fallbackSongs, fallbackErr := j.fetchPlaylistSongsFromPage(ctx, id); fallbackErr == nil && len(fallbackSongs) > 0 {
 			return fallbackSongs, nil
 		}
 		return nil, fmt.Errorf("joox playlist json error: %w", err)
@@ -358,7 +399,7 @@ func (j *Joox) GetPlaylistSongs(id string) ([]model.Song, error) {
 	}
 
 	if !foundSongs {
-		if fallbackSongs, fallbackErr := j.fetchPlaylistSongsFromPage(id); fallbackErr == nil && len(fallbackSongs) > 0 {
+		if fallbackSongs, fallbackErr := j.fetchPlaylistSongsFromPage(ctx, id); fallbackErr == nil && len(fallbackSongs) > 0 {
 			return fallbackSongs, nil
 		}
 		// If no songs found, the ID might be invalid or the playlist is empty
@@ -369,6 +410,11 @@ func (j *Joox) GetPlaylistSongs(id string) ([]model.Song, error) {
 }
 
 func (j *Joox) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return j.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext is like ParsePlaylist but carries ctx through its requests.
+func (j *Joox) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`joox\.com/.*/playlist/([^/?#]+)`),
 		regexp.MustCompile(`(?:playlistid|playlist_id|id)=([^&]+)`),
@@ -377,12 +423,12 @@ func (j *Joox) ParsePlaylist(link string) (*model.Playlist, []model.Song, error)
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return j.fetchPlaylistDetail(matches[1])
+			return j.fetchPlaylistDetail(ctx, matches[1])
 		}
 	}
 
 	if len(link) > 8 && !strings.Contains(link, "/") {
-		return j.fetchPlaylistDetail(link)
+		return j.fetchPlaylistDetail(ctx, link)
 	}
 
 	return nil, nil, errors.New("invalid joox playlist link")
@@ -404,8 +450,8 @@ type jooxCategoryPlaylistItem struct {
 	PicURL string      `json:"picurl"`
 }
 
-func (j *Joox) fetchJooxPlaylistCategoriesPage() (*jooxPlaylistCategoryPage, error) {
-	body, err := utils.Get("https://www.joox.com/sg/playlist",
+func (j *Joox) fetchJooxPlaylistCategoriesPage(ctx context.Context) (*jooxPlaylistCategoryPage, error) {
+	body, err := j.client.GetContext(ctx, "https://www.joox.com/sg/playlist",
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -0,0 +1,46 @@
+package joox
+
+import (
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func init() {
+	registry.Register(registry.Entry{
+		Name: "joox",
+		New: func(cookie string, opts ...utils.ClientOption) interface{} {
+			return New(cookie, opts...)
+		},
+		Capabilities: []provider.Capability{
+			provider.CapSearch,
+			provider.CapParse,
+			provider.CapDownload,
+			provider.CapLyrics,
+			provider.CapAlbum,
+			provider.CapPlaylist,
+			provider.CapPlaylistCategory,
+		},
+		Descriptor: provider.Descriptor{
+			Source: "joox",
+			Name:   "JOOX",
+			Note:   "歌单支持 OpenJOOX 接口和网页数据兜底",
+			Features: map[provider.Feature]provider.FeatureSupport{
+				provider.FeatureSearch:            {Level: provider.Supported},
+				provider.FeatureDownload:          {Level: provider.Supported},
+				provider.FeatureLyrics:            {Level: provider.Supported},
+				provider.FeatureSongParse:         {Level: provider.Supported},
+				provider.FeaturePlaylistSearch:    {Level: provider.Supported},
+				provider.FeaturePlaylistRecommend: {Level: provider.Unsupported},
+				provider.FeaturePlaylistSongs:     {Level: provider.Supported},
+				provider.FeaturePlaylistParse:     {Level: provider.Supported},
+				provider.FeatureAlbumSearch:       {Level: provider.Supported},
+				provider.FeatureAlbumSongs:        {Level: provider.Supported},
+				provider.FeatureAlbumParse:        {Level: provider.Supported},
+				provider.FeatureUserPlaylist:      {Level: provider.Unsupported},
+				provider.FeatureQRLogin:           {Level: provider.Unsupported},
+				provider.FeaturePlaylistCategory:  {Level: provider.Supported},
+			},
+		},
+	})
+}
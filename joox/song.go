@@ -1,6 +1,7 @@
 package joox
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,17 +14,30 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultJoox.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultJoox.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultJoox.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultJoox.ParseContext(ctx, link)
+}
+
 // Search 搜索歌曲
 func (j *Joox) Search(keyword string) ([]model.Song, error) {
+	return j.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext 与 Search 相同，但请求受 ctx 控制。
+func (j *Joox) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("country", "sg")
 	params.Set("lang", "zh_cn")
 	params.Set("keyword", keyword)
 	apiURL := "https://cache.api.joox.com/openjoox/v3/search?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := j.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", j.cookie),
 		utils.WithHeader("X-Forwarded-For", XForwardedFor),
@@ -103,6 +117,11 @@ func (j *Joox) Search(keyword string) ([]model.Song, error) {
 }
 
 func (j *Joox) Parse(link string) (*model.Song, error) {
+	return j.ParseContext(context.Background(), link)
+}
+
+// ParseContext 与 Parse 相同，但请求受 ctx 控制。
+func (j *Joox) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	// 1. 提取 ID
 	// 支持格式: https://www.joox.com/hk/single/C+Q0... 或纯 ID
 	re := regexp.MustCompile(`joox\.com/.*/single/([^/?#]+)`)
@@ -120,5 +139,5 @@ func (j *Joox) Parse(link string) (*model.Song, error) {
 	}
 
 	// 2. 调用核心逻辑获取详情
-	return j.fetchSongInfo(songID)
+	return j.fetchSongInfo(ctx, songID)
 }
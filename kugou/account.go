@@ -1,6 +1,7 @@
 package kugou
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -9,8 +10,17 @@ import (
 
 func IsVipAccount() (bool, error) { return defaultKugou.IsVipAccount() }
 
+func IsVipAccountContext(ctx context.Context) (bool, error) {
+	return defaultKugou.IsVipAccountContext(ctx)
+}
+
 // IsVipAccount 返回当前 cookie 是否已经探测到可用的 VIP 音质链路。
 func (k *Kugou) IsVipAccount() (bool, error) {
+	return k.IsVipAccountContext(context.Background())
+}
+
+// IsVipAccountContext 与 IsVipAccount 相同，但请求受 ctx 控制。
+func (k *Kugou) IsVipAccountContext(ctx context.Context) (bool, error) {
 	if k.isVipCache != nil {
 		return *k.isVipCache, nil
 	}
@@ -21,7 +31,7 @@ func (k *Kugou) IsVipAccount() (bool, error) {
 		return false, nil
 	}
 
-	body, err := utils.Get(VIPInfoAPI,
+	body, err := k.client.GetContext(ctx, VIPInfoAPI,
 		utils.WithHeader("User-Agent", PCUserAgent),
 		utils.WithHeader("Accept", "*/*"),
 		utils.WithHeader("Host", "vip.kugou.com"),
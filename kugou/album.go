@@ -1,6 +1,7 @@
 package kugou
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,18 +17,35 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultKugou.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultKugou.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := defaultKugou.fetchAlbumDetail(id)
+	_, songs, err := defaultKugou.fetchAlbumDetail(context.Background(), id)
 	return songs, err
 }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultKugou.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultKugou.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultKugou.ParseAlbumContext(ctx, link)
+}
+
 // SearchPlaylist 搜索歌单
 // SearchAlbum searches albums.
 func (k *Kugou) SearchAlbum(keyword string) ([]model.Playlist, error) {
+	return k.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext is like SearchAlbum but carries ctx through its requests.
+func (k *Kugou) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("keyword", keyword)
 	params.Set("format", "json")
@@ -35,7 +53,7 @@ func (k *Kugou) SearchAlbum(keyword string) ([]model.Playlist, error) {
 	params.Set("pagesize", "10")
 	apiURL := "http://mobilecdn.kugou.com/api/v3/search/album?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -65,7 +83,7 @@ func (k *Kugou) SearchAlbum(keyword string) ([]model.Playlist, error) {
 		return nil, fmt.Errorf("kugou album search json error: %w", err)
 	}
 	if resp.Errcode != 0 || resp.Status != 1 {
-		return nil, fmt.Errorf("kugou album search api error: status=%d errcode=%d error=%s", resp.Status, resp.Errcode, resp.Error)
+		return nil, model.CodeError(apiErrorKinds, resp.Errcode, "kugou album search api error: status=%d errcode=%d error=%s", resp.Status, resp.Errcode, resp.Error)
 	}
 
 	albums := make([]model.Playlist, 0, len(resp.Data.Info))
@@ -101,12 +119,22 @@ func (k *Kugou) SearchAlbum(keyword string) ([]model.Playlist, error) {
 // GetPlaylistSongs 获取歌单详情 (仅返回 Songs, 兼容旧接口)
 // GetAlbumSongs returns songs in an album.
 func (k *Kugou) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := k.fetchAlbumDetail(id)
+	return k.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext is like GetAlbumSongs but carries ctx through its requests.
+func (k *Kugou) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := k.fetchAlbumDetail(ctx, id)
 	return songs, err
 }
 
 // ParseAlbum parses an album link.
 func (k *Kugou) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return k.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext is like ParseAlbum but carries ctx through its requests.
+func (k *Kugou) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`album/single/(\d+)\.html`),
 		regexp.MustCompile(`yy/album/single/(\d+)\.html`),
@@ -117,7 +145,7 @@ func (k *Kugou) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return k.fetchAlbumDetail(matches[1])
+			return k.fetchAlbumDetail(ctx, matches[1])
 		}
 	}
 
@@ -0,0 +1,69 @@
+package kugou
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+// buildKGM 按 KGM v3 格式加密音频，vpr 为 true 时生成 VPR 文件
+func buildKGM(audio []byte, vpr bool) []byte {
+	head := make([]byte, 0x400)
+	if vpr {
+		copy(head, vprMagic)
+	} else {
+		copy(head, kgmMagic)
+	}
+	binary.LittleEndian.PutUint32(head[0x10:], uint32(len(head)))
+	binary.LittleEndian.PutUint32(head[0x14:], 3)
+	binary.LittleEndian.PutUint32(head[0x18:], 1)
+	copy(head[0x2C:], "0123456789abcdef")
+
+	c := &kgmCipher{
+		slotBox: kugouMD5(kgmSlotKeys[1]),
+		fileBox: append(kugouMD5(head[0x2C:0x3C]), 0x6B),
+		vpr:     vpr,
+	}
+	enc := append([]byte(nil), audio...)
+	for i := range enc {
+		// 按解密的相反顺序执行，b ^= b << 4 是自身的逆运算
+		b := enc[i]
+		if vpr {
+			b ^= vprMaskDiff[i%len(vprMaskDiff)]
+		}
+		b ^= byte(i) ^ byte(i>>8) ^ byte(i>>16) ^ byte(i>>24)
+		b ^= c.slotBox[i%len(c.slotBox)]
+		b ^= b << 4
+		enc[i] = b ^ c.fileBox[i%len(c.fileBox)]
+	}
+	return append(head, enc...)
+}
+
+func TestDecryptKGM(t *testing.T) {
+	audio := append([]byte("fLaC\x00\x00\x00\x22"), bytes.Repeat([]byte("kugou"), 100000)...)
+	for _, vpr := range []bool{false, true} {
+		encrypted := buildKGM(audio, vpr)
+		if !IsKGM(encrypted) {
+			t.Fatal("IsKGM should recognize the header")
+		}
+
+		got, ext, err := DecryptKGM(encrypted)
+		if err != nil || ext != "flac" || !bytes.Equal(got, audio) {
+			t.Fatalf("DecryptKGM(vpr=%v) = %q, %v", vpr, ext, err)
+		}
+
+		r, _, err := NewKGMReader(iotest.HalfReader(bytes.NewReader(encrypted)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := io.ReadAll(r); !bytes.Equal(got, audio) {
+			t.Fatalf("NewKGMReader(vpr=%v) stream mismatch", vpr)
+		}
+	}
+
+	if _, _, err := DecryptKGM([]byte("not a kgm file")); err == nil {
+		t.Fatal("expected error for invalid header")
+	}
+}
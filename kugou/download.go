@@ -1,6 +1,7 @@
 package kugou
 
 import (
+	"context"
 	"errors"
 	"github.com/guohuiyuan/music-lib/model"
 	"strings"
@@ -8,14 +9,27 @@ import (
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultKugou.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultKugou.GetDownloadURLContext(ctx, s)
+}
+
 func GetDownloadURLBySonginfo(s *model.Song) (string, error) {
 	return defaultKugou.GetDownloadURLBySonginfo(s)
 }
 
+func GetDownloadURLBySonginfoContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultKugou.GetDownloadURLBySonginfoContext(ctx, s)
+}
+
 // GetDownloadURL 获取下载链接
 func (k *Kugou) GetDownloadURL(s *model.Song) (string, error) {
+	return k.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext 与 GetDownloadURL 相同，但请求受 ctx 控制。
+func (k *Kugou) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "kugou" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	if s.URL != "" {
 		return s.URL, nil
@@ -29,19 +43,19 @@ func (k *Kugou) GetDownloadURL(s *model.Song) (string, error) {
 	privilege := getKugouPrivilege(s)
 
 	if privilege == 10 || privilege == 8 {
-		if info, err := k.fetchVIPSongInfo(s); err == nil && info != nil && info.URL != "" {
+		if info, err := k.fetchVIPSongInfo(ctx, s); err == nil && info != nil && info.URL != "" {
 			return info.URL, nil
 		}
 	}
 
-	isVip, vipErr := k.IsVipAccount()
+	isVip, vipErr := k.IsVipAccountContext(ctx)
 	if vipErr == nil && isVip {
-		if info, err := k.fetchTrackerSongInfo(hash); err == nil && info != nil && info.URL != "" {
+		if info, err := k.fetchTrackerSongInfo(ctx, hash); err == nil && info != nil && info.URL != "" {
 			return info.URL, nil
 		}
 	}
 
-	info, err := k.fetchSongInfo(hash)
+	info, err := k.fetchSongInfo(ctx, hash)
 	if err != nil {
 		return "", err
 	}
@@ -49,9 +63,14 @@ func (k *Kugou) GetDownloadURL(s *model.Song) (string, error) {
 }
 
 func (k *Kugou) GetDownloadURLBySonginfo(s *model.Song) (string, error) {
+	return k.GetDownloadURLBySonginfoContext(context.Background(), s)
+}
+
+// GetDownloadURLBySonginfoContext 与 GetDownloadURLBySonginfo 相同，但请求受 ctx 控制。
+func (k *Kugou) GetDownloadURLBySonginfoContext(ctx context.Context, s *model.Song) (string, error) {
 	var lastErr error
 	for _, hash := range collectCandidateHashes(s) {
-		info, err := k.fetchSonginfoV2(hash)
+		info, err := k.fetchSonginfoV2(ctx, hash)
 		if err != nil {
 			lastErr = err
 			continue
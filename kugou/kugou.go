@@ -1,6 +1,7 @@
 package kugou
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,22 +31,30 @@ const (
 type Kugou struct {
 	cookie     string
 	isVipCache *bool
+	client     *utils.Client
 }
 
-func New(cookie string) *Kugou { return &Kugou{cookie: cookie} }
+func New(cookie string, opts ...utils.ClientOption) *Kugou {
+	return &Kugou{cookie: cookie, client: utils.NewClient(opts...)}
+}
 
 var defaultKugou = New("")
 
+// apiErrorKinds 酷狗接口 errcode / error_code 对应的错误分类
+var apiErrorKinds = map[int]error{
+	1002: model.ErrRateLimited, // 操作太频繁 (风控)
+}
+
 // fetchPlaylistDetail [内部复用] 获取歌单详情 (Metadata + Songs)
 // fetchAlbumDetail returns album metadata and songs.
-func (k *Kugou) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
+func (k *Kugou) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	id = strings.TrimSpace(id)
 	if id == "" {
 		return nil, nil, errors.New("album id is empty")
 	}
 
 	infoURL := fmt.Sprintf("http://mobilecdn.kugou.com/api/v3/album/info?albumid=%s&version=9108&area_code=1", id)
-	infoBody, err := utils.Get(infoURL,
+	infoBody, err := k.client.GetContext(ctx, infoURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -74,7 +83,7 @@ func (k *Kugou) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, erro
 		return nil, nil, fmt.Errorf("kugou album info json error: %w", err)
 	}
 	if infoResp.Errcode != 0 || infoResp.Status != 1 {
-		return nil, nil, fmt.Errorf("kugou album info api error: status=%d errcode=%d error=%s", infoResp.Status, infoResp.Errcode, infoResp.Error)
+		return nil, nil, model.CodeError(apiErrorKinds, infoResp.Errcode, "kugou album info api error: status=%d errcode=%d error=%s", infoResp.Status, infoResp.Errcode, infoResp.Error)
 	}
 
 	const pageSize = 300
@@ -83,7 +92,7 @@ func (k *Kugou) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, erro
 
 	for page := 1; ; page++ {
 		songURL := fmt.Sprintf("http://mobilecdn.kugou.com/api/v3/album/song?albumid=%s&page=%d&pagesize=%d&version=9108&area_code=1", id, page, pageSize)
-		body, err := utils.Get(songURL,
+		body, err := k.client.GetContext(ctx, songURL,
 			utils.WithHeader("User-Agent", MobileUserAgent),
 			utils.WithHeader("Cookie", k.cookie),
 			utils.WithRandomIPHeader(),
@@ -132,7 +141,7 @@ func (k *Kugou) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, erro
 			return nil, nil, fmt.Errorf("kugou album songs json error: %w", err)
 		}
 		if resp.Errcode != 0 || resp.Status != 1 {
-			return nil, nil, fmt.Errorf("kugou album songs api error: status=%d errcode=%d error=%s", resp.Status, resp.Errcode, resp.Error)
+			return nil, nil, model.CodeError(apiErrorKinds, resp.Errcode, "kugou album songs api error: status=%d errcode=%d error=%s", resp.Status, resp.Errcode, resp.Error)
 		}
 
 		if total == 0 {
@@ -272,14 +281,14 @@ func (k *Kugou) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, erro
 	return album, songs, nil
 }
 
-func (k *Kugou) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (k *Kugou) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(id)), "gcid_") {
-		return k.fetchSonglistDetail(id)
+		return k.fetchSonglistDetail(ctx, id)
 	}
 
 	apiURL := fmt.Sprintf("http://mobilecdn.kugou.com/api/v3/special/song?specialid=%s&page=1&pagesize=300&version=9108&area_code=1", id)
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -424,11 +433,11 @@ func (k *Kugou) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, e
 	return playlist, songs, nil
 }
 
-func (k *Kugou) fetchSonglistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (k *Kugou) fetchSonglistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	id = strings.TrimSpace(id)
 	apiURL := fmt.Sprintf("https://www.kugou.com/songlist/%s/", id)
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
@@ -512,7 +521,7 @@ func (k *Kugou) fetchSonglistDetail(id string) (*model.Playlist, []model.Song, e
 		Link:        fmt.Sprintf("https://www.kugou.com/songlist/%s/", playlistID),
 	}
 
-	isVip, _ := k.IsVipAccount()
+	isVip, _ := k.IsVipAccountContext(ctx)
 	songs := make([]model.Song, 0, len(resp.Info.Songs))
 	for _, item := range resp.Info.Songs {
 		if !isVip && item.Privilege == 10 {
@@ -610,15 +619,15 @@ func (k *Kugou) fetchSonglistDetail(id string) (*model.Playlist, []model.Song, e
 	return playlist, songs, nil
 }
 
-func (k *Kugou) fetchVIPSongInfo(s *model.Song) (*model.Song, error) {
+func (k *Kugou) fetchVIPSongInfo(ctx context.Context, s *model.Song) (*model.Song, error) {
 	if strings.TrimSpace(k.cookie) == "" {
-		return nil, errors.New("cookie required for kugou vip download")
+		return nil, model.Errorf(model.ErrAuthExpired, "cookie required for kugou vip download")
 	}
 
 	var fallback *model.Song
 	var lastErr error
 	for _, hash := range collectCandidateHashes(s) {
-		info, err := k.fetchURLV5(s, hash)
+		info, err := k.fetchURLV5(ctx, s, hash)
 		if err == nil && info != nil && info.URL != "" {
 			if looksLossless(info.Ext, info.Bitrate, info.Size) {
 				isVip := true
@@ -632,7 +641,7 @@ func (k *Kugou) fetchVIPSongInfo(s *model.Song) (*model.Song, error) {
 			lastErr = err
 		}
 
-		info, err = k.fetchPrivURLV6(s, hash)
+		info, err = k.fetchPrivURLV6(ctx, s, hash)
 		if err == nil && info != nil && info.URL != "" {
 			if looksLossless(info.Ext, info.Bitrate, info.Size) {
 				isVip := true
@@ -646,7 +655,7 @@ func (k *Kugou) fetchVIPSongInfo(s *model.Song) (*model.Song, error) {
 			lastErr = err
 		}
 
-		info, err = k.fetchSonginfoV2(hash)
+		info, err = k.fetchSonginfoV2(ctx, hash)
 		if err == nil && info != nil && info.URL != "" {
 			if looksLossless(info.Ext, info.Bitrate, info.Size) {
 				isVip := true
@@ -660,7 +669,7 @@ func (k *Kugou) fetchVIPSongInfo(s *model.Song) (*model.Song, error) {
 			lastErr = err
 		}
 
-		info, err = k.fetchTrackerSongInfo(hash)
+		info, err = k.fetchTrackerSongInfo(ctx, hash)
 		if err == nil && info != nil && info.URL != "" {
 			if looksLossless(info.Ext, info.Bitrate, info.Size) {
 				isVip := true
@@ -684,10 +693,10 @@ func (k *Kugou) fetchVIPSongInfo(s *model.Song) (*model.Song, error) {
 	if lastErr != nil {
 		return nil, lastErr
 	}
-	return nil, errors.New("kugou vip download url not found")
+	return nil, model.Errorf(model.ErrVIPRequired, "kugou vip download url not found")
 }
 
-func (k *Kugou) fetchURLV5(s *model.Song, hash string) (*model.Song, error) {
+func (k *Kugou) fetchURLV5(ctx context.Context, s *model.Song, hash string) (*model.Song, error) {
 	hash = strings.ToLower(strings.TrimSpace(hash))
 	if !isValidHash(hash) {
 		return nil, errors.New("invalid kugou hash")
@@ -698,7 +707,7 @@ func (k *Kugou) fetchURLV5(s *model.Song, hash string) (*model.Song, error) {
 	userID := strings.TrimSpace(cookie["userid"])
 	mid := strings.TrimSpace(cookie["KUGOU_API_MID"])
 	if token == "" || userID == "" || userID == "0" || mid == "" {
-		return nil, errors.New("kugou v5 url requires app token, userid and KUGOU_API_MID")
+		return nil, model.Errorf(model.ErrAuthExpired, "kugou v5 url requires app token, userid and KUGOU_API_MID")
 	}
 
 	albumAudioID := "0"
@@ -747,7 +756,7 @@ func (k *Kugou) fetchURLV5(s *model.Song, hash string) (*model.Song, error) {
 	params["key"] = utils.MD5(hash + KugouLiteKey + KugouLiteAppID + mid + userID)
 	apiURL := buildKugouAndroidURL("https://gateway.kugou.com/v5/url", params, "")
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Android15-1070-11083-46-0-DiscoveryDRADProtocol-wifi"),
 		utils.WithHeader("x-router", "trackercdn.kugou.com"),
 		utils.WithHeader("dfid", dfid),
@@ -770,7 +779,7 @@ func (k *Kugou) fetchURLV5(s *model.Song, hash string) (*model.Song, error) {
 	}
 	downloadURL := pickKugouResponseURL(resp)
 	if strings.TrimSpace(downloadURL) == "" {
-		return nil, fmt.Errorf("kugou v5 url unavailable, status=%d error_code=%d", findKugouInt(resp, "status"), findKugouInt(resp, "error_code", "errcode"))
+		return nil, model.CodeError(apiErrorKinds, findKugouInt(resp, "error_code", "errcode"), "kugou v5 url unavailable, status=%d error_code=%d", findKugouInt(resp, "status"), findKugouInt(resp, "error_code", "errcode"))
 	}
 
 	ext := normalizeKugouExt(findKugouString(resp, "fileType", "extName", "extname"), downloadURL)
@@ -797,10 +806,10 @@ func (k *Kugou) fetchURLV5(s *model.Song, hash string) (*model.Song, error) {
 	}, nil
 }
 
-func (k *Kugou) fetchSonginfoV2(hash string) (*model.Song, error) {
+func (k *Kugou) fetchSonginfoV2(ctx context.Context, hash string) (*model.Song, error) {
 	cookie := parseKugouCookie(k.cookie)
 	if strings.TrimSpace(cookie["t"]) == "" || strings.TrimSpace(cookie["KugooID"]) == "" {
-		return nil, errors.New("kugou songinfo v2 requires cookie t and KugooID")
+		return nil, model.Errorf(model.ErrAuthExpired, "kugou songinfo v2 requires cookie t and KugooID")
 	}
 
 	baseParams := map[string]string{
@@ -831,7 +840,7 @@ func (k *Kugou) fetchSonginfoV2(hash string) (*model.Song, error) {
 		} `json:"data"`
 		Status int `json:"status"`
 	}
-	if err := k.getSonginfoV2(step1Params, &step1Resp); err != nil {
+	if err := k.getSonginfoV2(ctx, step1Params, &step1Resp); err != nil {
 		return nil, err
 	}
 	if strings.TrimSpace(step1Resp.Data.EncodeAlbumAudioID) == "" {
@@ -856,7 +865,7 @@ func (k *Kugou) fetchSonginfoV2(hash string) (*model.Song, error) {
 		} `json:"data"`
 		Status int `json:"status"`
 	}
-	if err := k.getSonginfoV2(step2Params, &step2Resp); err != nil {
+	if err := k.getSonginfoV2(ctx, step2Params, &step2Resp); err != nil {
 		return nil, err
 	}
 
@@ -890,7 +899,7 @@ func (k *Kugou) fetchSonginfoV2(hash string) (*model.Song, error) {
 	return info, nil
 }
 
-func (k *Kugou) fetchPrivURLV6(s *model.Song, hash string) (*model.Song, error) {
+func (k *Kugou) fetchPrivURLV6(ctx context.Context, s *model.Song, hash string) (*model.Song, error) {
 	hash = strings.ToLower(strings.TrimSpace(hash))
 	if !isValidHash(hash) {
 		return nil, errors.New("invalid kugou hash")
@@ -901,7 +910,7 @@ func (k *Kugou) fetchPrivURLV6(s *model.Song, hash string) (*model.Song, error)
 	userID := strings.TrimSpace(cookie["userid"])
 	mid := strings.TrimSpace(cookie["KUGOU_API_MID"])
 	if token == "" || userID == "" || userID == "0" || mid == "" {
-		return nil, errors.New("kugou priv_url v6 requires app token, userid and KUGOU_API_MID")
+		return nil, model.Errorf(model.ErrAuthExpired, "kugou priv_url v6 requires app token, userid and KUGOU_API_MID")
 	}
 
 	albumAudioID := ""
@@ -909,7 +918,7 @@ func (k *Kugou) fetchPrivURLV6(s *model.Song, hash string) (*model.Song, error)
 		albumAudioID = firstNonEmpty(s.Extra["album_audio_id"], s.Extra["audio_id"])
 	}
 	if albumAudioID == "" {
-		if info, err := k.fetchSonginfoV2(hash); err == nil && info != nil && info.Extra != nil {
+		if info, err := k.fetchSonginfoV2(ctx, hash); err == nil && info != nil && info.Extra != nil {
 			albumAudioID = firstNonEmpty(info.Extra["album_audio_id"], info.Extra["audio_id"])
 		}
 	}
@@ -971,7 +980,7 @@ func (k *Kugou) fetchPrivURLV6(s *model.Song, hash string) (*model.Song, error)
 	}
 	apiURL := buildKugouAndroidURL("http://tracker.kugou.com/v6/priv_url", params, string(jsonData))
 
-	body, err := utils.Post(apiURL, strings.NewReader(string(jsonData)),
+	body, err := k.client.PostContext(ctx, apiURL, strings.NewReader(string(jsonData)),
 		utils.WithHeader("User-Agent", "Android15-1070-11083-46-0-DiscoveryDRADProtocol-wifi"),
 		utils.WithHeader("Content-Type", "application/json"),
 		utils.WithHeader("dfid", dfid),
@@ -995,7 +1004,7 @@ func (k *Kugou) fetchPrivURLV6(s *model.Song, hash string) (*model.Song, error)
 
 	downloadURL := pickKugouResponseURL(resp)
 	if strings.TrimSpace(downloadURL) == "" {
-		return nil, fmt.Errorf("kugou priv_url v6 unavailable, status=%d error_code=%d", findKugouInt(resp, "status"), findKugouInt(resp, "error_code", "errcode"))
+		return nil, model.CodeError(apiErrorKinds, findKugouInt(resp, "error_code", "errcode"), "kugou priv_url v6 unavailable, status=%d error_code=%d", findKugouInt(resp, "status"), findKugouInt(resp, "error_code", "errcode"))
 	}
 
 	ext := normalizeKugouExt(findKugouString(resp, "fileType", "extName", "extname"), downloadURL)
@@ -1028,9 +1037,9 @@ func (k *Kugou) fetchPrivURLV6(s *model.Song, hash string) (*model.Song, error)
 	}, nil
 }
 
-func (k *Kugou) getSonginfoV2(params map[string]string, out interface{}) error {
+func (k *Kugou) getSonginfoV2(ctx context.Context, params map[string]string, out interface{}) error {
 	apiURL := buildKugouSonginfoURL(params)
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", PCUserAgent),
 		utils.WithHeader("Referer", "https://www.kugou.com/"),
 		utils.WithHeader("Cookie", k.cookie),
@@ -1047,14 +1056,14 @@ func (k *Kugou) getSonginfoV2(params map[string]string, out interface{}) error {
 }
 
 // fetchSongInfo 内部核心逻辑：获取详情和 URL
-func (k *Kugou) fetchSongInfo(hash string) (*model.Song, error) {
+func (k *Kugou) fetchSongInfo(ctx context.Context, hash string) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("cmd", "playInfo")
 	params.Set("hash", hash)
 
 	apiURL := "http://m.kugou.com/app/i/getSongInfo.php?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
@@ -1082,12 +1091,12 @@ func (k *Kugou) fetchSongInfo(hash string) (*model.Song, error) {
 	}
 
 	// errcode 1002 代表操作太频繁 (风控)
-	if resp.Errcode != 0 || resp.URL == "" {
-		return nil, fmt.Errorf("kugou song info unavailable, errcode=%d", resp.Errcode)
+	if resp.Errcode != 0 {
+		return nil, model.CodeError(apiErrorKinds, resp.Errcode, "kugou song info unavailable, errcode=%d", resp.Errcode)
 	}
 
 	if resp.URL == "" {
-		return nil, errors.New("download url not found (might be paid song)")
+		return nil, model.Errorf(model.ErrVIPRequired, "download url not found (might be paid song)")
 	}
 
 	cover := strings.Replace(resp.AlbumImg, "{size}", "240", 1)
@@ -1111,7 +1120,7 @@ func (k *Kugou) fetchSongInfo(hash string) (*model.Song, error) {
 }
 
 // fallbackFetchSongInfo 备用 API：如果原接口被风控 (1002)，使用 PC 网页端 API 进行 Fallback
-func (k *Kugou) fetchTrackerSongInfo(hash string) (*model.Song, error) {
+func (k *Kugou) fetchTrackerSongInfo(ctx context.Context, hash string) (*model.Song, error) {
 	hash = strings.ToLower(strings.TrimSpace(hash))
 	if !isValidHash(hash) {
 		return nil, errors.New("invalid kugou hash")
@@ -1124,7 +1133,7 @@ func (k *Kugou) fetchTrackerSongInfo(hash string) (*model.Song, error) {
 	}
 
 	for _, apiURL := range apiURLs {
-		body, err := utils.Get(apiURL,
+		body, err := k.client.GetContext(ctx, apiURL,
 			utils.WithHeader("User-Agent", PCUserAgent),
 			utils.WithHeader("Referer", "https://www.kugou.com/"),
 			utils.WithHeader("Cookie", k.cookie),
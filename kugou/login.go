@@ -2,6 +2,7 @@ package kugou
 
 import (
 	"bytes"
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/md5"
@@ -30,9 +31,22 @@ MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQDECi0Np2UR87scwrvTr72L6oO01rBbbBPriSDFPxr3
 
 func CreateQRLogin() (*model.QRLoginSession, error) { return defaultKugou.CreateQRLogin() }
 
+func CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
+	return defaultKugou.CreateQRLoginContext(ctx)
+}
+
 func CheckQRLogin(key string) (*model.QRLoginResult, error) { return defaultKugou.CheckQRLogin(key) }
 
+func CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
+	return defaultKugou.CheckQRLoginContext(ctx, key)
+}
+
 func (k *Kugou) CreateQRLogin() (*model.QRLoginSession, error) {
+	return k.CreateQRLoginContext(context.Background())
+}
+
+// CreateQRLoginContext is like CreateQRLogin but carries ctx through its requests.
+func (k *Kugou) CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
 	cookies := initKugouLoginDevice(nil)
 	params := map[string]string{
 		"appid":      "1001",
@@ -41,7 +55,7 @@ func (k *Kugou) CreateQRLogin() (*model.QRLoginSession, error) {
 		"qrcode_txt": "https://h5.kugou.com/apps/loginQRCode/html/index.html?appid=" + KugouLiteAppID + "&",
 		"srcappid":   "2919",
 	}
-	body, err := kugouLoginWebGet("https://login-user.kugou.com/v2/qrcode", params, cookies)
+	body, err := k.kugouLoginWebGet(ctx, "https://login-user.kugou.com/v2/qrcode", params, cookies)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +73,7 @@ func (k *Kugou) CreateQRLogin() (*model.QRLoginSession, error) {
 	}
 	key := strings.TrimSpace(resp.Data.QRCode)
 	if key == "" {
-		return nil, fmt.Errorf("kugou qr key api error: status=%d error_code=%d error=%s", resp.Status, resp.ErrorCode, resp.Error)
+		return nil, model.CodeError(apiErrorKinds, resp.ErrorCode, "kugou qr key api error: status=%d error_code=%d error=%s", resp.Status, resp.ErrorCode, resp.Error)
 	}
 
 	loginURL := "https://h5.kugou.com/apps/loginQRCode/html/index.html?qrcode=" + url.QueryEscape(key)
@@ -72,6 +86,11 @@ func (k *Kugou) CreateQRLogin() (*model.QRLoginSession, error) {
 }
 
 func (k *Kugou) CheckQRLogin(key string) (*model.QRLoginResult, error) {
+	return k.CheckQRLoginContext(context.Background(), key)
+}
+
+// CheckQRLoginContext is like CheckQRLogin but carries ctx through its requests.
+func (k *Kugou) CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
 	key = strings.TrimSpace(key)
 	if key == "" {
 		return nil, fmt.Errorf("kugou qr login key is empty")
@@ -84,7 +103,7 @@ func (k *Kugou) CheckQRLogin(key string) (*model.QRLoginResult, error) {
 		"srcappid": "2919",
 		"qrcode":   key,
 	}
-	body, err := kugouLoginWebGet("https://login-user.kugou.com/v2/get_userinfo_qrcode", params, cookies)
+	body, err := k.kugouLoginWebGet(ctx, "https://login-user.kugou.com/v2/get_userinfo_qrcode", params, cookies)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +147,7 @@ func (k *Kugou) CheckQRLogin(key string) (*model.QRLoginResult, error) {
 
 	cookies["token"] = token
 	cookies["userid"] = userID
-	if err := registerKugouLoginDevice(cookies); err != nil {
+	if err := k.registerKugouLoginDevice(ctx, cookies); err != nil {
 		result.Extra["register_error"] = err.Error()
 	}
 	result.Cookies = cookies
@@ -150,7 +169,7 @@ func initKugouLoginDevice(cookies map[string]string) map[string]string {
 	return cookies
 }
 
-func kugouLoginWebGet(apiURL string, params map[string]string, cookies map[string]string) ([]byte, error) {
+func (k *Kugou) kugouLoginWebGet(ctx context.Context, apiURL string, params map[string]string, cookies map[string]string) ([]byte, error) {
 	clienttime := strconv.FormatInt(time.Now().Unix(), 10)
 	finalParams := map[string]string{
 		"dfid":       firstNonEmpty(cookies["dfid"], "-"),
@@ -168,7 +187,7 @@ func kugouLoginWebGet(apiURL string, params map[string]string, cookies map[strin
 		query.Set(key, value)
 	}
 	query.Set("signature", signKugouSonginfoParams(finalParams))
-	return utils.Get(apiURL+"?"+query.Encode(),
+	return k.client.GetContext(ctx, apiURL+"?"+query.Encode(),
 		utils.WithHeader("User-Agent", "Android15-1070-11083-46-0-DiscoveryDRADProtocol-wifi"),
 		utils.WithHeader("dfid", finalParams["dfid"]),
 		utils.WithHeader("clienttime", clienttime),
@@ -182,7 +201,7 @@ func kugouLoginWebGet(apiURL string, params map[string]string, cookies map[strin
 	)
 }
 
-func registerKugouLoginDevice(cookies map[string]string) error {
+func (k *Kugou) registerKugouLoginDevice(ctx context.Context, cookies map[string]string) error {
 	aesSeed := strings.ToLower(randomKugouString(6))
 	digest := utils.MD5(aesSeed)
 	aesKey := []byte(digest[:16])
@@ -253,7 +272,7 @@ func registerKugouLoginDevice(cookies map[string]string) error {
 		"p":          p,
 	}
 	apiURL := buildKugouAndroidURL("https://userservice.kugou.com/risk/v2/r_register_dev", params, data)
-	body, err := utils.Post(apiURL, strings.NewReader(data),
+	body, err := k.client.PostContext(ctx, apiURL, strings.NewReader(data),
 		utils.WithHeader("User-Agent", "Android15-1070-11083-46-0-DiscoveryDRADProtocol-wifi"),
 		utils.WithHeader("dfid", params["dfid"]),
 		utils.WithHeader("clienttime", clienttime),
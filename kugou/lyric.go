@@ -1,9 +1,9 @@
 package kugou
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/lyrics"
 	"github.com/guohuiyuan/music-lib/model"
@@ -13,10 +13,39 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultKugou.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultKugou.GetLyricsContext(ctx, s)
+}
+
+func GetLyricsData(s *model.Song) (*lyrics.Lyrics, error) { return defaultKugou.GetLyricsData(s) }
+
+func GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error) {
+	return defaultKugou.GetLyricsDataContext(ctx, s)
+}
+
 // GetLyrics 获得歌词
 func (k *Kugou) GetLyrics(s *model.Song) (string, error) {
+	return k.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (k *Kugou) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	l, err := k.GetLyricsDataContext(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	return l.LRC(), nil
+}
+
+// GetLyricsData 获取结构化歌词，保留逐字时间轴以及翻译、音译轨道
+func (k *Kugou) GetLyricsData(s *model.Song) (*lyrics.Lyrics, error) {
+	return k.GetLyricsDataContext(context.Background(), s)
+}
+
+// GetLyricsDataContext is like GetLyricsData but carries ctx through its requests.
+func (k *Kugou) GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error) {
 	if s.Source != "kugou" {
-		return "", errors.New("source mismatch")
+		return nil, model.ErrSourceMismatch
 	}
 
 	hash := s.ID
@@ -26,14 +55,14 @@ func (k *Kugou) GetLyrics(s *model.Song) (string, error) {
 
 	searchURL := fmt.Sprintf("http://krcs.kugou.com/search?ver=1&client=mobi&duration=%d&hash=%s&album_audio_id=", s.Duration*1000, hash)
 
-	body, err := utils.Get(searchURL,
+	body, err := k.client.GetContext(ctx, searchURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
 	)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var searchResp struct {
@@ -46,24 +75,24 @@ func (k *Kugou) GetLyrics(s *model.Song) (string, error) {
 	}
 
 	if err := json.Unmarshal(body, &searchResp); err != nil {
-		return "", fmt.Errorf("search lyrics json parse error: %w", err)
+		return nil, fmt.Errorf("search lyrics json parse error: %w", err)
 	}
 
 	if len(searchResp.Candidates) == 0 {
-		return "", errors.New("lyrics not found")
+		return nil, model.Errorf(model.ErrNotFound, "lyrics not found")
 	}
 
 	candidate := searchResp.Candidates[0]
 	downloadURL := fmt.Sprintf("http://lyrics.kugou.com/download?ver=1&client=pc&id=%v&accesskey=%s&fmt=krc&charset=utf8", candidate.ID, candidate.AccessKey)
 
-	lrcBody, err := utils.Get(downloadURL,
+	lrcBody, err := k.client.GetContext(ctx, downloadURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
 	)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var downloadResp struct {
@@ -73,10 +102,10 @@ func (k *Kugou) GetLyrics(s *model.Song) (string, error) {
 		ContentType int    `json:"contenttype"`
 	}
 	if err := json.Unmarshal(lrcBody, &downloadResp); err != nil {
-		return "", fmt.Errorf("download lyrics json parse error: %w", err)
+		return nil, fmt.Errorf("download lyrics json parse error: %w", err)
 	}
 	if downloadResp.Content == "" {
-		return "", errors.New("lyrics content is empty")
+		return nil, model.Errorf(model.ErrNotFound, "lyrics content is empty")
 	}
 
 	tags := map[string]string{
@@ -88,7 +117,7 @@ func (k *Kugou) GetLyrics(s *model.Song) (string, error) {
 	if downloadResp.ContentType == 2 || downloadResp.Fmt == "lrc" {
 		decodedBytes, err := base64.StdEncoding.DecodeString(downloadResp.Content)
 		if err != nil {
-			return "", fmt.Errorf("base64 decode error: %w", err)
+			return nil, fmt.Errorf("base64 decode error: %w", err)
 		}
 		lrcTags, lrcData := lyrics.ParseLRC(string(decodedBytes))
 		for k, v := range lrcTags {
@@ -100,7 +129,7 @@ func (k *Kugou) GetLyrics(s *model.Song) (string, error) {
 	} else {
 		krc, err := lyrics.DecodeKRCBase64(downloadResp.Content)
 		if err != nil {
-			return "", fmt.Errorf("krc decode error: %w", err)
+			return nil, fmt.Errorf("krc decode error: %w", err)
 		}
 		krcTags, krcData := lyrics.ParseKRC(krc)
 		for k, v := range krcTags {
@@ -111,7 +140,7 @@ func (k *Kugou) GetLyrics(s *model.Song) (string, error) {
 		data = krcData
 	}
 	if len(data["orig"]) == 0 {
-		return "", errors.New("lyrics content is empty")
+		return nil, model.Errorf(model.ErrNotFound, "lyrics content is empty")
 	}
-	return lyrics.ConvertVerbatimLRC(tags, data, lyrics.DefaultDisplayOrder()), nil
+	return lyrics.New(tags, data), nil
 }
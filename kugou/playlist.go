@@ -1,6 +1,7 @@
 package kugou
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,32 +18,61 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultKugou.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultKugou.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) {
 	// 保持原接口兼容性，仅返回 Songs
-	_, songs, err := defaultKugou.fetchPlaylistDetail(id)
+	_, songs, err := defaultKugou.fetchPlaylistDetail(context.Background(), id)
 	return songs, err
 }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultKugou.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultKugou.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultKugou.ParsePlaylistContext(ctx, link)
+}
+
 // GetRecommendedPlaylists 获取推荐歌单
 func GetRecommendedPlaylists() ([]model.Playlist, error) {
 	return defaultKugou.GetRecommendedPlaylists()
 }
 
+func GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
+	return defaultKugou.GetRecommendedPlaylistsContext(ctx)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultKugou.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultKugou.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultKugou.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultKugou.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (k *Kugou) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return k.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext 与 GetPlaylistCategories 相同，但请求受 ctx 控制。
+func (k *Kugou) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	apiURL := "http://mobilecdnbj.kugou.com/api/v3/tag/list?pid=0&apiver=2&plat=0"
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
@@ -73,7 +103,7 @@ func (k *Kugou) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 		return nil, fmt.Errorf("kugou playlist category json parse error: %w", err)
 	}
 	if resp.Status != 1 || resp.Errcode != 0 {
-		return nil, fmt.Errorf("kugou playlist category api error: %s (status %d errcode %d)", resp.Error, resp.Status, resp.Errcode)
+		return nil, model.CodeError(apiErrorKinds, resp.Errcode, "kugou playlist category api error: %s (status %d errcode %d)", resp.Error, resp.Status, resp.Errcode)
 	}
 
 	categories := []model.PlaylistCategory{{
@@ -120,6 +150,11 @@ func (k *Kugou) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 }
 
 func (k *Kugou) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return k.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext 与 GetCategoryPlaylists 相同，但请求受 ctx 控制。
+func (k *Kugou) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	categoryID = strings.TrimSpace(categoryID)
 	if page < 1 {
 		page = 1
@@ -128,7 +163,7 @@ func (k *Kugou) GetCategoryPlaylists(categoryID string, page, limit int) ([]mode
 		limit = 20
 	}
 	if categoryID == "" {
-		categories, err := k.GetPlaylistCategories()
+		categories, err := k.GetPlaylistCategoriesContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -156,7 +191,7 @@ func (k *Kugou) GetCategoryPlaylists(categoryID string, page, limit int) ([]mode
 	params.Set("id", id)
 	params.Set("sort", "2")
 	apiURL := "http://mobilecdnbj.kugou.com/api/v3/tag/specialList?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
@@ -189,7 +224,7 @@ func (k *Kugou) GetCategoryPlaylists(categoryID string, page, limit int) ([]mode
 		return nil, fmt.Errorf("kugou category playlist json parse error: %w", err)
 	}
 	if resp.Status != 1 || resp.Errcode != 0 {
-		return nil, fmt.Errorf("kugou category playlist api error: %s (status %d errcode %d)", resp.Error, resp.Status, resp.Errcode)
+		return nil, model.CodeError(apiErrorKinds, resp.Errcode, "kugou category playlist api error: %s (status %d errcode %d)", resp.Error, resp.Status, resp.Errcode)
 	}
 
 	playlists := make([]model.Playlist, 0, len(resp.Data.Info))
@@ -252,6 +287,11 @@ func parseKugouPlaylistCategoryID(categoryID string) (string, string) {
 }
 
 func (k *Kugou) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return k.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext 与 SearchPlaylist 相同，但请求受 ctx 控制。
+func (k *Kugou) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("keyword", keyword)
 	params.Set("platform", "WebFilter")
@@ -261,7 +301,7 @@ func (k *Kugou) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	params.Set("filter", "0")
 	apiURL := "http://mobilecdn.kugou.com/api/v3/search/special?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -308,12 +348,22 @@ func (k *Kugou) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 }
 
 func (k *Kugou) GetPlaylistSongs(id string) ([]model.Song, error) {
-	_, songs, err := k.fetchPlaylistDetail(id)
+	return k.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext 与 GetPlaylistSongs 相同，但请求受 ctx 控制。
+func (k *Kugou) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := k.fetchPlaylistDetail(ctx, id)
 	return songs, err
 }
 
 // ParsePlaylist 解析歌单链接
 func (k *Kugou) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return k.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext 与 ParsePlaylist 相同，但请求受 ctx 控制。
+func (k *Kugou) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	// 链接格式: https://www.kugou.com/yy/special/single/546903.html
 	switch {
 	case strings.Contains(link, "/yy/special/single/"):
@@ -322,14 +372,14 @@ func (k *Kugou) ParsePlaylist(link string) (*model.Playlist, []model.Song, error
 		if len(matches) < 2 {
 			return nil, nil, errors.New("invalid kugou playlist link")
 		}
-		return k.fetchPlaylistDetail(matches[1])
+		return k.fetchPlaylistDetail(ctx, matches[1])
 	case strings.Contains(link, "/songlist/"):
 		re := regexp.MustCompile(`songlist/(gcid_[a-zA-Z0-9]+)`)
 		matches := re.FindStringSubmatch(link)
 		if len(matches) < 2 {
 			return nil, nil, errors.New("invalid kugou songlist link")
 		}
-		return k.fetchPlaylistDetail(matches[1])
+		return k.fetchPlaylistDetail(ctx, matches[1])
 	default:
 		return nil, nil, errors.New("invalid kugou playlist link")
 	}
@@ -337,11 +387,16 @@ func (k *Kugou) ParsePlaylist(link string) (*model.Playlist, []model.Song, error
 
 // GetRecommendedPlaylists 获取推荐歌单
 func (k *Kugou) GetRecommendedPlaylists() ([]model.Playlist, error) {
+	return k.GetRecommendedPlaylistsContext(context.Background())
+}
+
+// GetRecommendedPlaylistsContext 与 GetRecommendedPlaylists 相同，但请求受 ctx 控制。
+func (k *Kugou) GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
 	// [修改] 使用 m.kugou.com 的 plist 接口，这个接口对 MobileUserAgent 更友好
 	// json=true 返回 JSON 数据
 	apiURL := "http://m.kugou.com/plist/index&json=true"
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
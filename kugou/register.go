@@ -0,0 +1,49 @@
+package kugou
+
+import (
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func init() {
+	registry.Register(registry.Entry{
+		Name: "kugou",
+		New: func(cookie string, opts ...utils.ClientOption) interface{} {
+			return New(cookie, opts...)
+		},
+		Capabilities: []provider.Capability{
+			provider.CapSearch,
+			provider.CapParse,
+			provider.CapDownload,
+			provider.CapLyrics,
+			provider.CapAlbum,
+			provider.CapPlaylist,
+			provider.CapRecommendedPlaylist,
+			provider.CapPlaylistCategory,
+			provider.CapUserPlaylist,
+			provider.CapQRLogin,
+		},
+		Descriptor: provider.Descriptor{
+			Source: "kugou",
+			Name:   "酷狗音乐",
+			Note:   "支持普通歌曲 FLAC 无损",
+			Features: map[provider.Feature]provider.FeatureSupport{
+				provider.FeatureSearch:            {Level: provider.Supported},
+				provider.FeatureDownload:          {Level: provider.Supported},
+				provider.FeatureLyrics:            {Level: provider.Supported},
+				provider.FeatureSongParse:         {Level: provider.Supported},
+				provider.FeaturePlaylistSearch:    {Level: provider.Supported},
+				provider.FeaturePlaylistRecommend: {Level: provider.Supported},
+				provider.FeaturePlaylistSongs:     {Level: provider.Supported},
+				provider.FeaturePlaylistParse:     {Level: provider.Supported},
+				provider.FeatureAlbumSearch:       {Level: provider.Supported},
+				provider.FeatureAlbumSongs:        {Level: provider.Supported},
+				provider.FeatureAlbumParse:        {Level: provider.Supported},
+				provider.FeatureUserPlaylist:      {Level: provider.Supported},
+				provider.FeatureQRLogin:           {Level: provider.Supported},
+				provider.FeaturePlaylistCategory:  {Level: provider.Supported},
+			},
+		},
+	})
+}
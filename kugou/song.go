@@ -1,6 +1,7 @@
 package kugou
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,8 +18,16 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultKugou.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultKugou.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultKugou.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultKugou.ParseContext(ctx, link)
+}
+
 type kugouSearchResponse struct {
 	Data struct {
 		Lists []kugouSearchItem `json:"lists"`
@@ -57,6 +66,11 @@ type kugouSearchItem struct {
 
 // Search 搜索歌曲
 func (k *Kugou) Search(keyword string) ([]model.Song, error) {
+	return k.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but carries ctx through its requests.
+func (k *Kugou) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("keyword", keyword)
 	params.Set("platform", "WebFilter")
@@ -81,7 +95,7 @@ func (k *Kugou) Search(keyword string) ([]model.Song, error) {
 		if withCookie && strings.TrimSpace(k.cookie) != "" {
 			options = append(options, utils.WithHeader("Cookie", k.cookie))
 		}
-		return utils.Get(apiURL, options...)
+		return k.client.GetContext(ctx, apiURL, options...)
 	}
 
 	body, err := fetchSearch(true)
@@ -103,7 +117,7 @@ func (k *Kugou) Search(keyword string) ([]model.Song, error) {
 		}
 	}
 
-	isVip, _ := k.IsVipAccount()
+	isVip, _ := k.IsVipAccountContext(ctx)
 
 	var songs []model.Song
 	for _, item := range resp.Data.Lists {
@@ -197,13 +211,18 @@ func (k *Kugou) Search(keyword string) ([]model.Song, error) {
 
 // Parse 解析链接
 func (k *Kugou) Parse(link string) (*model.Song, error) {
+	return k.ParseContext(context.Background(), link)
+}
+
+// ParseContext is like Parse but carries ctx through its requests.
+func (k *Kugou) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	re := regexp.MustCompile(`(?i)hash=([a-f0-9]{32})`)
 	matches := re.FindStringSubmatch(link)
 	if len(matches) < 2 {
 		return nil, errors.New("invalid kugou link or hash not found")
 	}
 	hash := matches[1]
-	return k.fetchSongInfo(hash)
+	return k.fetchSongInfo(ctx, hash)
 }
 
 func cleanKugouSearchText(value string) string {
@@ -1,6 +1,7 @@
 package kugou
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -16,11 +17,20 @@ func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultKugou.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultKugou.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 func (k *Kugou) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return k.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (k *Kugou) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	cookie := parseKugouCookie(k.cookie)
 	userID := firstNonEmpty(cookie["userid"], cookie["KugooID"])
 	if strings.TrimSpace(userID) == "" || userID == "0" {
-		return nil, fmt.Errorf("kugou user playlists require userid cookie")
+		return nil, model.Errorf(model.ErrAuthExpired, "kugou user playlists require userid cookie")
 	}
 	if page < 1 {
 		page = 1
@@ -37,7 +47,7 @@ func (k *Kugou) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	params.Set("page", strconv.Itoa(page))
 	params.Set("pagesize", strconv.Itoa(limit))
 	apiURL := "http://m.kugou.com/plist/index/" + url.PathEscape(userID) + "?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", MobileUserAgent),
 		utils.WithHeader("Referer", MobileReferer),
 		utils.WithHeader("Cookie", k.cookie),
@@ -47,19 +57,19 @@ func (k *Kugou) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 		if strings.TrimSpace(cookie["token"]) == "" || strings.TrimSpace(cookie["KUGOU_API_MID"]) == "" {
 			return nil, err
 		}
-		return k.getUserPlaylistsGateway(cookie, page, limit)
+		return k.getUserPlaylistsGateway(ctx, cookie, page, limit)
 	}
 	playlists, parseErr := parseKugouUserPlaylists(body, userID)
 	if parseErr != nil {
 		if strings.TrimSpace(cookie["token"]) == "" || strings.TrimSpace(cookie["KUGOU_API_MID"]) == "" {
 			return nil, parseErr
 		}
-		return k.getUserPlaylistsGateway(cookie, page, limit)
+		return k.getUserPlaylistsGateway(ctx, cookie, page, limit)
 	}
 	return playlists, nil
 }
 
-func (k *Kugou) getUserPlaylistsGateway(cookie map[string]string, page, limit int) ([]model.Playlist, error) {
+func (k *Kugou) getUserPlaylistsGateway(ctx context.Context, cookie map[string]string, page, limit int) ([]model.Playlist, error) {
 	userID := firstNonEmpty(cookie["userid"], cookie["KugooID"])
 	mid := firstNonEmpty(cookie["KUGOU_API_MID"], "-")
 	dfid := firstNonEmpty(cookie["dfid"], "-")
@@ -77,7 +87,7 @@ func (k *Kugou) getUserPlaylistsGateway(cookie map[string]string, page, limit in
 		"pagesize":   strconv.Itoa(limit),
 	}
 	apiURL := buildKugouAndroidURL("https://gateway.kugou.com/v1/user_special/list", params, "")
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Android15-1070-11083-46-0-DiscoveryDRADProtocol-wifi"),
 		utils.WithHeader("dfid", dfid),
 		utils.WithHeader("clienttime", clienttime),
@@ -146,7 +156,7 @@ func parseKugouUserPlaylists(body []byte, userID string) ([]model.Playlist, erro
 		return nil, fmt.Errorf("kugou user playlist json parse error: %w", err)
 	}
 	if resp.Status != 0 && resp.Status != 1 {
-		return nil, fmt.Errorf("kugou user playlist api error: status=%d errcode=%d error=%s", resp.Status, resp.Errcode, resp.Error)
+		return nil, model.CodeError(apiErrorKinds, resp.Errcode, "kugou user playlist api error: status=%d errcode=%d error=%s", resp.Status, resp.Errcode, resp.Error)
 	}
 
 	playlists := make([]model.Playlist, 0, len(resp.Data.Info)+len(resp.Data.List))
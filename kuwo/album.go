@@ -1,6 +1,7 @@
 package kuwo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
@@ -12,17 +13,34 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultKuwo.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultKuwo.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := defaultKuwo.fetchAlbumDetail(id)
+	_, songs, err := defaultKuwo.fetchAlbumDetail(context.Background(), id)
 	return songs, err
 }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultKuwo.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultKuwo.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultKuwo.ParseAlbumContext(ctx, link)
+}
+
 // SearchAlbum 搜索专辑
 func (k *Kuwo) SearchAlbum(keyword string) ([]model.Playlist, error) {
+	return k.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext 与 SearchAlbum 相同，但请求受 ctx 控制。
+func (k *Kuwo) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	var resp struct {
 		AlbumList []struct {
 			AlbumID  string `json:"albumid"`
@@ -40,7 +58,7 @@ func (k *Kuwo) SearchAlbum(keyword string) ([]model.Playlist, error) {
 		} `json:"albumlist"`
 	}
 
-	if err := k.searchCollection(keyword, "album", &resp); err != nil {
+	if err := k.searchCollection(ctx, keyword, "album", &resp); err != nil {
 		return nil, err
 	}
 
@@ -79,11 +97,21 @@ func (k *Kuwo) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 // GetPlaylistSongs 获取歌单详情（解析歌曲列表）
 func (k *Kuwo) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := k.fetchAlbumDetail(id)
+	return k.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext 与 GetAlbumSongs 相同，但请求受 ctx 控制。
+func (k *Kuwo) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := k.fetchAlbumDetail(ctx, id)
 	return songs, err
 }
 
 func (k *Kuwo) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return k.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext 与 ParseAlbum 相同，但请求受 ctx 控制。
+func (k *Kuwo) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`album_detail/(\d+)`),
 		regexp.MustCompile(`album/(\d+)`),
@@ -93,7 +121,7 @@ func (k *Kuwo) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return k.fetchAlbumDetail(matches[1])
+			return k.fetchAlbumDetail(ctx, matches[1])
 		}
 	}
 
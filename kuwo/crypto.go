@@ -0,0 +1,114 @@
+package kuwo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/guohuiyuan/music-lib/audio"
+)
+
+var (
+	kwmMagic    = []byte("yeelion-kuwo-tme")
+	kwmMagicOld = []byte("yeelion-kuwo\x00\x00\x00\x00")
+)
+
+const (
+	kwmHeaderSize = 0x400
+	kwmKeyBase    = "MoOtOiTvINGwd2E6n0E1i7L5t2IoOoNk"
+)
+
+// IsKWM 判断数据是否以 KWM 文件头开始
+func IsKWM(head []byte) bool {
+	return bytes.HasPrefix(head, kwmMagic) || bytes.HasPrefix(head, kwmMagicOld)
+}
+
+// DecryptKWM 解密酷我客户端下载的 kwm 文件，返回音频数据和扩展名
+func DecryptKWM(encrypted []byte) ([]byte, string, error) {
+	r, ext, err := NewKWMReader(bytes.NewReader(encrypted))
+	if err != nil {
+		return nil, "", err
+	}
+	audio, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	return audio, ext, nil
+}
+
+// NewKWMReader 读取 KWM 文件头，返回边读边解密的音频流和扩展名。
+// 扩展名优先取文件头中记录的格式 (例如 "2000kflac")，没有时按解密后的数据识别。
+func NewKWMReader(r io.Reader) (io.Reader, string, error) {
+	br := bufio.NewReader(r)
+	head := make([]byte, kwmHeaderSize)
+	if _, err := io.ReadFull(br, head); err != nil || !IsKWM(head) {
+		return nil, "", errors.New("invalid kwm file")
+	}
+
+	version := binary.LittleEndian.Uint32(head[0x10:])
+	if version > 1 {
+		return nil, "", fmt.Errorf("unsupported kwm cipher version %d", version)
+	}
+	kr := &kwmReader{r: br, mask: kwmMask(binary.LittleEndian.Uint64(head[0x18:]))}
+
+	_, ext := parseKWMFormat(head[0x30:0x40])
+	if ext == "" {
+		peek, _ := br.Peek(12)
+		plain := append([]byte(nil), peek...)
+		kr.xor(plain, 0)
+		if ext = audio.DetectExt(plain); ext == "" {
+			ext = "mp3"
+		}
+	}
+	return kr, ext, nil
+}
+
+// kwmMask 用资源 ID 的十进制字符串循环填满 32 字节，再与内置密钥异或
+func kwmMask(rid uint64) []byte {
+	id := strconv.FormatUint(rid, 10)
+	mask := make([]byte, len(kwmKeyBase))
+	for i := range mask {
+		mask[i] = kwmKeyBase[i] ^ id[i%len(id)]
+	}
+	return mask
+}
+
+// parseKWMFormat 解析 "320kmp3"、"2000kflac" 这样的码率和格式
+func parseKWMFormat(data []byte) (int, string) {
+	s := strings.TrimRight(string(data), "\x00")
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i < 0 {
+		return 0, ""
+	}
+	bitrate, _ := strconv.Atoi(s[:i])
+	ext := strings.ToLower(strings.TrimLeft(s[i:], "kK"))
+	switch ext {
+	case "mp3", "flac", "ogg", "m4a", "ape", "wav":
+		return bitrate, ext
+	}
+	return bitrate, ""
+}
+
+type kwmReader struct {
+	r    io.Reader
+	mask []byte
+	pos  int
+}
+
+func (r *kwmReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.xor(p[:n], r.pos)
+	r.pos += n
+	return n, err
+}
+
+func (r *kwmReader) xor(data []byte, pos int) {
+	for i := range data {
+		data[i] ^= r.mask[(pos+i)&0x1F]
+	}
+}
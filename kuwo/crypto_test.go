@@ -0,0 +1,60 @@
+package kuwo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func buildKWM(audio []byte, rid uint64, format string) []byte {
+	head := make([]byte, kwmHeaderSize)
+	copy(head, kwmMagic)
+	binary.LittleEndian.PutUint32(head[0x10:], 1)
+	binary.LittleEndian.PutUint64(head[0x18:], rid)
+	copy(head[0x30:], format)
+
+	kr := &kwmReader{mask: kwmMask(rid)}
+	enc := append([]byte(nil), audio...)
+	kr.xor(enc, 0)
+	return append(head, enc...)
+}
+
+func TestDecryptKWM(t *testing.T) {
+	audio := append([]byte("fLaC\x00\x00\x00\x22"), bytes.Repeat([]byte("kuwo"), 1000)...)
+
+	encrypted := buildKWM(audio, 228908, "2000kflac")
+	got, ext, err := DecryptKWM(encrypted)
+	if err != nil || ext != "flac" || !bytes.Equal(got, audio) {
+		t.Fatalf("DecryptKWM = %q, %v", ext, err)
+	}
+
+	// 文件头没有记录格式时按解密后的数据识别
+	r, ext, err := NewKWMReader(iotest.HalfReader(bytes.NewReader(buildKWM(audio, 1, ""))))
+	if err != nil || ext != "flac" {
+		t.Fatalf("NewKWMReader = %q, %v", ext, err)
+	}
+	if got, _ := io.ReadAll(r); !bytes.Equal(got, audio) {
+		t.Fatal("NewKWMReader stream mismatch")
+	}
+}
+
+func TestParseKWMFormat(t *testing.T) {
+	cases := []struct {
+		in      string
+		bitrate int
+		ext     string
+	}{
+		{"320kmp3\x00", 320, "mp3"},
+		{"2000kflac", 2000, "flac"},
+		{"128kaac", 128, ""},
+		{"", 0, ""},
+	}
+	for _, c := range cases {
+		bitrate, ext := parseKWMFormat([]byte(c.in))
+		if bitrate != c.bitrate || ext != c.ext {
+			t.Errorf("parseKWMFormat(%q) = %d, %q", c.in, bitrate, ext)
+		}
+	}
+}
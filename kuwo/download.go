@@ -1,16 +1,25 @@
 package kuwo
 
 import (
-	"errors"
+	"context"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultKuwo.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultKuwo.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL 获取下载链接
 func (k *Kuwo) GetDownloadURL(s *model.Song) (string, error) {
+	return k.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext 与 GetDownloadURL 相同，但请求受 ctx 控制。
+func (k *Kuwo) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "kuwo" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	if s.URL != "" {
 		return s.URL, nil
@@ -21,5 +30,5 @@ func (k *Kuwo) GetDownloadURL(s *model.Song) (string, error) {
 		rid = s.Extra["rid"]
 	}
 
-	return k.fetchAudioURL(rid)
+	return k.fetchAudioURL(ctx, rid)
 }
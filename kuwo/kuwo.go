@@ -3,6 +3,7 @@ package kuwo
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -33,14 +34,17 @@ var (
 
 type Kuwo struct {
 	cookie string
+	client *utils.Client
 }
 
-func New(cookie string) *Kuwo { return &Kuwo{cookie: cookie} }
+func New(cookie string, opts ...utils.ClientOption) *Kuwo {
+	return &Kuwo{cookie: cookie, client: utils.NewClient(opts...)}
+}
 
 var defaultKuwo = New("")
 
 // 酷我的歌单和专辑搜索共用同一个 legacy 路由，仅通过 ft 参数区分类型。
-func (k *Kuwo) searchCollection(keyword, ft string, out interface{}) error {
+func (k *Kuwo) searchCollection(ctx context.Context, keyword, ft string, out interface{}) error {
 	params := url.Values{}
 	params.Set("all", keyword)
 	params.Set("ft", ft)
@@ -56,7 +60,7 @@ func (k *Kuwo) searchCollection(keyword, ft string, out interface{}) error {
 
 	apiURL := "http://search.kuwo.cn/r.s?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -73,7 +77,7 @@ func (k *Kuwo) searchCollection(keyword, ft string, out interface{}) error {
 }
 
 // fetchPlaylistDetail [内部复用] 获取歌单详情 (Metadata + Songs)
-func (k *Kuwo) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (k *Kuwo) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	params := url.Values{}
 	params.Set("op", "getlistinfo")
 	params.Set("pid", id)
@@ -88,7 +92,7 @@ func (k *Kuwo) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, er
 
 	apiURL := "http://nplserver.kuwo.cn/pl.svc?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -115,7 +119,7 @@ func (k *Kuwo) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, er
 	}
 
 	if len(resp.MusicList) == 0 {
-		return nil, nil, errors.New("playlist is empty or id is invalid")
+		return nil, nil, model.Errorf(model.ErrNotFound, "playlist is empty or id is invalid")
 	}
 
 	playlist := &model.Playlist{
@@ -177,18 +181,18 @@ func (k *Kuwo) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, er
 }
 
 // Parse 解析链接并获取完整信息
-func (k *Kuwo) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
+func (k *Kuwo) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	id = strings.TrimSpace(id)
 	if id == "" {
 		return nil, nil, errors.New("album id is empty")
 	}
 
-	album, songs, err := k.fetchAlbumDetailFromLegacyAPI(id)
+	album, songs, err := k.fetchAlbumDetailFromLegacyAPI(ctx, id)
 	if err == nil && len(songs) > 0 {
 		return album, songs, nil
 	}
 
-	pageAlbum, pageSongs, pageErr := k.fetchAlbumDetailFromPage(id)
+	pageAlbum, pageSongs, pageErr := k.fetchAlbumDetailFromPage(ctx, id)
 	if pageErr == nil && len(pageSongs) > 0 {
 		return pageAlbum, pageSongs, nil
 	}
@@ -206,7 +210,7 @@ func (k *Kuwo) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error
 	return album, songs, nil
 }
 
-func (k *Kuwo) fetchAlbumDetailFromLegacyAPI(id string) (*model.Playlist, []model.Song, error) {
+func (k *Kuwo) fetchAlbumDetailFromLegacyAPI(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	const pageSize = 100
 
 	var album *model.Playlist
@@ -228,7 +232,7 @@ func (k *Kuwo) fetchAlbumDetailFromLegacyAPI(id string) (*model.Playlist, []mode
 
 		apiURL := "http://search.kuwo.cn/r.s?" + params.Encode()
 
-		body, err := utils.Get(apiURL,
+		body, err := k.client.GetContext(ctx, apiURL,
 			utils.WithHeader("User-Agent", UserAgent),
 			utils.WithHeader("Cookie", k.cookie),
 			utils.WithRandomIPHeader(),
@@ -334,7 +338,7 @@ func (k *Kuwo) fetchAlbumDetailFromLegacyAPI(id string) (*model.Playlist, []mode
 	}
 
 	if album == nil {
-		return nil, nil, errors.New("album not found")
+		return nil, nil, model.Errorf(model.ErrNotFound, "album not found")
 	}
 	if album.TrackCount == 0 {
 		album.TrackCount = len(songs)
@@ -343,10 +347,10 @@ func (k *Kuwo) fetchAlbumDetailFromLegacyAPI(id string) (*model.Playlist, []mode
 	return album, songs, nil
 }
 
-func (k *Kuwo) fetchAlbumDetailFromPage(id string) (*model.Playlist, []model.Song, error) {
+func (k *Kuwo) fetchAlbumDetailFromPage(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	pageURL := fmt.Sprintf("https://www.kuwo.cn/album_detail/%s", id)
 
-	body, err := utils.Get(pageURL,
+	body, err := k.client.GetContext(ctx, pageURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 	)
@@ -461,14 +465,14 @@ func (k *Kuwo) fetchAlbumDetailFromPage(id string) (*model.Playlist, []model.Son
 }
 
 // fetchFullSongInfo 内部聚合：同时获取元数据和下载链接
-func (k *Kuwo) fetchFullSongInfo(rid string) (*model.Song, error) {
+func (k *Kuwo) fetchFullSongInfo(ctx context.Context, rid string) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("musicId", rid)
 	params.Set("httpsStatus", "1")
 	metaURL := "http://m.kuwo.cn/newh5/singles/songinfoandlrc?" + params.Encode()
 
 	var name, artist, cover string
-	metaBody, err := utils.Get(metaURL,
+	metaBody, err := k.client.GetContext(ctx, metaURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -495,7 +499,7 @@ func (k *Kuwo) fetchFullSongInfo(rid string) (*model.Song, error) {
 		name = fmt.Sprintf("Kuwo_Song_%s", rid)
 	}
 
-	audioURL, err := k.fetchAudioURL(rid)
+	audioURL, err := k.fetchAudioURL(ctx, rid)
 	if err != nil {
 		return nil, err
 	}
@@ -515,7 +519,7 @@ func (k *Kuwo) fetchFullSongInfo(rid string) (*model.Song, error) {
 }
 
 // fetchAudioURL 内部核心：仅获取下载链接
-func (k *Kuwo) fetchAudioURL(rid string) (string, error) {
+func (k *Kuwo) fetchAudioURL(ctx context.Context, rid string) (string, error) {
 	qualities := []string{"128kmp3", "320kmp3", "flac", "2000kflac"}
 	randomID := fmt.Sprintf("C_APK_guanwang_%d%d", time.Now().UnixNano(), rand.Intn(1000000))
 
@@ -531,7 +535,7 @@ func (k *Kuwo) fetchAudioURL(rid string) (string, error) {
 
 		apiURL := "https://mobi.kuwo.cn/mobi.s?" + params.Encode()
 
-		body, err := utils.Get(apiURL,
+		body, err := k.client.GetContext(ctx, apiURL,
 			utils.WithHeader("User-Agent", UserAgent),
 			utils.WithHeader("Cookie", k.cookie),
 			utils.WithRandomIPHeader(),
@@ -565,7 +569,7 @@ func (k *Kuwo) fetchAudioURL(rid string) (string, error) {
 		cookieWithSecret += "; kw_token=secret_token"
 	}
 
-	fallbackBody, err := utils.Get(fallbackURL,
+	fallbackBody, err := k.client.GetContext(ctx, fallbackURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", cookieWithSecret),
 		utils.WithHeader("Secret", secret), // Web API 需要的签名头
@@ -582,12 +586,12 @@ func (k *Kuwo) fetchAudioURL(rid string) (string, error) {
 		}
 	}
 
-	return "", errors.New("download url not found (copyright restricted)")
+	return "", model.Errorf(model.ErrRegionBlocked, "download url not found (copyright restricted)")
 }
 
-func (k *Kuwo) fetchNewLyrics(rid string) (string, error) {
+func (k *Kuwo) fetchNewLyrics(ctx context.Context, rid string) (string, error) {
 	apiURL := "http://newlyric.kuwo.cn/newlyric.lrc?" + buildKuwoNewLyricParams(rid, true)
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -601,7 +605,7 @@ func (k *Kuwo) fetchNewLyrics(rid string) (string, error) {
 	}
 	lrc := convertKuwoNewLyric(raw)
 	if strings.TrimSpace(lrc) == "" || !hasKuwoTimestampedLyric(lrc) {
-		return "", errors.New("kuwo newlyric content is empty")
+		return "", model.Errorf(model.ErrNotFound, "kuwo newlyric content is empty")
 	}
 	return lrc, nil
 }
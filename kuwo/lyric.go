@@ -1,8 +1,8 @@
 package kuwo
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -13,10 +13,19 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultKuwo.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultKuwo.GetLyricsContext(ctx, s)
+}
+
 // GetLyrics 获取歌词
 func (k *Kuwo) GetLyrics(s *model.Song) (string, error) {
+	return k.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (k *Kuwo) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "kuwo" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	rid := s.ID
@@ -24,7 +33,7 @@ func (k *Kuwo) GetLyrics(s *model.Song) (string, error) {
 		rid = s.Extra["rid"]
 	}
 
-	if lrc, err := k.fetchNewLyrics(rid); err == nil && strings.TrimSpace(lrc) != "" {
+	if lrc, err := k.fetchNewLyrics(ctx, rid); err == nil && strings.TrimSpace(lrc) != "" {
 		return lrc, nil
 	}
 
@@ -33,7 +42,7 @@ func (k *Kuwo) GetLyrics(s *model.Song) (string, error) {
 	params.Set("httpsStatus", "1")
 
 	apiURL := "http://m.kuwo.cn/newh5/singles/songinfoandlrc?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
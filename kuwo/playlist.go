@@ -1,6 +1,7 @@
 package kuwo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,29 +20,58 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultKuwo.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultKuwo.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) {
-	_, songs, err := defaultKuwo.fetchPlaylistDetail(id)
+	_, songs, err := defaultKuwo.fetchPlaylistDetail(context.Background(), id)
 	return songs, err
 }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultKuwo.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultKuwo.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultKuwo.ParsePlaylistContext(ctx, link)
+}
+
 // GetRecommendedPlaylists 获取推荐歌单 (新增)
 func GetRecommendedPlaylists() ([]model.Playlist, error) {
 	return defaultKuwo.GetRecommendedPlaylists()
 }
 
+func GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
+	return defaultKuwo.GetRecommendedPlaylistsContext(ctx)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultKuwo.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultKuwo.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultKuwo.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultKuwo.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (k *Kuwo) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return k.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext 与 GetPlaylistCategories 相同，但请求受 ctx 控制。
+func (k *Kuwo) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	params := url.Values{}
 	params.Set("cmd", "rcm_keyword_playlist")
 	params.Set("user", "0")
@@ -53,7 +83,7 @@ func (k *Kuwo) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	params.Set("appUid", "38668888")
 	apiURL := "http://wapi.kuwo.cn/api/pc/classify/playlist/getTagList?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -123,6 +153,11 @@ func (k *Kuwo) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 }
 
 func (k *Kuwo) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return k.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext 与 GetCategoryPlaylists 相同，但请求受 ctx 控制。
+func (k *Kuwo) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	categoryID = strings.TrimSpace(categoryID)
 	if page < 1 {
 		page = 1
@@ -147,7 +182,7 @@ func (k *Kuwo) GetCategoryPlaylists(categoryID string, page, limit int) ([]model
 	}
 	apiURL := "http://wapi.kuwo.cn/api/pc/classify/playlist/" + endpoint + "?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -234,6 +269,11 @@ func decodeKuwoPlaylistBody(body []byte) []byte {
 }
 
 func (k *Kuwo) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return k.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext 与 SearchPlaylist 相同，但请求受 ctx 控制。
+func (k *Kuwo) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	var resp struct {
 		AbsList []struct {
 			PlaylistID string `json:"playlistid"`
@@ -245,7 +285,7 @@ func (k *Kuwo) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 		} `json:"abslist"`
 	}
 
-	if err := k.searchCollection(keyword, "playlist", &resp); err != nil {
+	if err := k.searchCollection(ctx, keyword, "playlist", &resp); err != nil {
 		return nil, err
 	}
 
@@ -276,12 +316,22 @@ func (k *Kuwo) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 }
 
 func (k *Kuwo) GetPlaylistSongs(id string) ([]model.Song, error) {
-	_, songs, err := k.fetchPlaylistDetail(id)
+	return k.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext 与 GetPlaylistSongs 相同，但请求受 ctx 控制。
+func (k *Kuwo) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := k.fetchPlaylistDetail(ctx, id)
 	return songs, err
 }
 
 // ParsePlaylist 解析歌单链接
 func (k *Kuwo) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return k.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext 与 ParsePlaylist 相同，但请求受 ctx 控制。
+func (k *Kuwo) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	// 链接格式: http://www.kuwo.cn/playlist_detail/1082685103
 	re := regexp.MustCompile(`playlist_detail/(\d+)`)
 	matches := re.FindStringSubmatch(link)
@@ -290,11 +340,16 @@ func (k *Kuwo) ParsePlaylist(link string) (*model.Playlist, []model.Song, error)
 	}
 	playlistID := matches[1]
 
-	return k.fetchPlaylistDetail(playlistID)
+	return k.fetchPlaylistDetail(ctx, playlistID)
 }
 
 // GetRecommendedPlaylists 获取推荐歌单 (酷我热门歌单)
 func (k *Kuwo) GetRecommendedPlaylists() ([]model.Playlist, error) {
+	return k.GetRecommendedPlaylistsContext(context.Background())
+}
+
+// GetRecommendedPlaylistsContext 与 GetRecommendedPlaylists 相同，但请求受 ctx 控制。
+func (k *Kuwo) GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
 	// 使用 wapi 接口获取热门推荐歌单，不需要复杂 Token
 	params := url.Values{}
 	params.Set("pn", "0")
@@ -303,7 +358,7 @@ func (k *Kuwo) GetRecommendedPlaylists() ([]model.Playlist, error) {
 
 	apiURL := "http://wapi.kuwo.cn/api/pc/classify/playlist/getRcmPlayList?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -1,6 +1,7 @@
 package kuwo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,10 +15,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultKuwo.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultKuwo.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultKuwo.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultKuwo.ParseContext(ctx, link)
+}
+
 // Search 搜索歌曲
 func (k *Kuwo) Search(keyword string) ([]model.Song, error) {
+	return k.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but carries ctx through its requests.
+func (k *Kuwo) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("vipver", "1")
 	params.Set("client", "kt")
@@ -35,7 +49,7 @@ func (k *Kuwo) Search(keyword string) ([]model.Song, error) {
 
 	apiURL := "http://www.kuwo.cn/search/searchMusicBykeyWord?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := k.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", k.cookie),
 		utils.WithRandomIPHeader(),
@@ -94,6 +108,11 @@ func (k *Kuwo) Search(keyword string) ([]model.Song, error) {
 }
 
 func (k *Kuwo) Parse(link string) (*model.Song, error) {
+	return k.ParseContext(context.Background(), link)
+}
+
+// ParseContext is like Parse but carries ctx through its requests.
+func (k *Kuwo) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	re := regexp.MustCompile(`play_detail/(\d+)`)
 	matches := re.FindStringSubmatch(link)
 	if len(matches) < 2 {
@@ -101,5 +120,5 @@ func (k *Kuwo) Parse(link string) (*model.Song, error) {
 	}
 	rid := matches[1]
 
-	return k.fetchFullSongInfo(rid)
+	return k.fetchFullSongInfo(ctx, rid)
 }
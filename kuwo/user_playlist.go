@@ -1,11 +1,23 @@
 package kuwo
 
-import "github.com/guohuiyuan/music-lib/model"
+import (
+	"context"
+	"github.com/guohuiyuan/music-lib/model"
+)
 
 func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultKuwo.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultKuwo.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 func (p *Kuwo) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return p.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (p *Kuwo) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrUserPlaylistsUnsupported
 }
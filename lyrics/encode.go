@@ -0,0 +1,245 @@
+package lyrics
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EncodeYRC 把 data 编码为网易云的 YRC 逐字歌词，是 ParseYRC 的逆操作。
+// YRC 用 "(" 和 "[" 分隔字，文字中不能包含这两个字符。
+func EncodeYRC(data Data) string {
+	var b strings.Builder
+	for _, line := range data {
+		start, dur := lineSpan(line)
+		b.WriteString("[" + strconv.Itoa(start) + "," + strconv.Itoa(dur) + "]")
+		for _, w := range timedWords(line) {
+			b.WriteString("(" + strconv.Itoa(w.Start.MS) + "," + strconv.Itoa(w.End.MS-w.Start.MS) + ",0)" + w.Text)
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// EncodeQRC 把 tags 和 data 编码为带 XML 外壳的 QQ 音乐 QRC 歌词，是 ParseQRC 的逆操作。
+// QRC 的字以括号中的时间结尾，文字中不能包含括号。
+func EncodeQRC(tags map[string]string, data Data) string {
+	var content strings.Builder
+	for _, k := range sortedTagKeys(tags) {
+		content.WriteString("[" + k + ":" + tags[k] + "]\n")
+	}
+	for _, line := range data {
+		start, dur := lineSpan(line)
+		content.WriteString("[" + strconv.Itoa(start) + "," + strconv.Itoa(dur) + "]")
+		for _, w := range timedWords(line) {
+			content.WriteString(w.Text + "(" + strconv.Itoa(w.Start.MS) + "," + strconv.Itoa(w.End.MS-w.Start.MS) + ")")
+		}
+		content.WriteByte('\n')
+	}
+
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	b.WriteString("<QrcInfos>\n<QrcHeadInfo SaveTime=\"0\" Version=\"100\"/>\n<LyricInfo LyricCount=\"1\">\n")
+	b.WriteString("<Lyric_1 LyricType=\"1\" LyricContent=\"" + html.EscapeString(content.String()) + "\"/>\n")
+	b.WriteString("</LyricInfo>\n</QrcInfos>")
+	return b.String()
+}
+
+// EncodeKRC 把 tags 和 data 编码为酷狗 KRC 歌词，是 ParseKRC 的逆操作。
+// "roma" 和 "ts" 轨道写入 base64 的 language 标签，tags 中原有的 language 会被替换。
+// KRC 用 "<" 分隔字，文字中不能包含 "<"。
+func EncodeKRC(tags map[string]string, data MultiData) string {
+	var b strings.Builder
+	for _, k := range sortedTagKeys(tags) {
+		if k == "language" {
+			continue
+		}
+		b.WriteString("[" + k + ":" + tags[k] + "]\n")
+	}
+	orig := data["orig"]
+	if lang := encodeKrcLanguage(data, orig); lang != "" {
+		b.WriteString("[language:" + lang + "]\n")
+	}
+	for _, line := range orig {
+		start, dur := lineSpan(line)
+		b.WriteString("[" + strconv.Itoa(start) + "," + strconv.Itoa(dur) + "]")
+		for _, w := range timedWords(line) {
+			b.WriteString("<" + strconv.Itoa(w.Start.MS-start) + "," + strconv.Itoa(w.End.MS-w.Start.MS) + ",0>" + w.Text)
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// encodeKrcLanguage 生成 KRC 的 language 标签，格式与 addKrcLanguage 解析的相同：
+// 音译 (type 0) 与原文逐字对应，并跳过没有文字的行；翻译 (type 1) 每行一项。
+func encodeKrcLanguage(data MultiData, orig Data) string {
+	type krcLanguage struct {
+		Language     int        `json:"language"`
+		Type         int        `json:"type"`
+		LyricContent [][]string `json:"lyricContent"`
+	}
+	var content []krcLanguage
+	if roma := data["roma"]; len(roma) > 0 {
+		lang := krcLanguage{Type: 0, LyricContent: [][]string{}}
+		for i, line := range orig {
+			if !lineHasText(line) {
+				continue
+			}
+			words := make([]string, len(line.Words))
+			if idx := mappedIndex(roma, i, line); idx >= 0 && idx < len(roma) {
+				for j := range words {
+					if j < len(roma[idx].Words) {
+						words[j] = roma[idx].Words[j].Text
+					}
+				}
+			}
+			lang.LyricContent = append(lang.LyricContent, words)
+		}
+		content = append(content, lang)
+	}
+	if ts := data["ts"]; len(ts) > 0 {
+		lang := krcLanguage{Type: 1, LyricContent: [][]string{}}
+		for i, line := range orig {
+			text := []string{}
+			if idx := mappedIndex(ts, i, line); idx >= 0 && idx < len(ts) && lineHasText(ts[idx]) {
+				text = append(text, ts[idx].Text())
+			}
+			lang.LyricContent = append(lang.LyricContent, text)
+		}
+		content = append(content, lang)
+	}
+	if len(content) == 0 {
+		return ""
+	}
+	payload, err := json.Marshal(map[string]interface{}{"content": content, "version": 1})
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(payload)
+}
+
+// EncryptKRC 加密 KRC 文本，生成以 "krc1" 开头的 .krc 文件内容，是 DecryptKRC 的逆操作
+func EncryptKRC(plain string) ([]byte, error) {
+	compressed, err := zlibCompress([]byte(plain))
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte("krc1"), compressed...)
+	for i := 4; i < len(out); i++ {
+		out[i] ^= krcKey[(i-4)%len(krcKey)]
+	}
+	return out, nil
+}
+
+// EncodeKRCBase64 与 EncryptKRC 相同，返回酷狗歌词接口使用的 base64 文本，是 DecodeKRCBase64 的逆操作
+func EncodeKRCBase64(plain string) (string, error) {
+	encrypted, err := EncryptKRC(plain)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// EncryptQRCHex 加密 QRC 文本，返回 QQ 音乐歌词接口使用的十六进制文本，是 DecryptQRCHex 的逆操作
+func EncryptQRCHex(plain string) (string, error) {
+	compressed, err := zlibCompress([]byte(plain))
+	if err != nil {
+		return "", err
+	}
+	// 补齐到 8 字节的整数倍，zlib 解压时会忽略数据流之后的填充
+	if n := len(compressed) % 8; n != 0 {
+		compressed = append(compressed, make([]byte, 8-n)...)
+	}
+	encrypted := make([]byte, len(compressed))
+	for i := 0; i < len(compressed); i += 8 {
+		copy(encrypted[i:i+8], qrcTripleDESEncrypt(compressed[i:i+8]))
+	}
+	return strings.ToUpper(hex.EncodeToString(encrypted)), nil
+}
+
+func zlibCompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// lineSpan 返回行的开始时间和时长 (毫秒)
+func lineSpan(line Line) (int, int) {
+	start, end := lineStart(line), lineEnd(line)
+	if !start.OK {
+		return 0, 0
+	}
+	if !end.OK || end.MS < start.MS {
+		return start.MS, 0
+	}
+	return start.MS, end.MS - start.MS
+}
+
+// timedWords 返回 line 中有文字的字，并补全缺少的时间：
+// 没有开始时间的字接在上一个字之后，没有结束时间的字持续到下一个字或行尾。
+func timedWords(line Line) []Word {
+	start, dur := lineSpan(line)
+	end := start + dur
+	var words []Word
+	for _, w := range line.Words {
+		if w.Text != "" {
+			words = append(words, w)
+		}
+	}
+	cursor := start
+	for i := range words {
+		if !words[i].Start.OK {
+			words[i].Start = Time{MS: cursor, OK: true}
+		}
+		if !words[i].End.OK {
+			next := end
+			if i+1 < len(words) && words[i+1].Start.OK {
+				next = words[i+1].Start.MS
+			}
+			if next < words[i].Start.MS {
+				next = words[i].Start.MS
+			}
+			words[i].End = Time{MS: next, OK: true}
+		}
+		cursor = words[i].End.MS
+	}
+	return words
+}
+
+// sortedTagKeys 按 ti、ar、al、by、offset 的顺序返回标签，其余标签按字母顺序排在后面
+func sortedTagKeys(tags map[string]string) []string {
+	known := []string{"ti", "ar", "al", "by", "offset"}
+	var keys, rest []string
+	for _, k := range known {
+		if _, ok := tags[k]; ok {
+			keys = append(keys, k)
+		}
+	}
+	for k := range tags {
+		isKnown := false
+		for _, kk := range known {
+			if k == kk {
+				isKnown = true
+				break
+			}
+		}
+		if !isKnown {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
@@ -0,0 +1,274 @@
+package lyrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultCueDuration 是最后一行没有结束时间时字幕的显示时长 (毫秒)
+const defaultCueDuration = 5000
+
+// cue 是一条字幕：原文的一行以及按显示顺序叠放的其他轨道
+type cue struct {
+	start, end int
+	lines      []Line
+}
+
+// buildCues 按原文的行生成字幕，order 中每个轨道对应的行叠放在同一条字幕里。
+// 对应关系与 ConvertVerbatimLRC 相同；没有结束时间的行显示到下一行开始。
+func buildCues(data MultiData, order []string) []cue {
+	if len(order) == 0 {
+		order = DefaultDisplayOrder()
+	}
+	orig := data["orig"]
+	var cues []cue
+	for i, origLine := range orig {
+		start := lineStart(origLine)
+		if !start.OK {
+			continue
+		}
+		c := cue{start: start.MS}
+		for _, lang := range order {
+			lines := data[lang]
+			idx := mappedIndex(lines, i, origLine)
+			if idx < 0 || idx >= len(lines) || !lineHasText(lines[idx]) {
+				continue
+			}
+			c.lines = append(c.lines, lines[idx])
+		}
+		if len(c.lines) == 0 {
+			continue
+		}
+		if end := lineEnd(origLine); end.OK && end.MS > start.MS {
+			c.end = end.MS
+		}
+		cues = append(cues, c)
+	}
+	for i := range cues {
+		if cues[i].end > 0 {
+			continue
+		}
+		if i+1 < len(cues) && cues[i+1].start > cues[i].start {
+			cues[i].end = cues[i+1].start
+		} else {
+			cues[i].end = cues[i].start + defaultCueDuration
+		}
+	}
+	return cues
+}
+
+// ConvertSRT 生成 SRT 字幕，order 中的轨道 (例如原文和翻译) 叠放为同一条字幕的多行
+func ConvertSRT(data MultiData, order []string) string {
+	var b strings.Builder
+	for i, c := range buildCues(data, order) {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n", i+1, formatClock(c.start, ","), formatClock(c.end, ","))
+		for _, line := range c.lines {
+			b.WriteString(strings.TrimSpace(line.Text()))
+			b.WriteByte('\n')
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ConvertWebVTT 生成 WebVTT 字幕，叠放方式与 ConvertSRT 相同。
+// 第一个轨道有逐字时间轴时写入 <00:00:01.000> 形式的卡拉 OK 时间戳。
+func ConvertWebVTT(data MultiData, order []string) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n")
+	for _, c := range buildCues(data, order) {
+		fmt.Fprintf(&b, "\n%s --> %s\n", formatClock(c.start, "."), formatClock(c.end, "."))
+		for i, line := range c.lines {
+			if i == 0 {
+				b.WriteString(webVTTKaraoke(line, c.start, c.end))
+			} else {
+				b.WriteString(escapeWebVTT(strings.TrimSpace(line.Text())))
+			}
+			b.WriteByte('\n')
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func webVTTKaraoke(line Line, start, end int) string {
+	var b strings.Builder
+	for i, w := range line.Words {
+		text := w.Text
+		if i == 0 {
+			text = strings.TrimLeft(text, " ")
+		}
+		if i == len(line.Words)-1 {
+			text = strings.TrimRight(text, " ")
+		}
+		// 时间戳必须位于字幕时间范围内，且不能早于第一个字
+		if i > 0 && w.Start.OK && w.Start.MS > start && w.Start.MS < end {
+			b.WriteString("<" + formatClock(w.Start.MS, ".") + ">")
+		}
+		b.WriteString(escapeWebVTT(text))
+	}
+	return b.String()
+}
+
+func escapeWebVTT(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// ConvertASS 生成 ASS 字幕。第一个轨道按逐字时间轴写入 \k 卡拉 OK 标签，
+// 其余轨道以较小的 Secondary 样式用 \N 叠放在下方。tags 中的 ti 写入 Title。
+func ConvertASS(tags map[string]string, data MultiData, order []string) string {
+	var b strings.Builder
+	b.WriteString("[Script Info]\n")
+	if ti := strings.TrimSpace(tags["ti"]); ti != "" {
+		b.WriteString("Title: " + ti + "\n")
+	}
+	b.WriteString("ScriptType: v4.00+\nPlayResX: 1920\nPlayResY: 1080\nWrapStyle: 0\nScaledBorderAndShadow: yes\n\n")
+	b.WriteString("[V4+ Styles]\n")
+	b.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	// 卡拉 OK 从 SecondaryColour (白色) 逐字变为 PrimaryColour (金色)
+	b.WriteString("Style: Default,Microsoft YaHei,64,&H0000D7FF,&H00FFFFFF,&H00000000,&H80000000,-1,0,0,0,100,100,0,0,1,3,1,2,60,60,60,1\n")
+	b.WriteString("Style: Secondary,Microsoft YaHei,44,&H00FFFFFF,&H00FFFFFF,&H00000000,&H80000000,0,0,0,0,100,100,0,0,1,2,1,2,60,60,60,1\n\n")
+	b.WriteString("[Events]\n")
+	b.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+	for _, c := range buildCues(data, order) {
+		var text strings.Builder
+		for i, line := range c.lines {
+			if i == 0 {
+				text.WriteString(assKaraoke(line, c.start))
+				continue
+			}
+			text.WriteString(`\N{\rSecondary}`)
+			text.WriteString(escapeASS(strings.TrimSpace(line.Text())))
+		}
+		name := strings.ReplaceAll(c.lines[0].Agent, ",", " ")
+		fmt.Fprintf(&b, "Dialogue: 0,%s,%s,Default,%s,0,0,0,,%s\n", formatASSTime(c.start), formatASSTime(c.end), name, text.String())
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// assKaraoke 把逐字时间轴转换为 \k 标签，单位为厘秒；字之间的空隙写成不带文字的 {\k}
+func assKaraoke(line Line, start int) string {
+	timed := false
+	for _, w := range line.Words {
+		if w.Start.OK && w.End.OK {
+			timed = true
+			break
+		}
+	}
+	text := strings.TrimSpace(line.Text())
+	if !timed {
+		return escapeASS(text)
+	}
+	var b strings.Builder
+	cursor := start / 10
+	for i, w := range line.Words {
+		word := w.Text
+		if i == 0 {
+			word = strings.TrimLeft(word, " ")
+		}
+		if i == len(line.Words)-1 {
+			word = strings.TrimRight(word, " ")
+		}
+		if !w.Start.OK || !w.End.OK {
+			b.WriteString(escapeASS(word))
+			continue
+		}
+		if gap := w.Start.MS/10 - cursor; gap > 0 {
+			b.WriteString(`{\k` + strconv.Itoa(gap) + `}`)
+			cursor += gap
+		}
+		dur := w.End.MS/10 - cursor
+		if dur < 0 {
+			dur = 0
+		}
+		b.WriteString(`{\k` + strconv.Itoa(dur) + `}` + escapeASS(word))
+		cursor += dur
+	}
+	return b.String()
+}
+
+func escapeASS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "{", `\{`, "}", `\}`, "\n", `\N`).Replace(s)
+}
+
+// ConvertEnhancedLRC 生成 A2 扩展 (Enhanced LRC) 格式：第一个轨道在行内用 <mm:ss.xx> 标记每个字的开始时间，
+// 其余轨道作为同一时间戳的普通 LRC 行紧随其后。
+func ConvertEnhancedLRC(tags map[string]string, data MultiData, order []string) string {
+	var b strings.Builder
+	writeTags(&b, tags)
+	for _, c := range buildCues(data, order) {
+		stamp := "[" + formatTime(c.start) + "]"
+		for i, line := range c.lines {
+			b.WriteString(stamp)
+			if i == 0 {
+				b.WriteString(enhancedLRCLine(line, c.end))
+			} else {
+				b.WriteString(strings.TrimSpace(line.Text()))
+			}
+			b.WriteByte('\n')
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func enhancedLRCLine(line Line, end int) string {
+	var b strings.Builder
+	stamped := false
+	for i, w := range line.Words {
+		word := w.Text
+		if i == 0 {
+			word = strings.TrimLeft(word, " ")
+		}
+		if i == len(line.Words)-1 {
+			word = strings.TrimRight(word, " ")
+		}
+		// 空格放在时间戳之前，时间戳紧贴在字的前面
+		if trimmed := strings.TrimLeft(word, " "); w.Start.OK && trimmed != "" {
+			b.WriteString(word[:len(word)-len(trimmed)])
+			b.WriteString("<" + formatTime(w.Start.MS) + ">")
+			word = trimmed
+			stamped = true
+		}
+		b.WriteString(word)
+	}
+	if stamped {
+		b.WriteString("<" + formatTime(end) + ">")
+	}
+	return b.String()
+}
+
+// formatClock 格式化为 HH:MM:SS 加毫秒，sep 为 SRT 的 "," 或 WebVTT 的 "."
+func formatClock(ms int, sep string) string {
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// formatASSTime 格式化为 ASS 的 H:MM:SS.cc
+func formatASSTime(ms int) string {
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%d:%02d:%02d.%02d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000/10)
+}
+
+// SRT 按 order 的轨道顺序生成 SRT 字幕，参见 ConvertSRT
+func (l *Lyrics) SRT(order ...string) string {
+	return ConvertSRT(l.Data, order)
+}
+
+// WebVTT 按 order 的轨道顺序生成 WebVTT 字幕，参见 ConvertWebVTT
+func (l *Lyrics) WebVTT(order ...string) string {
+	return ConvertWebVTT(l.Data, order)
+}
+
+// ASS 按 order 的轨道顺序生成带卡拉 OK 标签的 ASS 字幕，参见 ConvertASS
+func (l *Lyrics) ASS(order ...string) string {
+	return ConvertASS(l.Tags, l.Data, order)
+}
+
+// EnhancedLRC 按 order 的轨道顺序生成 A2 扩展 LRC，参见 ConvertEnhancedLRC
+func (l *Lyrics) EnhancedLRC(order ...string) string {
+	return ConvertEnhancedLRC(l.Tags, l.Data, order)
+}
@@ -31,6 +31,8 @@ type Line struct {
 	Start Time
 	End   Time
 	Words []Word
+	// Agent 演唱者，目前只有 TTML 歌词 (ttm:agent) 提供
+	Agent string
 }
 
 type Data []Line
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/zlib"
 	"encoding/base64"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -126,3 +127,251 @@ func TestDecryptKRC(t *testing.T) {
 		t.Fatalf("got %q, want %q", got, plain)
 	}
 }
+
+func TestNewLyricsDetectsLanguages(t *testing.T) {
+	orig := ParseYRC("[980,1000](980,500,0)家まで(1480,500,0)送って")
+	_, ts := ParseLRC("[00:00.98]希望你能送我回家")
+	_, roma := ParseLRC("[00:00.98]ie made okutte")
+	data := MultiData{"orig": orig, "ts": ts, "roma": roma}
+
+	l := New(map[string]string{"ti": "song"}, data)
+	want := map[string]string{"orig": "ja", "ts": "zh", "roma": "ja-Latn"}
+	for k, v := range want {
+		if l.Languages[k] != v {
+			t.Errorf("language of %s = %q, want %q", k, l.Languages[k], v)
+		}
+	}
+	if got := l.LRC(); got != ConvertVerbatimLRC(l.Tags, data, DefaultDisplayOrder()) {
+		t.Fatalf("LRC should match ConvertVerbatimLRC:\n%s", got)
+	}
+	if l.Orig()[0].Text() != "家まで送って" {
+		t.Fatalf("unexpected orig text %q", l.Orig()[0].Text())
+	}
+
+	for text, lang := range map[string]string{"사랑해": "ko", "hello world": "en", "晴天": "zh", "123": ""} {
+		_, d := ParseLRC("[00:01.00]" + text)
+		if got := DetectLanguage(d); got != lang {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", text, got, lang)
+		}
+	}
+}
+
+func TestParseTTML(t *testing.T) {
+	raw := `<tt xmlns="http://www.w3.org/ns/ttml" xmlns:itunes="http://music.apple.com/lyric-ttml-internal" xmlns:ttm="http://www.w3.org/ns/ttml#metadata" itunes:timing="Word" xml:lang="ja"><head><metadata>` +
+		`<ttm:agent type="person" xml:id="v1"><ttm:name type="full">Singer</ttm:name></ttm:agent>` +
+		`<iTunesMetadata xmlns="http://music.apple.com/lyric-ttml-internal">` +
+		`<translations><translation type="subtitle" xml:lang="en"><text for="L1">Take me home</text></translation>` +
+		`<translation type="subtitle" xml:lang="zh-Hans"><text for="L1">送我回家</text></translation></translations>` +
+		`<transliterations><transliteration xml:lang="ja-Latn"><text for="L1"><span begin="0.98" end="1.48">ie made</span> <span begin="1.48" end="1.98">okutte</span></text></transliteration></transliterations>` +
+		`</iTunesMetadata></metadata></head><body dur="3:00.000"><div begin="0.98" end="2.5">` +
+		`<p begin="0.980" end="1.980" itunes:key="L1" ttm:agent="v1"><span begin="0.980" end="1.480">家まで</span><span begin="1.480" end="1.980">送って</span>` +
+		`<span ttm:role="x-bg"><span begin="1.5" end="1.9">(ah)</span></span></p>` +
+		`<p begin="1:02.5" end="1:03.000s" itunes:key="L2">line timed</p>` +
+		`</div></body></tt>`
+
+	l, err := ParseTTML(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := l.Orig()
+	if len(orig) != 2 || len(orig[0].Words) != 3 || orig[0].Agent != "Singer" {
+		t.Fatalf("unexpected orig %+v", orig)
+	}
+	if w := orig[0].Words[1]; w.Text != "送って" || w.Start.MS != 1480 || w.End.MS != 1980 {
+		t.Fatalf("unexpected word %+v", w)
+	}
+	if orig[1].Text() != "line timed" || orig[1].Start.MS != 62500 || orig[1].End.MS != 63000 {
+		t.Fatalf("unexpected line timed %+v", orig[1])
+	}
+	if ts := l.Data["ts"]; ts[0].Text() != "送我回家" || ts[0].Start.MS != 980 || len(ts[1].Words) != 0 {
+		t.Fatalf("unexpected ts %+v", ts)
+	}
+	if roma := l.Data["roma"]; roma[0].Text() != "ie made okutte" || roma[0].Words[1].Start.MS != 1480 {
+		t.Fatalf("unexpected roma %+v", roma)
+	}
+	want := map[string]string{"orig": "ja", "ts": "zh-Hans", "roma": "ja-Latn"}
+	for k, v := range want {
+		if l.Languages[k] != v {
+			t.Errorf("language of %s = %q, want %q", k, l.Languages[k], v)
+		}
+	}
+
+	wantLRC := "[00:00.98]家まで[00:01.48]送って[00:01.98][00:01.50](ah)[00:01.90]\n" +
+		"[00:00.98]ie made [00:01.48]okutte[00:01.98]\n" +
+		"[00:00.98]送我回家[00:01.98]\n" +
+		"[01:02.50]line timed[01:03.00]"
+	if got := l.LRC(); got != wantLRC {
+		t.Fatalf("unexpected LRC:\n%s", got)
+	}
+
+	// 带时间的 x-bg span 只包着和声的字，本身不应成为一个字
+	bg, err := ParseTTML(`<tt xmlns:ttm="http://www.w3.org/ns/ttml#metadata"><body><div>` +
+		`<p begin="1.0" end="5.0"><span begin="1.0" end="2.0">Hello</span> <span begin="2.0" end="3.0">world</span>` +
+		`<span ttm:role="x-bg" begin="3.0" end="5.0"><span begin="3.0" end="4.0">(oh</span> <span begin="4.0" end="5.0">yeah)</span></span></p>` +
+		`</div></body></tt>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for _, w := range bg.Orig()[0].Words {
+		texts = append(texts, w.Text)
+	}
+	if want := []string{"Hello ", "world", "(oh ", "yeah)"}; !reflect.DeepEqual(texts, want) {
+		t.Fatalf("background vocal words = %q, want %q", texts, want)
+	}
+	if w := bg.Orig()[0].Words[2]; w.Start.MS != 3000 || w.End.MS != 4000 {
+		t.Fatalf("unexpected background word %+v", w)
+	}
+	if ass := bg.ASS("orig"); !strings.Contains(ass, `{\k100}Hello {\k100}world{\k100}(oh {\k100}yeah)`) {
+		t.Fatalf("unexpected ASS karaoke for background vocals:\n%s", ass)
+	}
+
+	if _, err := ParseTTML(`<tt><body></body></tt>`); err == nil {
+		t.Fatal("expected error for empty ttml")
+	}
+}
+
+func TestExportSubtitles(t *testing.T) {
+	orig := ParseYRC("[980,1000](980,500,0)家まで(1480,500,0)送って\n[3000,1000](3000,400,0)Hello (3500,500,0)<world>")
+	_, ts := ParseLRC("[00:00.98]希望你能送我回家")
+	data := MultiData{"orig": orig, "ts": ts}
+
+	wantSRT := "1\n00:00:00,980 --> 00:00:01,980\n家まで送って\n希望你能送我回家\n\n" +
+		"2\n00:00:03,000 --> 00:00:04,000\nHello <world>"
+	if got := ConvertSRT(data, nil); got != wantSRT {
+		t.Errorf("unexpected SRT:\n%s", got)
+	}
+	if got := ConvertSRT(data, []string{"ts"}); got != "1\n00:00:00,980 --> 00:00:01,980\n希望你能送我回家" {
+		t.Errorf("unexpected SRT with ts only:\n%s", got)
+	}
+
+	wantVTT := "WEBVTT\n\n00:00:00.980 --> 00:00:01.980\n家まで<00:00:01.480>送って\n希望你能送我回家\n\n" +
+		"00:00:03.000 --> 00:00:04.000\nHello <00:00:03.500>&lt;world&gt;"
+	if got := ConvertWebVTT(data, nil); got != wantVTT {
+		t.Errorf("unexpected WebVTT:\n%s", got)
+	}
+
+	ass := ConvertASS(map[string]string{"ti": "song"}, data, nil)
+	for _, want := range []string{
+		"Title: song\n",
+		`Dialogue: 0,0:00:00.98,0:00:01.98,Default,,0,0,0,,{\k50}家まで{\k50}送って\N{\rSecondary}希望你能送我回家`,
+		`Dialogue: 0,0:00:03.00,0:00:04.00,Default,,0,0,0,,{\k40}Hello {\k10}{\k50}<world>`,
+	} {
+		if !strings.Contains(ass, want) {
+			t.Errorf("ASS missing %q:\n%s", want, ass)
+		}
+	}
+
+	wantLRC := "[ti:song]\n\n[00:00.98]<00:00.98>家まで<00:01.48>送って<00:01.98>\n[00:00.98]希望你能送我回家\n" +
+		"[00:03.00]<00:03.00>Hello <00:03.50><world><00:04.00>"
+	if got := New(map[string]string{"ti": "song"}, data).EnhancedLRC(); got != wantLRC {
+		t.Errorf("unexpected Enhanced LRC:\n%s", got)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	orig := ParseYRC("[980,1000](980,500,0)家まで(1480,500,0)送って\n[3000,1500](3000,400,0)Hello (3500,1000,0)world")
+	if got := ParseYRC(EncodeYRC(orig)); !reflect.DeepEqual(got, orig) {
+		t.Fatalf("yrc round trip:\n got %#v\nwant %#v", got, orig)
+	}
+
+	tags := map[string]string{"ti": "A&B \"song\"", "ar": "<singer>", "offset": "0"}
+	qrc := EncodeQRC(tags, orig)
+	gotTags, gotData := ParseQRC(qrc)
+	if !reflect.DeepEqual(gotTags, tags) || !reflect.DeepEqual(gotData, orig) {
+		t.Fatalf("qrc round trip:\n%s\n got %#v %#v", qrc, gotTags, gotData)
+	}
+	encrypted, err := EncryptQRCHex(qrc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, err := DecryptQRCHex(encrypted); err != nil || plain != qrc {
+		t.Fatalf("DecryptQRCHex(EncryptQRCHex(x)) = %q, %v", plain, err)
+	}
+
+	// 音译和翻译轨道按 ParseKRC 的结构构造：时间与原文相同
+	data := MultiData{"orig": orig, "roma": Data{}, "ts": Data{}}
+	for _, line := range orig {
+		roma := Line{Start: line.Start, End: line.End}
+		for _, w := range line.Words {
+			roma.Words = append(roma.Words, Word{Start: w.Start, End: w.End, Text: strings.ToLower(w.Text)})
+		}
+		data["roma"] = append(data["roma"], roma)
+	}
+	data["ts"] = append(data["ts"], Line{Start: orig[0].Start, End: orig[0].End, Words: []Word{{Start: orig[0].Start, End: orig[0].End, Text: "送我回家"}}})
+	krc := EncodeKRC(map[string]string{"ti": "song"}, data)
+	gotTags, gotMulti := ParseKRC(krc)
+	if gotTags["ti"] != "song" || gotTags["language"] == "" {
+		t.Fatalf("unexpected krc tags %v", gotTags)
+	}
+	if !reflect.DeepEqual(gotMulti, data) {
+		t.Fatalf("krc round trip:\n%s\n got %#v\nwant %#v", krc, gotMulti, data)
+	}
+	encoded, err := EncodeKRCBase64(krc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, err := DecodeKRCBase64(encoded); err != nil || plain != krc {
+		t.Fatalf("DecodeKRCBase64(EncodeKRCBase64(x)) = %q, %v", plain, err)
+	}
+}
+
+func TestEncodeFillsMissingWordTimes(t *testing.T) {
+	_, data := ParseLRC("[00:01.00]逐[00:01.50]字\n[00:03.00]next")
+	got := ParseYRC(EncodeYRC(data))
+	if w := got[0].Words[1]; w.Start.MS != 1500 || w.End.MS != 3000 {
+		t.Fatalf("unexpected filled word %#v", w)
+	}
+	if got[1].Start.MS != 3000 || got[1].Words[0].Text != "next" {
+		t.Fatalf("unexpected line %#v", got[1])
+	}
+}
+
+func TestTimingTools(t *testing.T) {
+	data := ParseYRC("[1000,1000](1000,500,0)a(1500,500,0)b\n[10000,1000](10000,1000,0)c\n[20000,1000](20000,1000,0)d")
+	starts := func(d Data) []int {
+		var out []int
+		for _, line := range d {
+			out = append(out, line.Start.MS)
+			for _, w := range line.Words {
+				out = append(out, w.Start.MS, w.End.MS)
+			}
+		}
+		return out
+	}
+
+	if got, want := starts(Shift(data, -1200)), []int{0, 0, 300, 300, 800, 8800, 8800, 9800, 18800, 18800, 19800}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Shift = %v, want %v", got, want)
+	}
+	if data[0].Start.MS != 1000 {
+		t.Fatal("Shift modified its input")
+	}
+	if got := ShiftLines(data, 1, 2, 500); got[0].Start.MS != 1000 || got[1].Words[0].End.MS != 11500 || got[2].Start.MS != 20000 {
+		t.Errorf("unexpected ShiftLines %v", starts(got))
+	}
+	if got := Rescale(data, 20000, 22000); got[2].Start.MS != 22000 || got[0].Words[1].End.MS != 2200 {
+		t.Errorf("unexpected Rescale %v", starts(got))
+	}
+
+	got := Align(data, Anchor{From: 10000, To: 11000}, Anchor{From: 1000, To: 1500})
+	if got[0].Start.MS != 1500 || got[0].Words[1].Start.MS != 2028 {
+		t.Errorf("unexpected Align around the first anchor: %v", starts(got))
+	}
+	if got[1].Start.MS != 11000 || got[2].Start.MS != 21000 {
+		t.Errorf("after the last anchor should only shift: %v", starts(got))
+	}
+	if mid := Align(Data{{Start: Time{MS: 5500, OK: true}}}, Anchor{From: 1000, To: 1500}, Anchor{From: 10000, To: 11000}); mid[0].Start.MS != 6250 {
+		t.Errorf("Align between anchors = %d, want 6250", mid[0].Start.MS)
+	}
+
+	tags, lrc := ParseLRC("[offset:500]\n[00:01.00]hello")
+	if got := ApplyLRCOffset(tags, lrc); got[0].Start.MS != 500 || got[0].Words[0].Start.MS != 500 {
+		t.Errorf("unexpected ApplyLRCOffset %+v", got[0])
+	}
+	l := New(tags, MultiData{"orig": lrc})
+	l.ApplyOffset()
+	l.ApplyOffset()
+	if l.Orig()[0].Start.MS != 500 || l.Tags["offset"] != "" {
+		t.Errorf("ApplyOffset should apply once and drop the tag: %+v %v", l.Orig()[0], l.Tags)
+	}
+}
@@ -29,6 +29,20 @@ func qrcTripleDESDecrypt(block []byte) []byte {
 	return data
 }
 
+// qrcTripleDESEncrypt 是 qrcTripleDESDecrypt 的逆运算
+func qrcTripleDESEncrypt(block []byte) []byte {
+	keys := [3][16][6]byte{
+		qrcKeySchedule(qrcKey[0:], qrcEncrypt),
+		qrcKeySchedule(qrcKey[8:], qrcDecrypt),
+		qrcKeySchedule(qrcKey[16:], qrcEncrypt),
+	}
+	data := append([]byte(nil), block...)
+	for i := 0; i < 3; i++ {
+		data = qrcDESCrypt(data, keys[i])
+	}
+	return data
+}
+
 func qrcBitNum(a []byte, b, c int) uint32 {
 	return uint32((a[(b/32)*4+3-(b%32)/8]>>(7-b%8))&1) << c
 }
@@ -0,0 +1,94 @@
+package lyrics
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Lyrics 是平台返回的结构化歌词，保留逐字时间轴和翻译、音译等多个轨道
+type Lyrics struct {
+	// Tags LRC 标签，例如 ti (歌名)、ar (歌手)、al (专辑)
+	Tags map[string]string
+	// Data 按轨道保存的歌词："orig" 原文、"ts" 翻译、"roma" 音译
+	Data MultiData
+	// Languages 每个轨道的语言 (BCP 47)，键与 Data 相同，无法识别的轨道不在其中
+	Languages map[string]string
+}
+
+// New 创建结构化歌词，并按文字识别每个轨道的语言。
+// 音译轨道记为原文语言加 "-Latn"，例如日语歌词的罗马音为 "ja-Latn"。
+func New(tags map[string]string, data MultiData) *Lyrics {
+	l := &Lyrics{Tags: tags, Data: data, Languages: map[string]string{}}
+	if l.Tags == nil {
+		l.Tags = map[string]string{}
+	}
+	for key, lines := range data {
+		if key == "roma" {
+			continue
+		}
+		if lang := DetectLanguage(lines); lang != "" {
+			l.Languages[key] = lang
+		}
+	}
+	if _, ok := data["roma"]; ok {
+		if orig := l.Languages["orig"]; orig != "" && orig != "en" {
+			l.Languages["roma"] = orig + "-Latn"
+		}
+	}
+	return l
+}
+
+// LRC 按 order 的轨道顺序生成逐字 LRC，与各平台 GetLyrics 返回的文本相同。
+// order 为空时使用 DefaultDisplayOrder。
+func (l *Lyrics) LRC(order ...string) string {
+	return ConvertVerbatimLRC(l.Tags, l.Data, order)
+}
+
+// Orig 返回原文轨道
+func (l *Lyrics) Orig() Data {
+	return l.Data["orig"]
+}
+
+// Text 返回 line 去掉时间轴后的文本
+func (line Line) Text() string {
+	var b strings.Builder
+	for _, w := range line.Words {
+		b.WriteString(w.Text)
+	}
+	return b.String()
+}
+
+// DetectLanguage 按文字的书写系统粗略识别歌词语言：
+// 含假名为 "ja"，以谚文为主为 "ko"，以汉字为主为 "zh"，以拉丁字母为主为 "en"。
+func DetectLanguage(data Data) string {
+	var kana, hangul, han, latin int
+	for _, line := range data {
+		for _, r := range line.Text() {
+			switch {
+			case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+				kana++
+			case unicode.Is(unicode.Hangul, r):
+				hangul++
+			case unicode.Is(unicode.Han, r):
+				han++
+			case unicode.Is(unicode.Latin, r):
+				latin++
+			}
+		}
+	}
+	total := kana + hangul + han + latin
+	switch {
+	case total == 0:
+		return ""
+	// 日语歌词中汉字往往多于假名，只要假名占一定比例就认为是日语
+	case kana*10 >= total:
+		return "ja"
+	case hangul*2 >= total:
+		return "ko"
+	case han*2 >= total:
+		return "zh"
+	case latin*2 >= total:
+		return "en"
+	}
+	return ""
+}
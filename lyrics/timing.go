@@ -0,0 +1,156 @@
+package lyrics
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Anchor 是对齐用的锚点：原时间轴上的 From 对应目标时间轴上的 To (毫秒)
+type Anchor struct {
+	From int
+	To   int
+}
+
+// Shift 把所有行和字的时间平移 ms 毫秒，ms 为负时提前，平移后小于 0 的时间记为 0。
+// 返回新的 Data，不修改 data。
+func Shift(data Data, ms int) Data {
+	return ShiftLines(data, 0, len(data), ms)
+}
+
+// ShiftLines 只平移下标在 [from, to) 范围内的行，用于修正中间某一段的偏差
+func ShiftLines(data Data, from, to, ms int) Data {
+	out := cloneData(data)
+	if from < 0 {
+		from = 0
+	}
+	if to > len(out) {
+		to = len(out)
+	}
+	for i := from; i < to; i++ {
+		mapLineTimes(&out[i], func(t int) int { return t + ms })
+	}
+	return out
+}
+
+// Rescale 把时间轴从总时长 fromDuration 线性缩放到 toDuration (毫秒)，
+// 用于同一首歌不同版本之间速度略有差异的情况。
+func Rescale(data Data, fromDuration, toDuration int) Data {
+	if fromDuration <= 0 || toDuration <= 0 {
+		return cloneData(data)
+	}
+	ratio := float64(toDuration) / float64(fromDuration)
+	return mapTimes(data, func(t int) int { return int(float64(t)*ratio + 0.5) })
+}
+
+// Align 按锚点分段线性调整时间轴：相邻锚点之间按比例缩放，第一个锚点之前和最后一个锚点之后只做平移。
+// 只有一个锚点时等同于 Shift。
+func Align(data Data, anchors ...Anchor) Data {
+	if len(anchors) == 0 {
+		return cloneData(data)
+	}
+	points := append([]Anchor(nil), anchors...)
+	sort.Slice(points, func(i, j int) bool { return points[i].From < points[j].From })
+	return mapTimes(data, func(t int) int { return alignTime(points, t) })
+}
+
+func alignTime(points []Anchor, t int) int {
+	first, last := points[0], points[len(points)-1]
+	if t <= first.From {
+		return t + first.To - first.From
+	}
+	if t >= last.From {
+		return t + last.To - last.From
+	}
+	i := sort.Search(len(points), func(i int) bool { return points[i].From > t }) - 1
+	a, b := points[i], points[i+1]
+	if b.From == a.From {
+		return t + a.To - a.From
+	}
+	return a.To + int(float64(t-a.From)*float64(b.To-a.To)/float64(b.From-a.From)+0.5)
+}
+
+// LRCOffset 返回 LRC 的 [offset:] 标签 (毫秒)，没有或无法解析时返回 0。
+// 按 LRC 的约定，offset 为正表示歌词整体提前显示。
+func LRCOffset(tags map[string]string) int {
+	v, err := strconv.Atoi(strings.TrimSpace(tags["offset"]))
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// ApplyLRCOffset 把 tags 中的 [offset:] 应用到 ParseLRC 解析出的时间轴上。
+// 之后生成 LRC 时应去掉 offset 标签，否则播放器会再偏移一次。
+func ApplyLRCOffset(tags map[string]string, data Data) Data {
+	return Shift(data, -LRCOffset(tags))
+}
+
+// Shift 平移所有轨道，参见 Shift
+func (l *Lyrics) Shift(ms int) {
+	for k, lines := range l.Data {
+		l.Data[k] = Shift(lines, ms)
+	}
+}
+
+// Rescale 缩放所有轨道的时间轴，参见 Rescale
+func (l *Lyrics) Rescale(fromDuration, toDuration int) {
+	for k, lines := range l.Data {
+		l.Data[k] = Rescale(lines, fromDuration, toDuration)
+	}
+}
+
+// Align 按锚点对齐所有轨道，参见 Align
+func (l *Lyrics) Align(anchors ...Anchor) {
+	for k, lines := range l.Data {
+		l.Data[k] = Align(lines, anchors...)
+	}
+}
+
+// ApplyOffset 把 Tags 中的 [offset:] 应用到所有轨道并删除该标签，重复调用不会重复偏移
+func (l *Lyrics) ApplyOffset() {
+	if offset := LRCOffset(l.Tags); offset != 0 {
+		l.Shift(-offset)
+	}
+	delete(l.Tags, "offset")
+}
+
+func mapTimes(data Data, f func(int) int) Data {
+	out := cloneData(data)
+	for i := range out {
+		mapLineTimes(&out[i], f)
+	}
+	return out
+}
+
+// mapLineTimes 对行和字中有效的时间应用 f，结果小于 0 时记为 0
+func mapLineTimes(line *Line, f func(int) int) {
+	apply := func(t *Time) {
+		if !t.OK {
+			return
+		}
+		if t.MS = f(t.MS); t.MS < 0 {
+			t.MS = 0
+		}
+	}
+	apply(&line.Start)
+	apply(&line.End)
+	for j := range line.Words {
+		apply(&line.Words[j].Start)
+		apply(&line.Words[j].End)
+	}
+}
+
+func cloneData(data Data) Data {
+	if data == nil {
+		return nil
+	}
+	out := make(Data, len(data))
+	for i, line := range data {
+		out[i] = line
+		if line.Words != nil {
+			out[i].Words = append(make([]Word, 0, len(line.Words)), line.Words...)
+		}
+	}
+	return out
+}
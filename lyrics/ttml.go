@@ -0,0 +1,293 @@
+package lyrics
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseTTML 解析 Apple Music 的 TTML 歌词。
+// <p> 为一行，带 begin/end 的 <span> 为一个字；ttm:agent 记录在 Line.Agent 中，
+// head 中 itunes 的 translation、transliteration 分别映射为 "ts"、"roma" 轨道，与原文按 itunes:key 对齐。
+// 只有行级时间轴的 TTML 会把整行作为一个字。
+func ParseTTML(raw string) (*Lyrics, error) {
+	p := &ttmlParser{
+		agents: map[string]string{},
+		extra:  map[string]*ttmlTrack{},
+	}
+	if err := p.parse(raw); err != nil {
+		return nil, err
+	}
+	if len(p.orig) == 0 {
+		return nil, errors.New("ttml contains no lyric lines")
+	}
+
+	data := MultiData{"orig": make(Data, 0, len(p.orig))}
+	for _, line := range p.orig {
+		if name := p.agents[line.Agent]; name != "" {
+			line.Agent = name
+		}
+		data["orig"] = append(data["orig"], line)
+	}
+	for name, track := range p.extra {
+		lines := make(Data, len(p.orig))
+		for i, line := range p.orig {
+			lines[i] = Line{Start: line.Start, End: line.End, Agent: data["orig"][i].Agent}
+			words := track.text[p.keys[i]]
+			if len(words) == 1 && !words[0].Start.OK {
+				// 逐行的翻译没有时间轴，沿用原文整行的时间
+				words[0].Start, words[0].End = line.Start, line.End
+			}
+			lines[i].Words = words
+		}
+		data[name] = lines
+	}
+
+	l := New(nil, data)
+	if p.lang != "" {
+		l.Languages["orig"] = p.lang
+	}
+	for name, track := range p.extra {
+		if track.lang != "" {
+			l.Languages[name] = track.lang
+		}
+	}
+	return l, nil
+}
+
+type ttmlTrack struct {
+	lang string
+	text map[string][]Word
+}
+
+type ttmlParser struct {
+	lang   string
+	orig   Data
+	keys   []string
+	agents map[string]string
+	// extra 保存 "ts"、"roma" 轨道，键为 itunes:key
+	extra map[string]*ttmlTrack
+}
+
+func (p *ttmlParser) parse(raw string) error {
+	dec := xml.NewDecoder(strings.NewReader(raw))
+	dec.Strict = false
+
+	var (
+		track     *ttmlTrack // 当前所在的 translation/transliteration
+		textKey   string
+		agentID   string
+		collector *ttmlCollector
+		line      Line
+		lineKey   string
+		inName    bool
+		text      strings.Builder
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			attrs := ttmlAttrs(t.Attr)
+			switch t.Name.Local {
+			case "tt":
+				p.lang = attrs["lang"]
+			case "agent":
+				agentID = attrs["id"]
+			case "name":
+				inName = agentID != ""
+				text.Reset()
+			case "translation", "transliteration":
+				name := "ts"
+				if t.Name.Local == "transliteration" {
+					name = "roma"
+				}
+				// 有多个翻译时优先使用简体中文
+				if old := p.extra[name]; old == nil || (!strings.HasPrefix(old.lang, "zh-Hans") && strings.HasPrefix(attrs["lang"], "zh-Hans")) {
+					p.extra[name] = &ttmlTrack{lang: attrs["lang"], text: map[string][]Word{}}
+					track = p.extra[name]
+				} else {
+					track = nil
+				}
+			case "text":
+				if track != nil {
+					textKey = attrs["for"]
+					collector = &ttmlCollector{}
+				}
+			case "p":
+				line = Line{Start: parseTTMLTime(attrs["begin"]), End: parseTTMLTime(attrs["end"]), Agent: attrs["agent"]}
+				lineKey = attrs["key"]
+				collector = &ttmlCollector{}
+			case "span":
+				if collector != nil {
+					collector.start(attrs)
+				}
+			case "br":
+				if collector != nil {
+					collector.add(" ")
+				}
+			}
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "agent":
+				agentID = ""
+			case "name":
+				if inName && p.agents[agentID] == "" {
+					p.agents[agentID] = strings.TrimSpace(text.String())
+				}
+				inName = false
+			case "translation", "transliteration":
+				track = nil
+			case "text":
+				if track != nil && collector != nil {
+					track.text[textKey] = collector.words(Time{}, Time{})
+				}
+				collector = nil
+			case "p":
+				if collector != nil {
+					line.Words = collector.words(line.Start, line.End)
+					p.orig = append(p.orig, line)
+					p.keys = append(p.keys, lineKey)
+				}
+				collector = nil
+			case "span":
+				if collector != nil {
+					collector.end()
+				}
+			}
+
+		case xml.CharData:
+			switch {
+			case collector != nil:
+				collector.add(string(t))
+			case inName:
+				text.Write(t)
+			}
+		}
+	}
+}
+
+// ttmlCollector 收集一行中的字，嵌套的 span (例如和声 x-bg) 会被展开。
+// 只有直接包含文字的 span 才成为一个字，只包着其他 span 的 span 即使带时间也不会产生字。
+type ttmlCollector struct {
+	list   []Word
+	prefix string
+	spans  []ttmlSpan // 当前所在的 span，最内层在末尾
+}
+
+type ttmlSpan struct {
+	start, end Time
+	word       int // 该 span 产生的字在 list 中的下标，还没有文字时为 -1
+}
+
+func (c *ttmlCollector) start(attrs map[string]string) {
+	c.spans = append(c.spans, ttmlSpan{start: parseTTMLTime(attrs["begin"]), end: parseTTMLTime(attrs["end"]), word: -1})
+}
+
+func (c *ttmlCollector) end() {
+	if len(c.spans) > 0 {
+		c.spans = c.spans[:len(c.spans)-1]
+	}
+}
+
+// add 追加文本：带时间的 span 中出现文字时生成一个字，span 之间的空白并入前一个字
+func (c *ttmlCollector) add(s string) {
+	s = collapseSpace(s)
+	if s == "" {
+		return
+	}
+	if n := len(c.spans); n > 0 && c.spans[n-1].start.OK {
+		span := &c.spans[n-1]
+		if span.word >= 0 {
+			c.list[span.word].Text += s
+			return
+		}
+		if strings.TrimSpace(s) != "" {
+			span.word = len(c.list)
+			c.list = append(c.list, Word{Start: span.start, End: span.end, Text: s})
+			return
+		}
+	}
+	if len(c.list) == 0 {
+		c.prefix += s
+		return
+	}
+	c.list[len(c.list)-1].Text += s
+}
+
+func (c *ttmlCollector) words(start, end Time) []Word {
+	if len(c.list) == 0 {
+		text := strings.TrimSpace(c.prefix)
+		if text == "" {
+			return nil
+		}
+		return []Word{{Start: start, End: end, Text: text}}
+	}
+	words := append([]Word(nil), c.list...)
+	words[0].Text = strings.TrimLeft(c.prefix+words[0].Text, " ")
+	last := len(words) - 1
+	words[last].Text = strings.TrimRight(words[last].Text, " ")
+	return words
+}
+
+func ttmlAttrs(attrs []xml.Attr) map[string]string {
+	out := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		out[a.Name.Local] = a.Value
+	}
+	return out
+}
+
+// collapseSpace 把连续的空白 (包括格式化 XML 时的换行和缩进) 合并为一个空格
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// parseTTMLTime 解析 "12.345"、"1:02.345"、"01:02:03.456" 和带 "s" 后缀的秒数
+func parseTTMLTime(s string) Time {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "s")
+	if s == "" {
+		return Time{}
+	}
+	parts := strings.Split(s, ":")
+	sec, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return Time{}
+	}
+	total := sec
+	unit := 60.0
+	for i := len(parts) - 2; i >= 0; i-- {
+		v, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return Time{}
+		}
+		total += float64(v) * unit
+		unit *= 60
+	}
+	return Time{MS: int(total*1000 + 0.5), OK: true}
+}
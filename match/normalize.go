@@ -0,0 +1,89 @@
+// Package match 判断不同平台返回的歌曲是否为同一录音。
+package match
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/guohuiyuan/music-lib/model"
+	"golang.org/x/text/width"
+)
+
+// DefaultDurationTolerance 判断同一录音时允许的时长误差 (秒)
+const DefaultDurationTolerance = 3
+
+// 括号中出现这些词时只是宣传信息 (影视插曲、合作歌手等)，不影响是否为同一录音
+var titleNoiseRe = regexp.MustCompile(`(?i)[(（\[【][^)）\]】]*(《|》|主题曲|插曲|片头曲|片尾曲|推广曲|feat\.?|ft\.)[^)）\]】]*[)）\]】]`)
+
+var artistSplitRe = regexp.MustCompile(`(?i)\s*(?:[、,，/／&＆;；|]|\s+feat\.?\s+|\s+ft\.\s+|\s+x\s+|\s+and\s+)\s*`)
+
+// normalizeText 全角转半角、转小写，并去掉空白和标点
+func normalizeText(s string) string {
+	s = strings.ToLower(width.Fold.String(s))
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// NormalizeTitle 归一化歌名，去掉 "(电视剧《xx》插曲)" 这类宣传后缀
+func NormalizeTitle(title string) string {
+	return normalizeText(titleNoiseRe.ReplaceAllString(title, ""))
+}
+
+// NormalizeArtists 拆分并归一化歌手列表，兼容 "、"、"/"、"&"、"feat." 等分隔方式
+func NormalizeArtists(artist string) []string {
+	parts := artistSplitRe.Split(width.Fold.String(artist), -1)
+	artists := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		name := normalizeText(part)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		artists = append(artists, name)
+	}
+	return artists
+}
+
+// artistsOverlap 判断两组歌手是否有交集，任意一方为空时不作限制
+func artistsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// durationClose 判断时长是否在误差范围内，任意一方未知时不作限制
+func durationClose(a, b, tolerance int) bool {
+	if a <= 0 || b <= 0 {
+		return true
+	}
+	return math.Abs(float64(a-b)) <= float64(tolerance)
+}
+
+// SameRecording 判断两首歌是否为同一录音：歌名归一化后相同、歌手有交集、时长误差不超过 tolerance 秒
+func SameRecording(a, b *model.Song, tolerance int) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	title := NormalizeTitle(a.Name)
+	if title == "" || title != NormalizeTitle(b.Name) {
+		return false
+	}
+	return artistsOverlap(NormalizeArtists(a.Artist), NormalizeArtists(b.Artist)) &&
+		durationClose(a.Duration, b.Duration, tolerance)
+}
@@ -0,0 +1,50 @@
+package match
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	cases := map[string]string{
+		"晴天":            "晴天",
+		"Ｈｅｌｌｏ， World!": "helloworld",
+		"光年之外 (电影《太空旅客》中国区主题曲)":      "光年之外",
+		"Stay (feat. Justin Bieber)": "stay",
+		"Live (Remix)":               "liveremix",
+	}
+	for in, want := range cases {
+		if got := NormalizeTitle(in); got != want {
+			t.Errorf("NormalizeTitle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeArtists(t *testing.T) {
+	got := NormalizeArtists("周杰伦、费玉清 / Lara feat. JJ Lin")
+	want := []string{"周杰伦", "费玉清", "lara", "jjlin"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeArtists = %v, want %v", got, want)
+	}
+}
+
+func TestSameRecording(t *testing.T) {
+	a := &model.Song{Name: "晴天", Artist: "周杰伦", Duration: 269}
+	cases := []struct {
+		b    model.Song
+		want bool
+	}{
+		{model.Song{Name: "晴天 ", Artist: "周杰伦/杨瑞代", Duration: 270}, true},
+		{model.Song{Name: "晴天", Artist: "", Duration: 0}, true},
+		{model.Song{Name: "晴天", Artist: "孙燕姿", Duration: 269}, false},
+		{model.Song{Name: "晴天", Artist: "周杰伦", Duration: 200}, false},
+		{model.Song{Name: "雨天", Artist: "周杰伦", Duration: 269}, false},
+	}
+	for _, c := range cases {
+		if got := SameRecording(a, &c.b, DefaultDurationTolerance); got != c.want {
+			t.Errorf("SameRecording(%+v) = %v, want %v", c.b, got, c.want)
+		}
+	}
+}
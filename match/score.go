@@ -0,0 +1,152 @@
+package match
+
+import (
+	"sort"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+// 各字段在综合得分中的权重，歌名和歌手决定是否为同一首歌，专辑和时长用于区分版本
+const (
+	titleWeight    = 0.5
+	artistWeight   = 0.3
+	albumWeight    = 0.1
+	durationWeight = 0.1
+)
+
+// DefaultMinScore 认为候选歌曲与目标为同一首歌的最低得分
+const DefaultMinScore = 0.75
+
+// MaxDurationDiff 双方时长都已知时允许的最大误差 (秒)。超出时通常是 Live、Remix、剪辑版等
+// 不同的录音，即使歌名和歌手完全相同也直接记 0 分。
+const MaxDurationDiff = 15
+
+// Candidate 是其他平台上的候选歌曲及其匹配得分
+type Candidate struct {
+	Song  model.Song `json:"song"`
+	Score float64    `json:"score"`
+}
+
+// Score 按歌名、歌手、专辑和时长计算 cand 与 want 的相似度，范围 0 ~ 1。
+// 某个字段任意一方缺失时该项按 0.5 计，既不加分也不扣分；时长相差超过 MaxDurationDiff 时返回 0。
+func Score(want, cand *model.Song) float64 {
+	if want == nil || cand == nil {
+		return 0
+	}
+	if want.Duration > 0 && cand.Duration > 0 && absInt(want.Duration-cand.Duration) > MaxDurationDiff {
+		return 0
+	}
+	score := titleWeight * textSimilarity(NormalizeTitle(want.Name), NormalizeTitle(cand.Name))
+	score += artistWeight * artistSimilarity(NormalizeArtists(want.Artist), NormalizeArtists(cand.Artist))
+	score += albumWeight * optionalSimilarity(NormalizeTitle(want.Album), NormalizeTitle(cand.Album))
+	score += durationWeight * durationSimilarity(want.Duration, cand.Duration)
+	return score
+}
+
+// Rank 对候选歌曲打分，过滤掉低于 minScore 的结果并按得分从高到低排序。
+// 得分相同时保持原有顺序 (即平台的搜索排名)。
+func Rank(want *model.Song, songs []model.Song, minScore float64) []Candidate {
+	candidates := make([]Candidate, 0, len(songs))
+	for _, s := range songs {
+		score := Score(want, &s)
+		if score < minScore {
+			continue
+		}
+		candidates = append(candidates, Candidate{Song: s, Score: score})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+func optionalSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0.5
+	}
+	return textSimilarity(a, b)
+}
+
+// textSimilarity 基于编辑距离的相似度，1 表示完全相同
+func textSimilarity(a, b string) float64 {
+	if a == b {
+		if a == "" {
+			return 0
+		}
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// artistSimilarity 取两组歌手的交集占较少一方的比例，兼容一方多列了合作歌手的情况
+func artistSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0.5
+	}
+	set := make(map[string]bool, len(a))
+	for _, name := range a {
+		set[name] = true
+	}
+	common := 0
+	for _, name := range b {
+		if set[name] {
+			common++
+		}
+	}
+	return float64(common) / float64(minInt(len(a), len(b)))
+}
+
+// durationSimilarity 误差在 DefaultDurationTolerance 内记满分，之后每多 1 秒递减，超出 30 秒记 0
+func durationSimilarity(a, b int) float64 {
+	if a <= 0 || b <= 0 {
+		return 0.5
+	}
+	diff := absInt(a - b)
+	if diff <= DefaultDurationTolerance {
+		return 1
+	}
+	s := 1 - float64(diff-DefaultDurationTolerance)/30
+	if s < 0 {
+		return 0
+	}
+	return s
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
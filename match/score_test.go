@@ -0,0 +1,45 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+func TestRankPrefersExactRecording(t *testing.T) {
+	want := &model.Song{Name: "晴天", Artist: "周杰伦", Album: "叶惠美", Duration: 269}
+	songs := []model.Song{
+		{ID: "cover", Name: "晴天", Artist: "孙燕姿", Duration: 250},
+		{ID: "live", Name: "晴天 (Live)", Artist: "周杰伦", Album: "地表最强演唱会", Duration: 300},
+		{ID: "orig", Name: "晴天", Artist: "周杰伦", Album: "叶惠美", Duration: 270},
+		{ID: "feat", Name: "晴天", Artist: "周杰伦/五月天", Duration: 269},
+	}
+
+	got := Rank(want, songs, DefaultMinScore)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 candidates, got %+v", got)
+	}
+	if got[0].Song.ID != "orig" || got[0].Score != 1 {
+		t.Fatalf("exact recording should rank first with score 1, got %+v", got[0])
+	}
+	if got[1].Song.ID != "feat" {
+		t.Fatalf("unexpected second candidate %+v", got[1])
+	}
+}
+
+func TestScoreRejectsDifferentDuration(t *testing.T) {
+	want := &model.Song{Name: "晴天", Artist: "周杰伦", Album: "叶惠美", Duration: 269}
+	extended := &model.Song{Name: "晴天", Artist: "周杰伦", Duration: 269 + 3*60}
+	if got := Score(want, extended); got != 0 {
+		t.Fatalf("same title and artist with a much longer duration should score 0, got %v", got)
+	}
+	if got := Rank(want, []model.Song{*extended}, DefaultMinScore); len(got) != 0 {
+		t.Fatalf("extended version should not be accepted: %+v", got)
+	}
+
+	// 时长未知时仍按歌名和歌手匹配
+	unknown := &model.Song{Name: "晴天", Artist: "周杰伦"}
+	if got := Score(want, unknown); got < DefaultMinScore {
+		t.Fatalf("song without duration should still match, got %v", got)
+	}
+}
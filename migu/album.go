@@ -1,6 +1,7 @@
 package migu
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,15 +16,32 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultMigu.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultMigu.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) { return defaultMigu.GetAlbumSongs(id) }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultMigu.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultMigu.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultMigu.ParseAlbumContext(ctx, link)
+}
+
 // SearchPlaylist 搜索歌单
 // SearchAlbum 鎼滅储涓撹緫
 func (m *Migu) SearchAlbum(keyword string) ([]model.Playlist, error) {
+	return m.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext 与 SearchAlbum 相同，但请求受 ctx 控制。
+func (m *Migu) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("ua", "Android_migu")
 	params.Set("version", "5.0.1")
@@ -34,7 +52,7 @@ func (m *Migu) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 	apiURL := "http://pd.musicapp.migu.cn/MIGUM2.0/v1.0/content/search_all.do?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := m.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", m.cookie),
@@ -99,11 +117,21 @@ func (m *Migu) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 // GetPlaylistSongs 获取歌单详情（解析歌曲列表）
 func (m *Migu) GetAlbumSongs(id string) ([]model.Song, error) {
-	songs, _, err := m.fetchAlbumSongs(id)
+	return m.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext 与 GetAlbumSongs 相同，但请求受 ctx 控制。
+func (m *Migu) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	songs, _, err := m.fetchAlbumSongs(ctx, id)
 	return songs, err
 }
 
 func (m *Migu) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return m.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext 与 ParseAlbum 相同，但请求受 ctx 控制。
+func (m *Migu) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`music\.migu\.cn/(?:v3|v5)/music/album/(\d+)`),
 		regexp.MustCompile(`albumId=(\d+)`),
@@ -113,7 +141,7 @@ func (m *Migu) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return m.fetchAlbumDetail(matches[1])
+			return m.fetchAlbumDetail(ctx, matches[1])
 		}
 	}
 
@@ -1,6 +1,7 @@
 package migu
 
 import (
+	"context"
 	"errors"
 	"github.com/guohuiyuan/music-lib/model"
 	"net/http"
@@ -10,10 +11,19 @@ import (
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultMigu.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultMigu.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL 获取下载链接
 func (m *Migu) GetDownloadURL(s *model.Song) (string, error) {
+	return m.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext is like GetDownloadURL but carries ctx through its requests.
+func (m *Migu) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "migu" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	if s.URL != "" {
 		return s.URL, nil
@@ -50,13 +60,12 @@ func (m *Migu) GetDownloadURL(s *model.Song) (string, error) {
 
 	apiURL := "http://app.pd.nf.migu.cn/MIGUM2.0/v1.0/content/sub/listenSong.do?" + params.Encode()
 
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
+	client := m.client.HTTPClient()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
 	}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package migu
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,9 +13,18 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultMigu.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultMigu.GetLyricsContext(ctx, s)
+}
+
 func (m *Migu) GetLyrics(s *model.Song) (string, error) {
+	return m.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (m *Migu) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "migu" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	contentID := ""
@@ -37,7 +47,7 @@ func (m *Migu) GetLyrics(s *model.Song) (string, error) {
 
 	apiURL := "http://c.musicapp.migu.cn/MIGUM2.0/v1.0/content/resourceinfo.do?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := m.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", m.cookie),
@@ -58,7 +68,7 @@ func (m *Migu) GetLyrics(s *model.Song) (string, error) {
 	}
 
 	if len(resp.Resource) == 0 {
-		return "", errors.New("resource info not found")
+		return "", model.Errorf(model.ErrNotFound, "resource info not found")
 	}
 
 	lyricUrl := resp.Resource[0].LrcUrl
@@ -67,12 +77,12 @@ func (m *Migu) GetLyrics(s *model.Song) (string, error) {
 	}
 
 	if lyricUrl == "" {
-		return "", errors.New("lyric url not found")
+		return "", model.Errorf(model.ErrNotFound, "lyric url not found")
 	}
 
 	lyricUrl = strings.Replace(lyricUrl, "http://", "https://", 1)
 
-	lrcBody, err := utils.Get(lyricUrl,
+	lrcBody, err := m.client.GetContext(ctx, lyricUrl,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/143.0.0.0 Safari/537.36"),
 		utils.WithHeader("Referer", "https://y.migu.cn/"),
 		utils.WithHeader("Cookie", m.cookie),
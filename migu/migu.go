@@ -1,6 +1,7 @@
 package migu
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,13 +21,16 @@ const (
 
 type Migu struct {
 	cookie string
+	client *utils.Client
 }
 
-func New(cookie string) *Migu { return &Migu{cookie: cookie} }
+func New(cookie string, opts ...utils.ClientOption) *Migu {
+	return &Migu{cookie: cookie, client: utils.NewClient(opts...)}
+}
 
 var defaultMigu = New("")
 
-func (m *Migu) fetchPlaylistInfo(id string) (*model.Playlist, error) {
+func (m *Migu) fetchPlaylistInfo(ctx context.Context, id string) (*model.Playlist, error) {
 	playlistID := strings.TrimSpace(id)
 	if playlistID == "" {
 		return nil, errors.New("playlist id is empty")
@@ -38,7 +42,7 @@ func (m *Migu) fetchPlaylistInfo(id string) (*model.Playlist, error) {
 	params.Set("resourceId", playlistID)
 
 	apiURL := "https://app.c.nf.migu.cn/MIGUM2.0/v1.0/content/resourceinfo.do?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := m.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", m.cookie),
@@ -76,7 +80,7 @@ func (m *Migu) fetchPlaylistInfo(id string) (*model.Playlist, error) {
 		return nil, fmt.Errorf("migu api error: %s (code %s)", resp.Info, resp.Code)
 	}
 	if len(resp.Resource) == 0 {
-		return nil, errors.New("migu playlist info not found")
+		return nil, model.Errorf(model.ErrNotFound, "migu playlist info not found")
 	}
 
 	info := resp.Resource[0]
@@ -101,18 +105,18 @@ func (m *Migu) fetchPlaylistInfo(id string) (*model.Playlist, error) {
 	return playlist, nil
 }
 
-func (m *Migu) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (m *Migu) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	playlistID := strings.TrimSpace(id)
 	if playlistID == "" {
 		return nil, nil, errors.New("playlist id is empty")
 	}
 
-	songs, err := m.GetPlaylistSongs(playlistID)
+	songs, err := m.GetPlaylistSongsContext(ctx, playlistID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	playlist, infoErr := m.fetchPlaylistInfo(playlistID)
+	playlist, infoErr := m.fetchPlaylistInfo(ctx, playlistID)
 	if infoErr != nil {
 		playlist = &model.Playlist{
 			Source:     "migu",
@@ -137,13 +141,13 @@ func (m *Migu) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, er
 }
 
 // Parse 解析链接并获取完整信息
-func (m *Migu) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
+func (m *Migu) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	albumID := strings.TrimSpace(id)
 	if albumID == "" {
 		return nil, nil, errors.New("album id is empty")
 	}
 
-	songs, totalSongs, err := m.fetchAlbumSongs(albumID)
+	songs, totalSongs, err := m.fetchAlbumSongs(ctx, albumID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -154,7 +158,7 @@ func (m *Migu) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error
 	params.Set("resourceId", albumID)
 
 	apiURL := "https://app.c.nf.migu.cn/MIGUM2.0/v1.0/content/resourceinfo.do?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := m.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", m.cookie),
@@ -192,7 +196,7 @@ func (m *Migu) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error
 		return nil, nil, fmt.Errorf("migu api error: %s (code %s)", resp.Info, resp.Code)
 	}
 	if len(resp.Resource) == 0 {
-		return nil, nil, errors.New("album not found")
+		return nil, nil, model.Errorf(model.ErrNotFound, "album not found")
 	}
 
 	info := resp.Resource[0]
@@ -233,7 +237,7 @@ func (m *Migu) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error
 	return album, songs, nil
 }
 
-func (m *Migu) fetchAlbumSongs(id string) ([]model.Song, int, error) {
+func (m *Migu) fetchAlbumSongs(ctx context.Context, id string) ([]model.Song, int, error) {
 	albumID := strings.TrimSpace(id)
 	if albumID == "" {
 		return nil, 0, errors.New("album id is empty")
@@ -252,7 +256,7 @@ func (m *Migu) fetchAlbumSongs(id string) ([]model.Song, int, error) {
 		params.Set("pageSize", strconv.Itoa(pageSize))
 
 		apiURL := "https://app.c.nf.migu.cn/MIGUM2.0/v1.0/content/queryAlbumSong?" + params.Encode()
-		body, err := utils.Get(apiURL,
+		body, err := m.client.GetContext(ctx, apiURL,
 			utils.WithHeader("User-Agent", UserAgent),
 			utils.WithHeader("Referer", Referer),
 			utils.WithHeader("Cookie", m.cookie),
@@ -378,14 +382,14 @@ type MiguSongItem struct {
 }
 
 // fetchSongDetail 通过 contentId 获取歌曲详情
-func (m *Migu) fetchSongDetail(contentID string) (*model.Song, error) {
+func (m *Migu) fetchSongDetail(ctx context.Context, contentID string) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("resourceType", "2")
 	params.Set("contentId", contentID)
 
 	// 使用 queryById 接口获取详情，结构与 Search 结果类似
 	apiURL := "http://c.musicapp.migu.cn/MIGUM2.0/v1.0/content/queryById.do?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := m.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", m.cookie),
@@ -413,7 +417,7 @@ func (m *Migu) fetchSongDetail(contentID string) (*model.Song, error) {
 	} else if resp.Data.Item.ContentID != "" {
 		item = resp.Data.Item
 	} else {
-		return nil, errors.New("song detail not found")
+		return nil, model.Errorf(model.ErrNotFound, "song detail not found")
 	}
 
 	song := m.convertItemToSong(item)
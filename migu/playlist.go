@@ -1,6 +1,7 @@
 package migu
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,21 +18,46 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultMigu.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultMigu.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) { return defaultMigu.GetPlaylistSongs(id) }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultMigu.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultMigu.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultMigu.ParsePlaylistContext(ctx, link)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultMigu.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultMigu.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultMigu.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultMigu.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (m *Migu) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return m.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext is like GetPlaylistCategories but carries ctx through its requests.
+func (m *Migu) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	categories := []model.PlaylistCategory{{
 		Source: "migu",
 		ID:     "",
@@ -51,6 +77,11 @@ func (m *Migu) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 }
 
 func (m *Migu) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return m.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext is like GetCategoryPlaylists but carries ctx through its requests.
+func (m *Migu) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	categoryID = strings.TrimSpace(categoryID)
 	if categoryID == "" {
 		categoryID = "华语"
@@ -62,7 +93,7 @@ func (m *Migu) GetCategoryPlaylists(categoryID string, page, limit int) ([]model
 		limit = 20
 	}
 
-	playlists, err := m.searchPlaylists(categoryID, page, limit)
+	playlists, err := m.searchPlaylists(ctx, categoryID, page, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -104,10 +135,15 @@ var miguPlaylistCategoryKeywords = []struct {
 }
 
 func (m *Migu) SearchPlaylist(keyword string) ([]model.Playlist, error) {
-	return m.searchPlaylists(keyword, 1, 10)
+	return m.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext is like SearchPlaylist but carries ctx through its requests.
+func (m *Migu) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return m.searchPlaylists(ctx, keyword, 1, 10)
 }
 
-func (m *Migu) searchPlaylists(keyword string, page, limit int) ([]model.Playlist, error) {
+func (m *Migu) searchPlaylists(ctx context.Context, keyword string, page, limit int) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("ua", "Android_migu")
 	params.Set("version", "5.0.1")
@@ -119,7 +155,7 @@ func (m *Migu) searchPlaylists(keyword string, page, limit int) ([]model.Playlis
 
 	apiURL := "http://pd.musicapp.migu.cn/MIGUM2.0/v1.0/content/search_all.do?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := m.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", m.cookie),
@@ -179,6 +215,11 @@ func (m *Migu) searchPlaylists(keyword string, page, limit int) ([]model.Playlis
 }
 
 func (m *Migu) GetPlaylistSongs(id string) ([]model.Song, error) {
+	return m.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext is like GetPlaylistSongs but carries ctx through its requests.
+func (m *Migu) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
 	playlistID := strings.TrimSpace(id)
 	if playlistID == "" {
 		return nil, errors.New("playlist id is empty")
@@ -196,7 +237,7 @@ func (m *Migu) GetPlaylistSongs(id string) ([]model.Song, error) {
 		params.Set("playlistId", playlistID)
 
 		apiURL := "https://app.c.nf.migu.cn/MIGUM3.0/resource/playlist/song/v2.0?" + params.Encode()
-		body, err := utils.Get(apiURL,
+		body, err := m.client.GetContext(ctx, apiURL,
 			utils.WithHeader("User-Agent", UserAgent),
 			utils.WithHeader("Referer", Referer),
 			utils.WithHeader("Cookie", m.cookie),
@@ -264,6 +305,11 @@ func (m *Migu) GetPlaylistSongs(id string) ([]model.Song, error) {
 }
 
 func (m *Migu) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return m.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext is like ParsePlaylist but carries ctx through its requests.
+func (m *Migu) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`playlistId=(\d+)`),
 		regexp.MustCompile(`musicListId=(\d+)`),
@@ -273,12 +319,12 @@ func (m *Migu) ParsePlaylist(link string) (*model.Playlist, []model.Song, error)
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return m.fetchPlaylistDetail(matches[1])
+			return m.fetchPlaylistDetail(ctx, matches[1])
 		}
 	}
 
 	if len(link) > 0 && !strings.Contains(link, "/") {
-		return m.fetchPlaylistDetail(link)
+		return m.fetchPlaylistDetail(ctx, link)
 	}
 
 	return nil, nil, errors.New("invalid migu playlist link")
@@ -1,6 +1,7 @@
 package migu
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,10 +13,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultMigu.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultMigu.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultMigu.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultMigu.ParseContext(ctx, link)
+}
+
 // Search 搜索歌曲
 func (m *Migu) Search(keyword string) ([]model.Song, error) {
+	return m.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext 与 Search 相同，但请求受 ctx 控制。
+func (m *Migu) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("ua", "Android_migu")
 	params.Set("version", "5.0.1")
@@ -26,7 +40,7 @@ func (m *Migu) Search(keyword string) ([]model.Song, error) {
 
 	apiURL := "http://pd.musicapp.migu.cn/MIGUM2.0/v1.0/content/search_all.do?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := m.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", m.cookie),
@@ -56,6 +70,11 @@ func (m *Migu) Search(keyword string) ([]model.Song, error) {
 }
 
 func (m *Migu) Parse(link string) (*model.Song, error) {
+	return m.ParseContext(context.Background(), link)
+}
+
+// ParseContext 与 Parse 相同，但请求受 ctx 控制。
+func (m *Migu) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	// 1. 提取 ContentID
 	// 支持格式: https://music.migu.cn/v3/music/song/60054701934
 	re := regexp.MustCompile(`music\.migu\.cn/v3/music/song/(\d+)`)
@@ -66,14 +85,14 @@ func (m *Migu) Parse(link string) (*model.Song, error) {
 	contentID := matches[1]
 
 	// 2. 获取歌曲详情 (为了拿到 resourceType 和 formatType)
-	song, err := m.fetchSongDetail(contentID)
+	song, err := m.fetchSongDetail(ctx, contentID)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. 获取下载链接
 	// 因为 convertItemToSong 已经填充了 Extra，所以可以直接调用 GetDownloadURL
-	downloadURL, err := m.GetDownloadURL(song)
+	downloadURL, err := m.GetDownloadURLContext(ctx, song)
 	if err == nil {
 		song.URL = downloadURL
 	}
@@ -1,11 +1,23 @@
 package migu
 
-import "github.com/guohuiyuan/music-lib/model"
+import (
+	"context"
+	"github.com/guohuiyuan/music-lib/model"
+)
 
 func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultMigu.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultMigu.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 func (p *Migu) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return p.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (p *Migu) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrUserPlaylistsUnsupported
 }
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+// 错误分类。各平台返回的错误可以用 errors.Is 判断属于哪一类，
+// 例如 errors.Is(err, model.ErrVIPRequired) 表示需要会员，换一个平台或账号可能就能下载。
+var (
+	ErrSourceMismatch = errors.New("source mismatch")
+	ErrVIPRequired    = errors.New("vip required")
+	// ErrRegionBlocked 受地区或版权限制，与是否会员无关
+	ErrRegionBlocked = errors.New("region blocked")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrNotFound      = errors.New("not found")
+	// ErrAuthExpired 未登录或登录态 (Cookie、Token) 已失效
+	ErrAuthExpired = errors.New("auth expired")
+	ErrUnsupported = errors.New("unsupported")
+)
+
+func init() {
+	utils.RegisterStatusKind(http.StatusUnauthorized, ErrAuthExpired)
+	utils.RegisterStatusKind(http.StatusNotFound, ErrNotFound)
+	utils.RegisterStatusKind(http.StatusTooManyRequests, ErrRateLimited)
+	utils.RegisterStatusKind(http.StatusUnavailableForLegalReasons, ErrRegionBlocked)
+}
+
+// Error 是带分类的错误，Error() 只返回原始错误信息，不会额外拼接分类名
+type Error struct {
+	Kind error // 上面的 Err* 之一
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Kind.Error()
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+func (e *Error) Is(target error) bool { return e.Kind == target }
+
+// Errorf 按 format 生成错误并归入 kind，format 中的 %w 仍然可以被 errors.Is / errors.As 展开
+func Errorf(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// CodeError 按 kinds 把平台返回的业务码归类，kinds 中没有的业务码返回不带分类的普通错误
+func CodeError(kinds map[int]error, code int, format string, args ...interface{}) error {
+	if kind, ok := kinds[code]; ok {
+		return Errorf(kind, format, args...)
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// KindOf 返回 err 所属的分类，无法归类时返回 nil
+func KindOf(err error) error {
+	for _, kind := range []error{
+		ErrSourceMismatch, ErrVIPRequired, ErrRegionBlocked, ErrRateLimited,
+		ErrNotFound, ErrAuthExpired, ErrUnsupported,
+	} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
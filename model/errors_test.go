@@ -0,0 +1,65 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func TestErrorfKeepsMessageAndKind(t *testing.T) {
+	cause := errors.New("boom")
+	err := Errorf(ErrVIPRequired, "download url not found: %w", cause)
+	if err.Error() != "download url not found: boom" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, ErrVIPRequired) || !errors.Is(err, cause) {
+		t.Fatalf("errors.Is should match both kind and cause")
+	}
+	if errors.Is(err, ErrRegionBlocked) {
+		t.Fatalf("unexpected kind match")
+	}
+
+	wrapped := fmt.Errorf("resolve: %w", err)
+	if KindOf(wrapped) != ErrVIPRequired {
+		t.Fatalf("KindOf(%v) = %v", wrapped, KindOf(wrapped))
+	}
+	if KindOf(cause) != nil {
+		t.Fatalf("plain error should not have a kind")
+	}
+}
+
+func TestCodeError(t *testing.T) {
+	kinds := map[int]error{301: ErrAuthExpired}
+	if err := CodeError(kinds, 301, "api error code: %d", 301); !errors.Is(err, ErrAuthExpired) || err.Error() != "api error code: 301" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := CodeError(kinds, 500, "api error code: %d", 500); KindOf(err) != nil {
+		t.Fatalf("unknown code should not be classified: %v", err)
+	}
+}
+
+func TestUnsupportedSentinels(t *testing.T) {
+	for _, err := range []error{ErrPlaylistCategoriesUnsupported, ErrUserPlaylistsUnsupported} {
+		if !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("%v should be ErrUnsupported", err)
+		}
+	}
+}
+
+func TestStatusErrorKinds(t *testing.T) {
+	cases := map[int]error{
+		401: ErrAuthExpired,
+		404: ErrNotFound,
+		429: ErrRateLimited,
+		451: ErrRegionBlocked,
+		500: nil,
+	}
+	for code, want := range cases {
+		err := fmt.Errorf("fetch: %w", &utils.StatusError{Method: "GET", StatusCode: code})
+		if got := KindOf(err); got != want {
+			t.Fatalf("status %d: got kind %v, want %v", code, got, want)
+		}
+	}
+}
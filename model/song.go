@@ -1,14 +1,13 @@
 package model
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/guohuiyuan/music-lib/utils"
 )
 
-var ErrPlaylistCategoriesUnsupported = errors.New("playlist categories not supported")
-var ErrUserPlaylistsUnsupported = errors.New("user playlists not supported")
+var ErrPlaylistCategoriesUnsupported = Errorf(ErrUnsupported, "playlist categories not supported")
+var ErrUserPlaylistsUnsupported = Errorf(ErrUnsupported, "user playlists not supported")
 
 // Song 是所有音乐源通用的歌曲结构
 type Song struct {
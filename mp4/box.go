@@ -0,0 +1,172 @@
+// Package mp4 提供解析 MP4/M4A box 结构的基础函数，
+// 汽水音乐的解密和 tag 包的 iTunes 标签写入共用这些函数。
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrBoxNotFound 表示在指定范围内没有找到目标 box
+var ErrBoxNotFound = errors.New("box not found")
+
+// Box 是在数据中找到的一个 box，Offset 和 Size 以整个数据为基准，Data 不含 box 头
+type Box struct {
+	Offset     int
+	Size       int
+	HeaderSize int
+	Data       []byte
+}
+
+// End 返回 box 结束的位置
+func (b *Box) End() int {
+	return b.Offset + b.Size
+}
+
+// Header 解析 box 头，支持 64 位长度 (size == 1)；size == 0 表示 box 一直延续到文件末尾，
+// 此时返回的 size 为 0，由调用方按文件长度处理
+func Header(head []byte) (boxType string, size uint64, headerSize int, ok bool) {
+	if len(head) < 8 {
+		return "", 0, 0, false
+	}
+	size = uint64(binary.BigEndian.Uint32(head[:4]))
+	boxType = string(head[4:8])
+	headerSize = 8
+	if size == 1 {
+		if len(head) < 16 {
+			return "", 0, 0, false
+		}
+		size = binary.BigEndian.Uint64(head[8:16])
+		headerSize = 16
+	}
+	if size != 0 && size < uint64(headerSize) {
+		return "", 0, 0, false
+	}
+	return boxType, size, headerSize, true
+}
+
+// FindBox 在 [start, end) 范围内的同级 box 中查找 boxType，不进入子 box
+func FindBox(data []byte, boxType string, start, end int) (*Box, error) {
+	if end > len(data) {
+		end = len(data)
+	}
+	pos := start
+	target := []byte(boxType)
+	for pos+8 <= end {
+		size := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		if size < 8 {
+			break
+		}
+		if bytes.Equal(data[pos+4:pos+8], target) {
+			if pos+size > len(data) {
+				break
+			}
+			return &Box{Offset: pos, Size: size, HeaderSize: 8, Data: data[pos+8 : pos+size]}, nil
+		}
+		pos += size
+	}
+	return nil, ErrBoxNotFound
+}
+
+// FindBoxDeep 与 FindBox 相同，但会递归进入已知的容器 box (moov、trak、stsd、mp4a 等)
+func FindBoxDeep(data []byte, boxType string, start, end int) (*Box, error) {
+	if end > len(data) {
+		end = len(data)
+	}
+	pos := start
+	target := []byte(boxType)
+	for pos+8 <= end {
+		size := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		headerSize := 8
+		if size == 1 {
+			if pos+16 > end {
+				break
+			}
+			size64 := binary.BigEndian.Uint64(data[pos+8 : pos+16])
+			if size64 > uint64(end-pos) {
+				break
+			}
+			size = int(size64)
+			headerSize = 16
+		}
+		if size < headerSize || pos+size > end {
+			break
+		}
+		currentType := string(data[pos+4 : pos+8])
+		if bytes.Equal(data[pos+4:pos+8], target) {
+			return &Box{Offset: pos, Size: size, HeaderSize: headerSize, Data: data[pos+headerSize : pos+size]}, nil
+		}
+
+		if childStart, ok := childStart(currentType, pos, headerSize); ok && childStart < pos+size {
+			if found, err := FindBoxDeep(data, boxType, childStart, pos+size); err == nil {
+				return found, nil
+			}
+		}
+		pos += size
+	}
+	return nil, ErrBoxNotFound
+}
+
+// FindPath 依次进入 path 中的每一级 box，例如 "moov", "udta", "meta"
+func FindPath(data []byte, start, end int, path ...string) (*Box, error) {
+	var box *Box
+	for _, name := range path {
+		found, err := FindBox(data, name, start, end)
+		if err != nil {
+			return nil, err
+		}
+		box = found
+		start, end = firstChild(name, box), box.End()
+	}
+	if box == nil {
+		return nil, ErrBoxNotFound
+	}
+	return box, nil
+}
+
+func firstChild(boxType string, b *Box) int {
+	if start, ok := childStart(boxType, b.Offset, b.HeaderSize); ok {
+		return start
+	}
+	if boxType == "meta" {
+		// meta 是 full box，子 box 前有 4 字节的 version/flags
+		return b.Offset + b.HeaderSize + 4
+	}
+	return b.Offset + b.HeaderSize
+}
+
+func childStart(boxType string, offset, headerSize int) (int, bool) {
+	switch boxType {
+	case "moov", "trak", "mdia", "minf", "stbl", "sinf", "schi":
+		return offset + headerSize, true
+	case "stsd":
+		return offset + headerSize + 8, true
+	case "enca", "mp4a", "alac", "fLaC":
+		return offset + headerSize + 28, true
+	default:
+		return 0, false
+	}
+}
+
+// ParseStsz 解析 stsz box 的内容，返回每个 sample 的大小
+func ParseStsz(data []byte) []uint32 {
+	if len(data) < 12 {
+		return nil
+	}
+	sampleSizeFixed := binary.BigEndian.Uint32(data[4:8])
+	sampleCount := int(binary.BigEndian.Uint32(data[8:12]))
+	sizes := make([]uint32, sampleCount)
+	if sampleSizeFixed != 0 {
+		for i := 0; i < sampleCount; i++ {
+			sizes[i] = sampleSizeFixed
+		}
+	} else {
+		for i := 0; i < sampleCount; i++ {
+			if 12+i*4+4 <= len(data) {
+				sizes[i] = binary.BigEndian.Uint32(data[12+i*4 : 12+i*4+4])
+			}
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,74 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func box(boxType string, data ...[]byte) []byte {
+	size := 8
+	for _, d := range data {
+		size += len(d)
+	}
+	out := make([]byte, 8, size)
+	binary.BigEndian.PutUint32(out, uint32(size))
+	copy(out[4:], boxType)
+	for _, d := range data {
+		out = append(out, d...)
+	}
+	return out
+}
+
+func TestHeader(t *testing.T) {
+	head := make([]byte, 16)
+	binary.BigEndian.PutUint32(head, 1)
+	copy(head[4:], "mdat")
+	binary.BigEndian.PutUint64(head[8:], 1<<33)
+	boxType, size, headerSize, ok := Header(head)
+	if !ok || boxType != "mdat" || size != 1<<33 || headerSize != 16 {
+		t.Fatalf("Header = %q %d %d %v", boxType, size, headerSize, ok)
+	}
+	if _, _, _, ok := Header([]byte{0, 0, 0, 4, 'f', 'r', 'e', 'e'}); ok {
+		t.Fatal("size smaller than header should be rejected")
+	}
+}
+
+func TestFindPath(t *testing.T) {
+	ilst := box("ilst", box("\xa9nam"))
+	meta := box("meta", []byte{0, 0, 0, 0}, box("hdlr", make([]byte, 25)), ilst)
+	data := append(box("ftyp", []byte("M4A ")), box("moov", box("mvhd", make([]byte, 100)), box("udta", meta))...)
+
+	found, err := FindPath(data, 0, len(data), "moov", "udta", "meta", "ilst")
+	if err != nil {
+		t.Fatalf("FindPath: %v", err)
+	}
+	if string(data[found.Offset+4:found.Offset+8]) != "ilst" || found.Size != len(ilst) || found.End() != len(data) {
+		t.Fatalf("unexpected box: %+v", found)
+	}
+	if _, err := FindPath(data, 0, len(data), "moov", "trak"); err != ErrBoxNotFound {
+		t.Fatalf("err = %v, want ErrBoxNotFound", err)
+	}
+}
+
+func TestFindBoxDeep(t *testing.T) {
+	stsd := box("stsd", make([]byte, 8), box("mp4a", make([]byte, 28), box("esds", []byte{1, 2})))
+	data := box("moov", box("trak", box("mdia", box("minf", box("stbl", stsd)))))
+	esds, err := FindBoxDeep(data, "esds", 0, len(data))
+	if err != nil {
+		t.Fatalf("FindBoxDeep: %v", err)
+	}
+	if string(esds.Data) != "\x01\x02" {
+		t.Fatalf("esds data = %x", esds.Data)
+	}
+}
+
+func TestParseStsz(t *testing.T) {
+	fixed := []byte{0, 0, 0, 0, 0, 0, 0, 7, 0, 0, 0, 3}
+	if sizes := ParseStsz(fixed); len(sizes) != 3 || sizes[2] != 7 {
+		t.Fatalf("fixed sizes = %v", sizes)
+	}
+	table := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 5, 0, 0, 0, 9}
+	if sizes := ParseStsz(table); len(sizes) != 2 || sizes[0] != 5 || sizes[1] != 9 {
+		t.Fatalf("table sizes = %v", sizes)
+	}
+}
@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -10,6 +11,11 @@ import (
 
 // IsVipAccount reports whether the current account is VIP.
 func (n *Netease) IsVipAccount() (bool, error) {
+	return n.IsVipAccountContext(context.Background())
+}
+
+// IsVipAccountContext is like IsVipAccount but carries ctx through its requests.
+func (n *Netease) IsVipAccountContext(ctx context.Context) (bool, error) {
 	if n.isVipCache != nil {
 		return *n.isVipCache, nil
 	}
@@ -41,7 +47,7 @@ func (n *Netease) IsVipAccount() (bool, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post(UserAccountAPI, strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, UserAccountAPI, strings.NewReader(form.Encode()), headers...)
 	if err != nil {
 		return false, fmt.Errorf("failed to fetch user account info: %w", err)
 	}
@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
@@ -11,17 +12,34 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultNetease.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultNetease.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(albumID string) ([]model.Song, error) {
 	return defaultNetease.GetAlbumSongs(albumID)
 }
 
+func GetAlbumSongsContext(ctx context.Context, albumID string) ([]model.Song, error) {
+	return defaultNetease.GetAlbumSongsContext(ctx, albumID)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultNetease.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultNetease.ParseAlbumContext(ctx, link)
+}
+
 // SearchAlbum searches albums.
 func (n *Netease) SearchAlbum(keyword string) ([]model.Playlist, error) {
-	body, err := n.cloudSearch(keyword, 10, 10)
+	return n.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext is like SearchAlbum but carries ctx through its requests.
+func (n *Netease) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	body, err := n.cloudSearch(ctx, keyword, 10, 10)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +70,7 @@ func (n *Netease) SearchAlbum(keyword string) ([]model.Playlist, error) {
 		return nil, fmt.Errorf("netease album json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return nil, fmt.Errorf("netease api error code: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "netease api error code: %d", resp.Code)
 	}
 
 	albums := make([]model.Playlist, 0, len(resp.Result.Albums))
@@ -95,15 +113,25 @@ func (n *Netease) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 // GetAlbumSongs returns songs in an album.
 func (n *Netease) GetAlbumSongs(albumID string) ([]model.Song, error) {
-	_, songs, err := n.fetchAlbumDetail(albumID)
+	return n.GetAlbumSongsContext(context.Background(), albumID)
+}
+
+// GetAlbumSongsContext is like GetAlbumSongs but carries ctx through its requests.
+func (n *Netease) GetAlbumSongsContext(ctx context.Context, albumID string) ([]model.Song, error) {
+	_, songs, err := n.fetchAlbumDetail(ctx, albumID)
 	return songs, err
 }
 
 // ParseAlbum parses an album link.
 func (n *Netease) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return n.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext is like ParseAlbum but carries ctx through its requests.
+func (n *Netease) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	kind, albumID, err := parseNeteaseLink(link)
 	if err != nil || kind != neteaseLinkAlbum {
 		return nil, nil, errNeteaseInvalidAlbumLink
 	}
-	return n.fetchAlbumDetail(albumID)
+	return n.fetchAlbumDetail(ctx, albumID)
 }
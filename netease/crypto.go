@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
@@ -12,9 +13,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net/url"
 	"strings"
+
+	"github.com/guohuiyuan/music-lib/audio"
+	"github.com/guohuiyuan/music-lib/model"
 )
 
 var (
@@ -176,79 +181,218 @@ func EncryptEApi(urlPath string, payload string) string {
 	return hex.EncodeToString(encrypted)
 }
 
+// NCMMeta 是 NCM 文件中内嵌的歌曲信息
+type NCMMeta struct {
+	MusicID   string   `json:"music_id"`
+	MusicName string   `json:"music_name"`
+	Artists   []string `json:"artists"`
+	Album     string   `json:"album"`
+	AlbumID   string   `json:"album_id"`
+	AlbumPic  string   `json:"album_pic"`
+	Bitrate   int      `json:"bitrate"`  // bps
+	Duration  int      `json:"duration"` // 毫秒
+	Format    string   `json:"format"`
+}
+
+// Song 把内嵌信息转换为网易云的歌曲，ID 可直接用于 GetLyrics 等接口
+func (m *NCMMeta) Song() *model.Song {
+	s := &model.Song{
+		Source:   "netease",
+		ID:       m.MusicID,
+		Name:     m.MusicName,
+		Artist:   strings.Join(m.Artists, "、"),
+		Album:    m.Album,
+		AlbumID:  m.AlbumID,
+		Duration: m.Duration / 1000,
+		Bitrate:  m.Bitrate / 1000,
+		Ext:      m.Format,
+		Cover:    m.AlbumPic,
+	}
+	if m.MusicID != "" {
+		s.Link = "https://music.163.com/#/song?id=" + m.MusicID
+		s.Extra = map[string]string{"song_id": m.MusicID}
+	}
+	return s
+}
+
+// NCMFile 是解密后的 NCM 文件
+type NCMFile struct {
+	Audio []byte
+	Ext   string
+	// Meta 内嵌的歌曲信息，文件中没有或无法解析时为 nil
+	Meta *NCMMeta
+	// Cover 内嵌的专辑封面 (通常为 JPEG)，可能为空
+	Cover []byte
+}
+
+// DecryptNCM 解密 NCM 文件，返回音频数据和扩展名
 func DecryptNCM(encrypted []byte) ([]byte, string, error) {
-	if len(encrypted) < 16 || string(encrypted[:8]) != "CTENFDAM" {
-		return nil, "", errors.New("invalid ncm file")
+	f, err := DecryptNCMFile(encrypted)
+	if err != nil {
+		return nil, "", err
 	}
+	return f.Audio, f.Ext, nil
+}
 
-	offset := 8
-	if offset+2 > len(encrypted) {
-		return nil, "", errors.New("invalid ncm header")
+// DecryptNCMFile 与 DecryptNCM 相同，但同时返回内嵌的歌曲信息和封面
+func DecryptNCMFile(encrypted []byte) (*NCMFile, error) {
+	r, err := NewNCMReader(bytes.NewReader(encrypted))
+	if err != nil {
+		return nil, err
 	}
-	offset += 2
+	audio, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return &NCMFile{Audio: audio, Ext: r.Ext, Meta: r.Meta, Cover: r.Cover}, nil
+}
 
-	keyLen, next, ok := readU32LE(encrypted, offset)
-	if !ok || next+int(keyLen) > len(encrypted) {
-		return nil, "", errors.New("invalid ncm key length")
+// NCMReader 边读边解密 NCM 音频，只需要在内存中保留文件头
+type NCMReader struct {
+	r   *bufio.Reader
+	key [256]byte
+	pos int
+
+	// Ext 音频格式，优先取内嵌信息中的 format，没有时按解密后的文件头识别
+	Ext   string
+	Meta  *NCMMeta
+	Cover []byte
+}
+
+// NewNCMReader 读取并解析 NCM 文件头，之后从返回值中读到的都是解密后的音频数据
+func NewNCMReader(r io.Reader) (*NCMReader, error) {
+	br := bufio.NewReader(r)
+	head := make([]byte, 10)
+	if _, err := io.ReadFull(br, head); err != nil || string(head[:8]) != "CTENFDAM" {
+		return nil, errors.New("invalid ncm file")
+	}
+
+	keyData, err := readNCMBlock(br)
+	if err != nil {
+		return nil, errors.New("invalid ncm key length")
 	}
-	keyData := append([]byte(nil), encrypted[next:next+int(keyLen)]...)
 	for i := range keyData {
 		keyData[i] ^= 0x64
 	}
-	offset = next + int(keyLen)
-
 	decryptedKey, err := aesECBDecrypt(ncmCoreKey, keyData)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 	decryptedKey = pkcs7Unpad(decryptedKey)
 	if len(decryptedKey) > 17 {
 		decryptedKey = decryptedKey[17:]
 	}
 	if len(decryptedKey) == 0 {
-		return nil, "", errors.New("invalid ncm key data")
+		return nil, errors.New("invalid ncm key data")
 	}
 
-	keyBox := buildNCMKeyBox(decryptedKey)
-
-	metaLen, next, ok := readU32LE(encrypted, offset)
-	if !ok || next+int(metaLen) > len(encrypted) {
-		return nil, "", errors.New("invalid ncm meta length")
+	metaData, err := readNCMBlock(br)
+	if err != nil {
+		return nil, errors.New("invalid ncm meta length")
 	}
-	metaData := append([]byte(nil), encrypted[next:next+int(metaLen)]...)
 	for i := range metaData {
 		metaData[i] ^= 0x63
 	}
-	offset = next + int(metaLen)
 
-	outExt := parseNCMFormat(metaData)
+	out := &NCMReader{r: br, Meta: parseNCMMeta(metaData)}
+	box := buildNCMKeyBox(decryptedKey)
+	for i := range out.key {
+		j := byte(i)
+		out.key[i] = box[(int(box[j])+int(box[(int(box[j])+int(j))&0xff]))&0xff]
+	}
+	if out.Meta != nil {
+		out.Ext = out.Meta.Format
+	}
 
-	if offset+9 > len(encrypted) {
-		return nil, "", errors.New("invalid ncm payload")
+	// 4 字节 CRC 和 5 字节保留字段
+	if _, err := br.Discard(9); err != nil {
+		return nil, errors.New("invalid ncm payload")
+	}
+	if out.Cover, err = readNCMCover(br); err != nil {
+		return nil, errors.New("invalid ncm image block")
 	}
-	offset += 9
 
-	imageSize, next, ok := readU32LE(encrypted, offset)
-	if !ok {
-		return nil, "", errors.New("invalid ncm image length")
+	if out.Ext == "" {
+		head, _ := br.Peek(12)
+		plain := append([]byte(nil), head...)
+		out.xor(plain, 0)
+		if out.Ext = audio.DetectExt(plain); out.Ext == "" {
+			out.Ext = "mp3"
+		}
 	}
-	offset = next + int(imageSize)
-	if offset > len(encrypted) {
-		return nil, "", errors.New("invalid ncm image block")
+	return out, nil
+}
+
+func (r *NCMReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.xor(p[:n], r.pos)
+	r.pos += n
+	return n, err
+}
+
+// xor 解密从音频数据第 pos 个字节开始的 data，密钥流以 256 字节为周期
+func (r *NCMReader) xor(data []byte, pos int) {
+	for i := range data {
+		data[i] ^= r.key[(pos+i+1)&0xff]
 	}
+}
 
-	audio := append([]byte(nil), encrypted[offset:]...)
-	for i := range audio {
-		j := byte((i + 1) & 0xff)
-		idx := (int(keyBox[j]) + int(keyBox[(int(keyBox[j])+int(j))&0xff])) & 0xff
-		audio[i] ^= keyBox[idx]
+// readNCMBlock 读取 "4 字节长度 + 数据" 格式的区块
+func readNCMBlock(r io.Reader) ([]byte, error) {
+	var n [4]byte
+	if _, err := io.ReadFull(r, n[:]); err != nil {
+		return nil, err
 	}
+	size := binary.LittleEndian.Uint32(n[:])
+	if size > ncmMaxBlock {
+		return nil, errors.New("ncm block too large")
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
 
-	if outExt == "" {
-		outExt = detectAudioExt(audio)
+// ncmMaxBlock 限制文件头中单个区块的大小，防止损坏的长度字段导致超大内存分配
+const ncmMaxBlock = 64 << 20
+
+// readNCMCover 读取封面区块。
+// 旧版格式为 [图片长度][图片]，新版在前面多了一个区块总长度：[区块长度][图片长度][图片][填充]，
+// 按图片文件头区分两种格式，都无法识别时按旧版处理。
+func readNCMCover(r *bufio.Reader) ([]byte, error) {
+	var n [4]byte
+	if _, err := io.ReadFull(r, n[:]); err != nil {
+		return nil, err
 	}
+	first := binary.LittleEndian.Uint32(n[:])
+	// 新版格式中图片长度不超过区块长度，这里同时限制了两种格式的分配大小
+	if first > ncmMaxBlock {
+		return nil, errors.New("ncm cover too large")
+	}
+	if next, _ := r.Peek(8); first > 0 && len(next) == 8 && !isImage(next) {
+		second := binary.LittleEndian.Uint32(next[:4])
+		if second <= first && (second == 0 || isImage(next[4:])) {
+			r.Discard(4)
+			cover := make([]byte, second)
+			if _, err := io.ReadFull(r, cover); err != nil {
+				return nil, err
+			}
+			if _, err := r.Discard(int(first - second)); err != nil {
+				return nil, err
+			}
+			return cover, nil
+		}
+	}
+	cover := make([]byte, first)
+	if _, err := io.ReadFull(r, cover); err != nil {
+		return nil, err
+	}
+	return cover, nil
+}
 
-	return audio, outExt, nil
+func isImage(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}) || bytes.HasPrefix(data, []byte("\x89PNG"))
 }
 
 func buildNCMKeyBox(key []byte) [256]byte {
@@ -274,33 +418,84 @@ func buildNCMKeyBox(key []byte) [256]byte {
 	return box
 }
 
-func parseNCMFormat(metaData []byte) string {
+// parseNCMMeta 解密内嵌的 JSON 信息，格式为 "163 key(Don't modify):" + base64(AES("music:" + JSON))。
+// 电台节目以 "dj:" 开头，歌曲信息位于 mainMusic 中。
+func parseNCMMeta(metaData []byte) *NCMMeta {
 	if len(metaData) <= 22 {
-		return ""
+		return nil
 	}
 
 	decoded, err := base64.StdEncoding.DecodeString(string(metaData[22:]))
 	if err != nil {
-		return ""
+		return nil
 	}
 
 	decrypted, err := aesECBDecrypt(ncmMetaKey, decoded)
 	if err != nil {
-		return ""
+		return nil
 	}
 	decrypted = pkcs7Unpad(decrypted)
 
-	if bytes.HasPrefix(decrypted, []byte("music:")) {
-		decrypted = decrypted[len("music:"):]
+	var payload ncmMetaJSON
+	switch {
+	case bytes.HasPrefix(decrypted, []byte("music:")):
+		if err := json.Unmarshal(decrypted[len("music:"):], &payload); err != nil {
+			return nil
+		}
+	case bytes.HasPrefix(decrypted, []byte("dj:")):
+		var dj struct {
+			MainMusic ncmMetaJSON `json:"mainMusic"`
+		}
+		if err := json.Unmarshal(decrypted[len("dj:"):], &dj); err != nil {
+			return nil
+		}
+		payload = dj.MainMusic
+	default:
+		if err := json.Unmarshal(decrypted, &payload); err != nil {
+			return nil
+		}
 	}
 
-	var payload struct {
-		Format string `json:"format"`
+	meta := &NCMMeta{
+		MusicID:   jsonID(payload.MusicID),
+		MusicName: payload.MusicName,
+		Album:     payload.Album,
+		AlbumID:   jsonID(payload.AlbumID),
+		AlbumPic:  payload.AlbumPic,
+		Bitrate:   payload.Bitrate,
+		Duration:  payload.Duration,
+		Format:    payload.Format,
+	}
+	// artist 形如 [["周杰伦", 6452], ...]
+	for _, ar := range payload.Artist {
+		if len(ar) > 0 {
+			if name, ok := ar[0].(string); ok && name != "" {
+				meta.Artists = append(meta.Artists, name)
+			}
+		}
 	}
-	if err := json.Unmarshal(decrypted, &payload); err != nil {
+	return meta
+}
+
+type ncmMetaJSON struct {
+	MusicID   json.RawMessage `json:"musicId"`
+	MusicName string          `json:"musicName"`
+	Artist    [][]interface{} `json:"artist"`
+	AlbumID   json.RawMessage `json:"albumId"`
+	Album     string          `json:"album"`
+	AlbumPic  string          `json:"albumPic"`
+	Bitrate   int             `json:"bitrate"`
+	Duration  int             `json:"duration"`
+	Format    string          `json:"format"`
+}
+
+// jsonID 兼容数字和字符串两种写法的 ID
+func jsonID(raw json.RawMessage) string {
+	id := strings.Trim(strings.TrimSpace(string(raw)), `"`)
+	if id == "null" || id == "0" {
 		return ""
 	}
-	return payload.Format
+	return id
 }
 
 func aesECBDecrypt(key, data []byte) ([]byte, error) {
@@ -334,26 +529,3 @@ func pkcs7Unpad(data []byte) []byte {
 	}
 	return data[:len(data)-pad]
 }
-
-func readU32LE(data []byte, offset int) (uint32, int, bool) {
-	if offset+4 > len(data) {
-		return 0, offset, false
-	}
-	return binary.LittleEndian.Uint32(data[offset : offset+4]), offset + 4, true
-}
-
-func detectAudioExt(data []byte) string {
-	if len(data) >= 4 && bytes.Equal(data[:4], []byte{'f', 'L', 'a', 'C'}) {
-		return "flac"
-	}
-	if len(data) >= 3 && bytes.Equal(data[:3], []byte{'I', 'D', '3'}) {
-		return "mp3"
-	}
-	if len(data) >= 4 && bytes.Equal(data[:4], []byte{'O', 'g', 'g', 'S'}) {
-		return "ogg"
-	}
-	if len(data) >= 8 && bytes.Equal(data[4:8], []byte{'f', 't', 'y', 'p'}) {
-		return "m4a"
-	}
-	return "mp3"
-}
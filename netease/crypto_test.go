@@ -0,0 +1,125 @@
+package netease
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// buildNCM 按 NCM 格式加密音频，newCover 为 true 时使用带区块长度的新版封面布局
+func buildNCM(audio []byte, metaJSON string, cover []byte, newCover bool) []byte {
+	u32 := func(n int) []byte {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(n))
+		return b
+	}
+	rc4Key := []byte("0123456789abcdef")
+
+	key := aesEncryptECB(append([]byte("neteasecloudmusic"), rc4Key...), ncmCoreKey)
+	for i := range key {
+		key[i] ^= 0x64
+	}
+	meta := []byte("163 key(Don't modify):" + base64.StdEncoding.EncodeToString(aesEncryptECB([]byte(metaJSON), ncmMetaKey)))
+	for i := range meta {
+		meta[i] ^= 0x63
+	}
+
+	box := buildNCMKeyBox(rc4Key)
+	encAudio := append([]byte(nil), audio...)
+	for i := range encAudio {
+		j := byte((i + 1) & 0xff)
+		encAudio[i] ^= box[(int(box[j])+int(box[(int(box[j])+int(j))&0xff]))&0xff]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CTENFDAM")
+	buf.Write([]byte{0, 0})
+	buf.Write(u32(len(key)))
+	buf.Write(key)
+	buf.Write(u32(len(meta)))
+	buf.Write(meta)
+	buf.Write(make([]byte, 9))
+	if newCover {
+		buf.Write(u32(len(cover) + 16))
+		buf.Write(u32(len(cover)))
+		buf.Write(cover)
+		buf.Write(make([]byte, 16))
+	} else {
+		buf.Write(u32(len(cover)))
+		buf.Write(cover)
+	}
+	buf.Write(encAudio)
+	return buf.Bytes()
+}
+
+func TestDecryptNCMFile(t *testing.T) {
+	audio := append([]byte("fLaC\x00\x00\x00\x22"), bytes.Repeat([]byte{1, 2, 3}, 200)...)
+	cover := []byte{0xFF, 0xD8, 0xFF, 0xE0, 9, 9, 9}
+	metaJSON := `music:{"musicId":186001,"musicName":"晴天","artist":[["周杰伦",6452],["Jay",1]],"albumId":18905,"album":"叶惠美","albumPic":"http://p3.music.126.net/x.jpg","bitrate":999000,"duration":269000,"format":"flac"}`
+
+	for _, newCover := range []bool{false, true} {
+		f, err := DecryptNCMFile(buildNCM(audio, metaJSON, cover, newCover))
+		if err != nil {
+			t.Fatalf("DecryptNCMFile(newCover=%v): %v", newCover, err)
+		}
+		if !bytes.Equal(f.Audio, audio) || f.Ext != "flac" {
+			t.Fatalf("audio mismatch (newCover=%v), ext %q", newCover, f.Ext)
+		}
+		if !bytes.Equal(f.Cover, cover) {
+			t.Fatalf("cover = %x (newCover=%v)", f.Cover, newCover)
+		}
+		if f.Meta == nil || f.Meta.MusicID != "186001" || f.Meta.AlbumID != "18905" || len(f.Meta.Artists) != 2 {
+			t.Fatalf("meta = %+v", f.Meta)
+		}
+	}
+
+	f, _ := DecryptNCMFile(buildNCM(audio, metaJSON, cover, false))
+	s := f.Meta.Song()
+	if s.Source != "netease" || s.ID != "186001" || s.Artist != "周杰伦、Jay" || s.Duration != 269 || s.Bitrate != 999 {
+		t.Fatalf("song = %+v", s)
+	}
+}
+
+func TestDecryptNCMWithoutCover(t *testing.T) {
+	audio := []byte("ID3\x04\x00\x00\x00\x00\x00\x00 mp3 frames")
+	f, err := DecryptNCMFile(buildNCM(audio, `dj:{"mainMusic":{"musicId":"42","musicName":"电台节目"}}`, nil, false))
+	if err != nil {
+		t.Fatalf("DecryptNCMFile: %v", err)
+	}
+	if !bytes.Equal(f.Audio, audio) || f.Ext != "mp3" || len(f.Cover) != 0 {
+		t.Fatalf("audio/ext/cover = %q/%q/%x", f.Audio, f.Ext, f.Cover)
+	}
+	if f.Meta == nil || f.Meta.MusicID != "42" || f.Meta.MusicName != "电台节目" {
+		t.Fatalf("meta = %+v", f.Meta)
+	}
+}
+
+func TestNCMReaderStreams(t *testing.T) {
+	audio := bytes.Repeat([]byte("OggS stream data "), 100)
+	r, err := NewNCMReader(iotest.OneByteReader(bytes.NewReader(buildNCM(audio, `music:{"musicId":1}`, nil, true))))
+	if err != nil {
+		t.Fatalf("NewNCMReader: %v", err)
+	}
+	if r.Ext != "ogg" {
+		t.Errorf("ext = %q, want ogg", r.Ext)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(got, audio) {
+		t.Fatalf("stream mismatch: %v", err)
+	}
+}
+
+func TestReadNCMCoverRejectsHugeBlock(t *testing.T) {
+	// 新版格式：区块长度和图片长度都接近 4 GiB，后面跟着 PNG 文件头
+	head := []byte{0xF0, 0xFF, 0xFF, 0xFF, 0x00, 0xFF, 0xFF, 0xFF}
+	head = append(head, "\x89PNG"...)
+	_, err := readNCMCover(bufio.NewReader(bytes.NewReader(head)))
+	if err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Fatalf("expected cover too large error, got %v", err)
+	}
+}
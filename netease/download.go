@@ -1,8 +1,8 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -12,10 +12,19 @@ import (
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultNetease.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultNetease.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL returns a download URL.
 func (n *Netease) GetDownloadURL(s *model.Song) (string, error) {
+	return n.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext is like GetDownloadURL but carries ctx through its requests.
+func (n *Netease) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "netease" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	songID := s.ID
@@ -26,7 +35,7 @@ func (n *Netease) GetDownloadURL(s *model.Song) (string, error) {
 	levels := preferredDownloadLevels(s)
 
 	if n.cookie != "" {
-		isVip, _ := n.IsVipAccount()
+		isVip, _ := n.IsVipAccountContext(ctx)
 		if isVip {
 			if cached, ok := n.getCachedDownloadURL(songID, strings.Join(levels, ",")); ok {
 				if cached.ext != "" {
@@ -36,7 +45,7 @@ func (n *Netease) GetDownloadURL(s *model.Song) (string, error) {
 			}
 
 			for _, level := range levels {
-				if url, ext, err := n.getEAPIDownloadURL(songID, level); err == nil && url != "" {
+				if url, ext, err := n.getEAPIDownloadURL(ctx, songID, level); err == nil && url != "" {
 					s.Ext = ext
 					n.setCachedDownloadURL(songID, strings.Join(levels, ","), url, s.Ext)
 					return url, nil
@@ -63,7 +72,7 @@ func (n *Netease) GetDownloadURL(s *model.Song) (string, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post(DownloadAPI, strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, DownloadAPI, strings.NewReader(form.Encode()), headers...)
 	if err != nil {
 		return "", err
 	}
@@ -78,8 +87,11 @@ func (n *Netease) GetDownloadURL(s *model.Song) (string, error) {
 	if err := json.Unmarshal(body, &resp); err != nil {
 		return "", fmt.Errorf("json parse error: %w", err)
 	}
-	if len(resp.Data) == 0 || resp.Data[0].URL == "" {
-		return "", errors.New("download url not found (might be vip or copyright restricted)")
+	if len(resp.Data) == 0 {
+		return "", model.Errorf(model.ErrNotFound, "download url not found (might be vip or copyright restricted)")
+	}
+	if resp.Data[0].URL == "" {
+		return "", urlError(resp.Data[0].Code, "download url not found (might be vip or copyright restricted)")
 	}
 	n.setCachedDownloadURL(songID, strings.Join(levels, ","), resp.Data[0].URL, s.Ext)
 	return resp.Data[0].URL, nil
@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,9 +22,22 @@ const (
 
 func CreateQRLogin() (*model.QRLoginSession, error) { return defaultNetease.CreateQRLogin() }
 
+func CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
+	return defaultNetease.CreateQRLoginContext(ctx)
+}
+
 func CheckQRLogin(key string) (*model.QRLoginResult, error) { return defaultNetease.CheckQRLogin(key) }
 
+func CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
+	return defaultNetease.CheckQRLoginContext(ctx, key)
+}
+
 func (n *Netease) CreateQRLogin() (*model.QRLoginSession, error) {
+	return n.CreateQRLoginContext(context.Background())
+}
+
+// CreateQRLoginContext is like CreateQRLogin but carries ctx through its requests.
+func (n *Netease) CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
 	reqData := map[string]interface{}{"type": 3}
 	reqJSON, _ := json.Marshal(reqData)
 
@@ -32,7 +46,7 @@ func (n *Netease) CreateQRLogin() (*model.QRLoginSession, error) {
 		form.Set(k, v)
 	}
 
-	body, _, err := n.postQRLogin(neteaseQRKeyAPI, form)
+	body, _, err := n.postQRLogin(ctx, neteaseQRKeyAPI, form)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +59,7 @@ func (n *Netease) CreateQRLogin() (*model.QRLoginSession, error) {
 		return nil, fmt.Errorf("netease qr key json parse error: %w", err)
 	}
 	if resp.Code != 200 || strings.TrimSpace(resp.UniKey) == "" {
-		return nil, fmt.Errorf("netease qr key api error: code=%d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "netease qr key api error: code=%d", resp.Code)
 	}
 
 	loginURL := "https://music.163.com/login?codekey=" + url.QueryEscape(resp.UniKey)
@@ -58,6 +72,11 @@ func (n *Netease) CreateQRLogin() (*model.QRLoginSession, error) {
 }
 
 func (n *Netease) CheckQRLogin(key string) (*model.QRLoginResult, error) {
+	return n.CheckQRLoginContext(context.Background(), key)
+}
+
+// CheckQRLoginContext is like CheckQRLogin but carries ctx through its requests.
+func (n *Netease) CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
 	key = strings.TrimSpace(key)
 	if key == "" {
 		return nil, fmt.Errorf("netease qr login key is empty")
@@ -71,7 +90,7 @@ func (n *Netease) CheckQRLogin(key string) (*model.QRLoginResult, error) {
 		form.Set(k, v)
 	}
 
-	body, cookies, err := n.postQRLogin(neteaseQRCheckAPI, form)
+	body, cookies, err := n.postQRLogin(ctx, neteaseQRCheckAPI, form)
 	if err != nil {
 		return nil, err
 	}
@@ -107,8 +126,8 @@ func (n *Netease) CheckQRLogin(key string) (*model.QRLoginResult, error) {
 	return result, nil
 }
 
-func (n *Netease) postQRLogin(apiURL string, form url.Values) ([]byte, map[string]string, error) {
-	req, err := http.NewRequest("POST", apiURL, strings.NewReader(form.Encode()))
+func (n *Netease) postQRLogin(ctx context.Context, apiURL string, form url.Values) ([]byte, map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -117,7 +136,7 @@ func (n *Netease) postQRLogin(apiURL string, form url.Values) ([]byte, map[strin
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	utils.WithRandomIPHeader()(req)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := n.client.Do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1,8 +1,8 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/lyrics"
 	"github.com/guohuiyuan/music-lib/model"
@@ -13,10 +13,39 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultNetease.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultNetease.GetLyricsContext(ctx, s)
+}
+
+func GetLyricsData(s *model.Song) (*lyrics.Lyrics, error) { return defaultNetease.GetLyricsData(s) }
+
+func GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error) {
+	return defaultNetease.GetLyricsDataContext(ctx, s)
+}
+
 // GetLyrics fetches lyrics.
 func (n *Netease) GetLyrics(s *model.Song) (string, error) {
+	return n.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (n *Netease) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	l, err := n.GetLyricsDataContext(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	return l.LRC(), nil
+}
+
+// GetLyricsData 获取结构化歌词，保留逐字时间轴以及翻译、音译轨道
+func (n *Netease) GetLyricsData(s *model.Song) (*lyrics.Lyrics, error) {
+	return n.GetLyricsDataContext(context.Background(), s)
+}
+
+// GetLyricsDataContext is like GetLyricsData but carries ctx through its requests.
+func (n *Netease) GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error) {
 	if s.Source != "netease" {
-		return "", errors.New("source mismatch")
+		return nil, model.ErrSourceMismatch
 	}
 
 	songID := s.ID
@@ -46,9 +75,9 @@ func (n *Netease) GetLyrics(s *model.Song) (string, error) {
 	}
 
 	lyricAPI := "https://music.163.com/weapi/song/lyric"
-	body, err := utils.Post(lyricAPI, strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, lyricAPI, strings.NewReader(form.Encode()), headers...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var resp struct {
@@ -67,10 +96,10 @@ func (n *Netease) GetLyrics(s *model.Song) (string, error) {
 		} `json:"romalrc"`
 	}
 	if err := json.Unmarshal(body, &resp); err != nil {
-		return "", fmt.Errorf("json parse error: %w", err)
+		return nil, fmt.Errorf("json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return "", fmt.Errorf("netease api error code: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "netease api error code: %d", resp.Code)
 	}
 	tags := map[string]string{
 		"ti": s.Name,
@@ -96,7 +125,7 @@ func (n *Netease) GetLyrics(s *model.Song) (string, error) {
 		_, data["roma"] = lyrics.ParseLRC(resp.RomaLrc.Lyric)
 	}
 	if len(data["orig"]) == 0 {
-		return "", errors.New("lyric is empty or not found")
+		return nil, model.Errorf(model.ErrNotFound, "lyric is empty or not found")
 	}
-	return lyrics.ConvertVerbatimLRC(tags, data, lyrics.DefaultDisplayOrder()), nil
+	return lyrics.New(tags, data), nil
 }
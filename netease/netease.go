@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +33,7 @@ const (
 type Netease struct {
 	cookie     string
 	isVipCache *bool
+	client     *utils.Client
 }
 
 type neteaseLinkKind string
@@ -49,9 +51,34 @@ var (
 	errNeteaseAlbumLink        = errors.New("netease album link detected, use ParseAlbum")
 	errNeteaseInvalidAlbumLink = errors.New("invalid netease album link")
 	errNeteaseInvalidListLink  = errors.New("invalid netease playlist link")
-	errNeteaseSongNotFound     = errors.New("netease song not found")
+	errNeteaseSongNotFound     = model.Errorf(model.ErrNotFound, "netease song not found")
 )
 
+// apiErrorKinds 网易云接口 code 字段对应的错误分类
+var apiErrorKinds = map[int]error{
+	301:  model.ErrAuthExpired, // 需要登录
+	404:  model.ErrNotFound,
+	405:  model.ErrRateLimited, // 操作频繁
+	-447: model.ErrRateLimited, // 服务器忙碌
+	-460: model.ErrRateLimited, // 网络拥挤，触发风控
+}
+
+// urlErrorKinds 获取播放地址时 data[].code 对应的错误分类
+var urlErrorKinds = map[int]error{
+	404:  model.ErrRegionBlocked, // 无版权，海外 IP 也会返回 404
+	-110: model.ErrVIPRequired,   // 需要付费
+	-10:  model.ErrVIPRequired,
+}
+
+// urlError 根据 data[].code 归类拿不到播放地址的原因，无法判断时按需要会员处理
+func urlError(code int, format string, args ...interface{}) error {
+	kind, ok := urlErrorKinds[code]
+	if !ok {
+		kind = model.ErrVIPRequired
+	}
+	return model.Errorf(kind, format, args...)
+}
+
 type cachedDownloadURL struct {
 	url       string
 	ext       string
@@ -76,7 +103,9 @@ var vipStatusCache = struct {
 const downloadURLCacheTTL = 10 * time.Minute
 const vipStatusCacheTTL = 10 * time.Minute
 
-func New(cookie string) *Netease { return &Netease{cookie: cookie} }
+func New(cookie string, opts ...utils.ClientOption) *Netease {
+	return &Netease{cookie: cookie, client: utils.NewClient(opts...)}
+}
 
 var defaultNetease = New("")
 
@@ -114,7 +143,7 @@ func (n *Netease) setCachedVIPStatus(isVip bool) {
 }
 
 // cloudSearch calls the shared Netease cloud search route.
-func (n *Netease) cloudSearch(keyword string, searchType int, limit int) ([]byte, error) {
+func (n *Netease) cloudSearch(ctx context.Context, keyword string, searchType int, limit int) ([]byte, error) {
 	eparams := map[string]interface{}{
 		"method": "POST",
 		"url":    "http://music.163.com/api/cloudsearch/pc",
@@ -137,7 +166,7 @@ func (n *Netease) cloudSearch(keyword string, searchType int, limit int) ([]byte
 		utils.WithRandomIPHeader(),
 	}
 
-	return utils.Post(SearchAPI, strings.NewReader(form.Encode()), headers...)
+	return n.client.PostContext(ctx, SearchAPI, strings.NewReader(form.Encode()), headers...)
 }
 
 // joinArtistNames joins artist names for display.
@@ -146,7 +175,7 @@ func joinArtistNames(names []string) string {
 }
 
 // fetchAlbumDetail returns album metadata and songs.
-func (n *Netease) fetchAlbumDetail(albumID string) (*model.Playlist, []model.Song, error) {
+func (n *Netease) fetchAlbumDetail(ctx context.Context, albumID string) (*model.Playlist, []model.Song, error) {
 	reqData := map[string]interface{}{
 		"csrf_token": "",
 	}
@@ -163,7 +192,7 @@ func (n *Netease) fetchAlbumDetail(albumID string) (*model.Playlist, []model.Son
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post(fmt.Sprintf(AlbumAPI, albumID), strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, fmt.Sprintf(AlbumAPI, albumID), strings.NewReader(form.Encode()), headers...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -217,7 +246,7 @@ func (n *Netease) fetchAlbumDetail(albumID string) (*model.Playlist, []model.Son
 		return nil, nil, fmt.Errorf("netease album detail json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return nil, nil, fmt.Errorf("netease api error code: %d", resp.Code)
+		return nil, nil, model.CodeError(apiErrorKinds, resp.Code, "netease api error code: %d", resp.Code)
 	}
 
 	artistName := resp.Album.Artist.Name
@@ -298,7 +327,7 @@ func (n *Netease) fetchAlbumDetail(albumID string) (*model.Playlist, []model.Son
 	return album, songs, nil
 }
 
-func (n *Netease) fetchPlaylistDetail(playlistID string) (*model.Playlist, []model.Song, error) {
+func (n *Netease) fetchPlaylistDetail(ctx context.Context, playlistID string) (*model.Playlist, []model.Song, error) {
 	reqData := map[string]interface{}{
 		"id":         playlistID,
 		"n":          0, // 0表示不直接返回详情，我们只需要ID列表
@@ -317,7 +346,7 @@ func (n *Netease) fetchPlaylistDetail(playlistID string) (*model.Playlist, []mod
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post(PlaylistAPI, strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, PlaylistAPI, strings.NewReader(form.Encode()), headers...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -345,7 +374,7 @@ func (n *Netease) fetchPlaylistDetail(playlistID string) (*model.Playlist, []mod
 		return nil, nil, fmt.Errorf("netease playlist detail json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return nil, nil, fmt.Errorf("netease api error code: %d", resp.Code)
+		return nil, nil, model.CodeError(apiErrorKinds, resp.Code, "netease api error code: %d", resp.Code)
 	}
 
 	// Build playlist metadata.
@@ -377,7 +406,7 @@ func (n *Netease) fetchPlaylistDetail(playlistID string) (*model.Playlist, []mod
 		}
 
 		batchIDs := allIDs[i:end]
-		batchSongs, err := n.fetchSongsBatch(batchIDs)
+		batchSongs, err := n.fetchSongsBatch(ctx, batchIDs)
 		if err == nil {
 			allSongs = append(allSongs, batchSongs...)
 		}
@@ -387,7 +416,7 @@ func (n *Netease) fetchPlaylistDetail(playlistID string) (*model.Playlist, []mod
 }
 
 // fetchSongsBatch fetches song details in batches.
-func (n *Netease) fetchSongsBatch(songIDs []string) ([]model.Song, error) {
+func (n *Netease) fetchSongsBatch(ctx context.Context, songIDs []string) ([]model.Song, error) {
 	if len(songIDs) == 0 {
 		return nil, nil
 	}
@@ -418,7 +447,7 @@ func (n *Netease) fetchSongsBatch(songIDs []string) ([]model.Song, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post(DetailAPI, strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, DetailAPI, strings.NewReader(form.Encode()), headers...)
 	if err != nil {
 		return nil, err
 	}
@@ -606,7 +635,7 @@ func preferredDownloadLevels(s *model.Song) []string {
 }
 
 // getEAPIDownloadURL fetches a high-quality download URL via eapi.
-func (n *Netease) getEAPIDownloadURL(songID string, quality string) (string, string, error) {
+func (n *Netease) getEAPIDownloadURL(ctx context.Context, songID string, quality string) (string, string, error) {
 	idNum, err := strconv.Atoi(songID)
 	if err != nil {
 		return "", "", fmt.Errorf("invalid song id: %v", err)
@@ -634,7 +663,7 @@ func (n *Netease) getEAPIDownloadURL(songID string, quality string) (string, str
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post(DownloadEAPI, strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, DownloadEAPI, strings.NewReader(form.Encode()), headers...)
 	if err != nil {
 		return "", "", err
 	}
@@ -650,8 +679,11 @@ func (n *Netease) getEAPIDownloadURL(songID string, quality string) (string, str
 	if err := json.Unmarshal(body, &resp); err != nil {
 		return "", "", fmt.Errorf("eapi json parse error: %w", err)
 	}
-	if len(resp.Data) == 0 || resp.Data[0].URL == "" {
-		return "", "", errors.New("eapi download url not found")
+	if len(resp.Data) == 0 {
+		return "", "", model.Errorf(model.ErrNotFound, "eapi download url not found")
+	}
+	if resp.Data[0].URL == "" {
+		return "", "", urlError(resp.Data[0].Code, "eapi download url not found")
 	}
 	return resp.Data[0].URL, normalizeNeteaseAudioType(resp.Data[0].Type, quality), nil
 }
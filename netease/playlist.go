@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -15,34 +16,67 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultNetease.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultNetease.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(playlistID string) ([]model.Song, error) {
 	return defaultNetease.GetPlaylistSongs(playlistID)
 }
 
+func GetPlaylistSongsContext(ctx context.Context, playlistID string) ([]model.Song, error) {
+	return defaultNetease.GetPlaylistSongsContext(ctx, playlistID)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultNetease.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultNetease.ParsePlaylistContext(ctx, link)
+}
+
 // GetRecommendedPlaylists returns recommended playlists without login.
 func GetRecommendedPlaylists() ([]model.Playlist, error) {
 	return defaultNetease.GetRecommendedPlaylists()
 }
 
+func GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
+	return defaultNetease.GetRecommendedPlaylistsContext(ctx)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultNetease.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultNetease.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultNetease.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultNetease.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultNetease.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultNetease.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 // SearchPlaylist searches playlists.
 func (n *Netease) SearchPlaylist(keyword string) ([]model.Playlist, error) {
-	body, err := n.cloudSearch(keyword, 1000, 10)
+	return n.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext is like SearchPlaylist but carries ctx through its requests.
+func (n *Netease) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	body, err := n.cloudSearch(ctx, keyword, 1000, 10)
 	if err != nil {
 		return nil, err
 	}
@@ -86,21 +120,36 @@ func (n *Netease) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 // GetPlaylistSongs returns songs in a playlist.
 func (n *Netease) GetPlaylistSongs(playlistID string) ([]model.Song, error) {
-	_, songs, err := n.fetchPlaylistDetail(playlistID)
+	return n.GetPlaylistSongsContext(context.Background(), playlistID)
+}
+
+// GetPlaylistSongsContext is like GetPlaylistSongs but carries ctx through its requests.
+func (n *Netease) GetPlaylistSongsContext(ctx context.Context, playlistID string) ([]model.Song, error) {
+	_, songs, err := n.fetchPlaylistDetail(ctx, playlistID)
 	return songs, err
 }
 
 // ParsePlaylist parses a playlist link.
 func (n *Netease) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return n.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext is like ParsePlaylist but carries ctx through its requests.
+func (n *Netease) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	kind, playlistID, err := parseNeteaseLink(link)
 	if err != nil || kind != neteaseLinkPlaylist {
 		return nil, nil, errNeteaseInvalidListLink
 	}
-	return n.fetchPlaylistDetail(playlistID)
+	return n.fetchPlaylistDetail(ctx, playlistID)
 }
 
 // GetRecommendedPlaylists returns homepage recommended playlists.
 func (n *Netease) GetRecommendedPlaylists() ([]model.Playlist, error) {
+	return n.GetRecommendedPlaylistsContext(context.Background())
+}
+
+// GetRecommendedPlaylistsContext is like GetRecommendedPlaylists but carries ctx through its requests.
+func (n *Netease) GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
 	reqData := map[string]interface{}{
 		"limit": 30,
 		"total": true,
@@ -118,7 +167,7 @@ func (n *Netease) GetRecommendedPlaylists() ([]model.Playlist, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post(RecommendedPlaylistAPI, strings.NewReader(form.Encode()), headers...)
+	body, err := n.client.PostContext(ctx, RecommendedPlaylistAPI, strings.NewReader(form.Encode()), headers...)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +188,7 @@ func (n *Netease) GetRecommendedPlaylists() ([]model.Playlist, error) {
 		return nil, fmt.Errorf("netease recommended playlist json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return nil, fmt.Errorf("netease api error code: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "netease api error code: %d", resp.Code)
 	}
 
 	var playlists []model.Playlist
@@ -173,6 +222,11 @@ func (n *Netease) GetRecommendedPlaylists() ([]model.Playlist, error) {
 }
 
 func (n *Netease) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return n.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext is like GetPlaylistCategories but carries ctx through its requests.
+func (n *Netease) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	reqData := map[string]interface{}{
 		"csrf_token": "",
 	}
@@ -182,7 +236,7 @@ func (n *Netease) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	form.Set("params", params)
 	form.Set("encSecKey", encSecKey)
 
-	body, err := utils.Post(PlaylistCategoryAPI, strings.NewReader(form.Encode()),
+	body, err := n.client.PostContext(ctx, PlaylistCategoryAPI, strings.NewReader(form.Encode()),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Content-Type", "application/x-www-form-urlencoded"),
 		utils.WithRandomIPHeader(),
@@ -210,7 +264,7 @@ func (n *Netease) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 		return nil, fmt.Errorf("netease playlist category json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return nil, fmt.Errorf("netease api error code: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "netease api error code: %d", resp.Code)
 	}
 
 	categories := make([]model.PlaylistCategory, 0, len(resp.Sub)+1)
@@ -244,6 +298,11 @@ func (n *Netease) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 }
 
 func (n *Netease) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return n.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext is like GetCategoryPlaylists but carries ctx through its requests.
+func (n *Netease) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	categoryID = strings.TrimSpace(categoryID)
 	if categoryID == "" {
 		categoryID = "全部"
@@ -272,7 +331,7 @@ func (n *Netease) GetCategoryPlaylists(categoryID string, page, limit int) ([]mo
 	form.Set("params", params)
 	form.Set("encSecKey", encSecKey)
 
-	body, err := utils.Post(CategoryPlaylistAPI, strings.NewReader(form.Encode()),
+	body, err := n.client.PostContext(ctx, CategoryPlaylistAPI, strings.NewReader(form.Encode()),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Content-Type", "application/x-www-form-urlencoded"),
 		utils.WithRandomIPHeader(),
@@ -299,7 +358,7 @@ func (n *Netease) GetCategoryPlaylists(categoryID string, page, limit int) ([]mo
 		return nil, fmt.Errorf("netease category playlist json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return nil, fmt.Errorf("netease api error code: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "netease api error code: %d", resp.Code)
 	}
 
 	playlists := make([]model.Playlist, 0, len(resp.Playlists))
@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
@@ -10,11 +11,24 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultNetease.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultNetease.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultNetease.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultNetease.ParseContext(ctx, link)
+}
+
 // Search searches songs.
 func (n *Netease) Search(keyword string) ([]model.Song, error) {
-	body, err := n.cloudSearch(keyword, 1, 10)
+	return n.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but carries ctx through its requests.
+func (n *Netease) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	body, err := n.cloudSearch(ctx, keyword, 1, 10)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +69,7 @@ func (n *Netease) Search(keyword string) ([]model.Song, error) {
 	}
 
 	var songs []model.Song
-	isVip, _ := n.IsVipAccount()
+	isVip, _ := n.IsVipAccountContext(ctx)
 
 	for _, item := range resp.Result.Songs {
 		// Skip unavailable songs for non-VIP accounts.
@@ -105,6 +119,11 @@ func (n *Netease) Search(keyword string) ([]model.Song, error) {
 
 // Parse parses a song link.
 func (n *Netease) Parse(link string) (*model.Song, error) {
+	return n.ParseContext(context.Background(), link)
+}
+
+// ParseContext is like Parse but carries ctx through its requests.
+func (n *Netease) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	kind, songID, err := parseNeteaseLink(link)
 	if err != nil {
 		return nil, errInvalidNeteaseLink
@@ -119,7 +138,7 @@ func (n *Netease) Parse(link string) (*model.Song, error) {
 		return nil, errInvalidNeteaseLink
 	}
 
-	songs, err := n.fetchSongsBatch([]string{songID})
+	songs, err := n.fetchSongsBatch(ctx, []string{songID})
 	if err != nil {
 		return nil, fmt.Errorf("fetch song detail failed: %w", err)
 	}
@@ -128,7 +147,7 @@ func (n *Netease) Parse(link string) (*model.Song, error) {
 	}
 	song := &songs[0]
 
-	downloadURL, err := n.GetDownloadURL(song)
+	downloadURL, err := n.GetDownloadURLContext(ctx, song)
 	if err == nil {
 		song.URL = downloadURL
 	}
@@ -1,6 +1,7 @@
 package netease
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -12,8 +13,13 @@ import (
 )
 
 func (n *Netease) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return n.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (n *Netease) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	if strings.TrimSpace(n.cookie) == "" {
-		return nil, fmt.Errorf("netease user playlists require cookie")
+		return nil, model.Errorf(model.ErrAuthExpired, "netease user playlists require cookie")
 	}
 	if page < 1 {
 		page = 1
@@ -33,7 +39,7 @@ func (n *Netease) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	accountForm := url.Values{}
 	accountForm.Set("params", accountParams)
 	accountForm.Set("encSecKey", accountEncSecKey)
-	accountBody, err := utils.Post(UserAccountAPI, strings.NewReader(accountForm.Encode()),
+	accountBody, err := n.client.PostContext(ctx, UserAccountAPI, strings.NewReader(accountForm.Encode()),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Content-Type", "application/x-www-form-urlencoded"),
 		utils.WithHeader("Cookie", n.cookie),
@@ -53,7 +59,7 @@ func (n *Netease) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 		return nil, fmt.Errorf("netease account json parse error: %w", err)
 	}
 	if accountResp.Code != 200 || accountResp.Profile.UserID == 0 {
-		return nil, fmt.Errorf("netease account api error code: %d", accountResp.Code)
+		return nil, model.CodeError(apiErrorKinds, accountResp.Code, "netease account api error code: %d", accountResp.Code)
 	}
 
 	reqData := map[string]interface{}{
@@ -68,7 +74,7 @@ func (n *Netease) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	form := url.Values{}
 	form.Set("params", params)
 	form.Set("encSecKey", encSecKey)
-	body, err := utils.Post(UserPlaylistAPI, strings.NewReader(form.Encode()),
+	body, err := n.client.PostContext(ctx, UserPlaylistAPI, strings.NewReader(form.Encode()),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Content-Type", "application/x-www-form-urlencoded"),
 		utils.WithHeader("Cookie", n.cookie),
@@ -97,7 +103,7 @@ func (n *Netease) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 		return nil, fmt.Errorf("netease user playlist json parse error: %w", err)
 	}
 	if resp.Code != 200 {
-		return nil, fmt.Errorf("netease user playlist api error code: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "netease user playlist api error code: %d", resp.Code)
 	}
 
 	playlists := make([]model.Playlist, 0, len(resp.Playlist))
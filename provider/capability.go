@@ -0,0 +1,47 @@
+package provider
+
+// Capability 标识平台支持的一类能力，与本包中的接口一一对应。
+// 所有平台都实现了 PlaylistCategoryProvider 等接口，但部分平台在运行时
+// 返回 model.ErrPlaylistCategoriesUnsupported，因此能力需要由平台显式声明。
+type Capability string
+
+const (
+	CapSearch              Capability = "search"               // SongSearcher
+	CapParse               Capability = "parse"                // SongParser
+	CapDownload            Capability = "download"             // SongDownloader
+	CapLyrics              Capability = "lyrics"               // LyricProvider
+	CapAlbum               Capability = "album"                // AlbumProvider
+	CapPlaylist            Capability = "playlist"             // PlaylistProvider
+	CapRecommendedPlaylist Capability = "recommended_playlist" // RecommendedPlaylistProvider
+	CapPlaylistCategory    Capability = "playlist_category"    // PlaylistCategoryProvider
+	CapUserPlaylist        Capability = "user_playlist"        // UserPlaylistProvider
+	CapQRLogin             Capability = "qr_login"             // QRLoginProvider
+)
+
+// Implements 判断 v 是否实现了能力对应的接口
+func Implements(v interface{}, c Capability) bool {
+	var ok bool
+	switch c {
+	case CapSearch:
+		_, ok = v.(SongSearcher)
+	case CapParse:
+		_, ok = v.(SongParser)
+	case CapDownload:
+		_, ok = v.(SongDownloader)
+	case CapLyrics:
+		_, ok = v.(LyricProvider)
+	case CapAlbum:
+		_, ok = v.(AlbumProvider)
+	case CapPlaylist:
+		_, ok = v.(PlaylistProvider)
+	case CapRecommendedPlaylist:
+		_, ok = v.(RecommendedPlaylistProvider)
+	case CapPlaylistCategory:
+		_, ok = v.(PlaylistCategoryProvider)
+	case CapUserPlaylist:
+		_, ok = v.(UserPlaylistProvider)
+	case CapQRLogin:
+		_, ok = v.(QRLoginProvider)
+	}
+	return ok
+}
@@ -0,0 +1,104 @@
+package provider
+
+// Feature 对应 README 支持矩阵中的一列
+type Feature string
+
+const (
+	FeatureSearch            Feature = "search"
+	FeatureDownload          Feature = "download"
+	FeatureLyrics            Feature = "lyrics"
+	FeatureSongParse         Feature = "song_parse"
+	FeaturePlaylistSearch    Feature = "playlist_search"
+	FeaturePlaylistRecommend Feature = "playlist_recommend"
+	FeaturePlaylistSongs     Feature = "playlist_songs"
+	FeaturePlaylistParse     Feature = "playlist_parse"
+	FeatureAlbumSearch       Feature = "album_search"
+	FeatureAlbumSongs        Feature = "album_songs"
+	FeatureAlbumParse        Feature = "album_parse"
+	FeatureUserPlaylist      Feature = "user_playlist"
+	FeatureQRLogin           Feature = "qr_login"
+	FeaturePlaylistCategory  Feature = "playlist_category"
+)
+
+// Features 按 README 表格的列顺序返回全部功能，便于 UI 渲染矩阵
+func Features() []Feature {
+	return []Feature{
+		FeatureSearch,
+		FeatureDownload,
+		FeatureLyrics,
+		FeatureSongParse,
+		FeaturePlaylistSearch,
+		FeaturePlaylistRecommend,
+		FeaturePlaylistSongs,
+		FeaturePlaylistParse,
+		FeatureAlbumSearch,
+		FeatureAlbumSongs,
+		FeatureAlbumParse,
+		FeatureUserPlaylist,
+		FeatureQRLogin,
+		FeaturePlaylistCategory,
+	}
+}
+
+// Support 表示某项功能的支持程度
+type Support string
+
+const (
+	Supported   Support = "supported"   // ✅
+	Unstable    Support = "unstable"    // ⚠️ 方法已接入，但结果不稳定或能力受限
+	Unsupported Support = "unsupported" // ❌
+)
+
+// FeatureSupport 是矩阵中的一个单元格
+type FeatureSupport struct {
+	Level Support `json:"level"`
+	Note  string  `json:"note,omitempty"`
+}
+
+// Descriptor 是单个平台的能力描述，与 README 中的支持矩阵保持一致
+type Descriptor struct {
+	Source   string                     `json:"source"` // 与 model.Song.Source 一致
+	Name     string                     `json:"name"`   // 展示名称，例如 "网易云音乐"
+	Features map[Feature]FeatureSupport `json:"features"`
+	Note     string                     `json:"note,omitempty"`
+}
+
+// Support 返回某项功能的支持程度，未声明的功能视为不支持
+func (d Descriptor) Support(f Feature) Support {
+	if fs, ok := d.Features[f]; ok && fs.Level != "" {
+		return fs.Level
+	}
+	return Unsupported
+}
+
+// Available 判断功能是否可用 (包括不稳定)
+func (d Descriptor) Available(f Feature) bool {
+	return d.Support(f) != Unsupported
+}
+
+// Features 返回 Capability 对应的矩阵列
+func (c Capability) Features() []Feature {
+	switch c {
+	case CapSearch:
+		return []Feature{FeatureSearch}
+	case CapParse:
+		return []Feature{FeatureSongParse}
+	case CapDownload:
+		return []Feature{FeatureDownload}
+	case CapLyrics:
+		return []Feature{FeatureLyrics}
+	case CapAlbum:
+		return []Feature{FeatureAlbumSearch, FeatureAlbumSongs, FeatureAlbumParse}
+	case CapPlaylist:
+		return []Feature{FeaturePlaylistSearch, FeaturePlaylistSongs, FeaturePlaylistParse}
+	case CapRecommendedPlaylist:
+		return []Feature{FeaturePlaylistRecommend}
+	case CapPlaylistCategory:
+		return []Feature{FeaturePlaylistCategory}
+	case CapUserPlaylist:
+		return []Feature{FeatureUserPlaylist}
+	case CapQRLogin:
+		return []Feature{FeatureQRLogin}
+	}
+	return nil
+}
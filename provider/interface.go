@@ -1,6 +1,11 @@
 package provider
 
-import "github.com/guohuiyuan/music-lib/model"
+import (
+	"context"
+
+	"github.com/guohuiyuan/music-lib/lyrics"
+	"github.com/guohuiyuan/music-lib/model"
+)
 
 type SongSearcher interface {
 	Search(keyword string) ([]model.Song, error)
@@ -18,6 +23,11 @@ type LyricProvider interface {
 	GetLyrics(s *model.Song) (string, error)
 }
 
+// StructuredLyricProvider 返回结构化歌词，GetLyrics 的结果等同于 Lyrics.LRC()
+type StructuredLyricProvider interface {
+	GetLyricsData(s *model.Song) (*lyrics.Lyrics, error)
+}
+
 type MusicProvider interface {
 	SongSearcher
 	SongParser
@@ -66,3 +76,75 @@ type FullMusicProvider interface {
 	AlbumProvider
 	FullPlaylistProvider
 }
+
+// 以下接口是上面各接口的 context 版本，ctx 会一路传递到底层 HTTP 请求，
+// 调用方可以借此取消请求或设置单次请求的超时。
+
+type SongSearcherContext interface {
+	SearchContext(ctx context.Context, keyword string) ([]model.Song, error)
+}
+
+type SongParserContext interface {
+	ParseContext(ctx context.Context, link string) (*model.Song, error)
+}
+
+type SongDownloaderContext interface {
+	GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error)
+}
+
+type LyricProviderContext interface {
+	GetLyricsContext(ctx context.Context, s *model.Song) (string, error)
+}
+
+type StructuredLyricProviderContext interface {
+	GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error)
+}
+
+type MusicProviderContext interface {
+	SongSearcherContext
+	SongParserContext
+	SongDownloaderContext
+	LyricProviderContext
+}
+
+type AlbumProviderContext interface {
+	SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error)
+	GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error)
+	ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error)
+}
+
+type PlaylistProviderContext interface {
+	SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error)
+	GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error)
+	ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error)
+}
+
+type RecommendedPlaylistProviderContext interface {
+	GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error)
+}
+
+type PlaylistCategoryProviderContext interface {
+	GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error)
+	GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error)
+}
+
+type UserPlaylistProviderContext interface {
+	GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error)
+}
+
+type QRLoginProviderContext interface {
+	CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error)
+	CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error)
+}
+
+type FullPlaylistProviderContext interface {
+	PlaylistProviderContext
+	RecommendedPlaylistProviderContext
+	PlaylistCategoryProviderContext
+}
+
+type FullMusicProviderContext interface {
+	MusicProviderContext
+	AlbumProviderContext
+	FullPlaylistProviderContext
+}
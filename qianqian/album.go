@@ -1,6 +1,7 @@
 package qianqian
 
 import (
+	"context"
 	"errors"
 	"github.com/guohuiyuan/music-lib/model"
 	"regexp"
@@ -11,17 +12,34 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultQianqian.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultQianqian.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) {
 	return defaultQianqian.GetAlbumSongs(id)
 }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultQianqian.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultQianqian.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultQianqian.ParseAlbumContext(ctx, link)
+}
+
 // SearchAlbum 搜索专辑
 func (q *Qianqian) SearchAlbum(keyword string) ([]model.Playlist, error) {
-	items, err := q.searchAlbumItems(keyword)
+	return q.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext 与 SearchAlbum 相同，但请求受 ctx 控制。
+func (q *Qianqian) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	items, err := q.searchAlbumItems(ctx, keyword)
 	if err != nil {
 		return nil, err
 	}
@@ -60,12 +78,22 @@ func (q *Qianqian) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 // GetAlbumSongs 获取专辑歌曲列表
 func (q *Qianqian) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := q.fetchAlbumDetail(id)
+	return q.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext 与 GetAlbumSongs 相同，但请求受 ctx 控制。
+func (q *Qianqian) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := q.fetchAlbumDetail(ctx, id)
 	return songs, err
 }
 
 // ParseAlbum 解析专辑链接
 func (q *Qianqian) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return q.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext 与 ParseAlbum 相同，但请求受 ctx 控制。
+func (q *Qianqian) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`music\.91q\.com/album/([A-Za-z0-9]+)`),
 		regexp.MustCompile(`albumAssetCode=([A-Za-z0-9]+)`),
@@ -75,7 +103,7 @@ func (q *Qianqian) ParseAlbum(link string) (*model.Playlist, []model.Song, error
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return q.fetchAlbumDetail(matches[1])
+			return q.fetchAlbumDetail(ctx, matches[1])
 		}
 	}
 
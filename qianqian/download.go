@@ -1,16 +1,25 @@
 package qianqian
 
 import (
-	"errors"
+	"context"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultQianqian.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultQianqian.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL 获取下载链接
 func (q *Qianqian) GetDownloadURL(s *model.Song) (string, error) {
+	return q.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext 与 GetDownloadURL 相同，但请求受 ctx 控制。
+func (q *Qianqian) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "qianqian" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 	if s.URL != "" {
 		return s.URL, nil
@@ -21,5 +30,5 @@ func (q *Qianqian) GetDownloadURL(s *model.Song) (string, error) {
 		tsid = s.Extra["tsid"]
 	}
 
-	return q.fetchDownloadURL(tsid)
+	return q.fetchDownloadURL(ctx, tsid)
 }
@@ -1,8 +1,8 @@
 package qianqian
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -11,10 +11,19 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultQianqian.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultQianqian.GetLyricsContext(ctx, s)
+}
+
 // GetLyrics 获取歌词
 func (q *Qianqian) GetLyrics(s *model.Song) (string, error) {
+	return q.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (q *Qianqian) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "qianqian" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	tsid := s.ID
@@ -28,7 +37,7 @@ func (q *Qianqian) GetLyrics(s *model.Song) (string, error) {
 	signParams(params)
 	apiURL := "https://music.91q.com/v1/song/info?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -46,11 +55,11 @@ func (q *Qianqian) GetLyrics(s *model.Song) (string, error) {
 		return "", fmt.Errorf("qianqian song info parse error: %w", err)
 	}
 	if len(resp.Data) == 0 || resp.Data[0].Lyric == "" {
-		return "", errors.New("lyric url not found")
+		return "", model.Errorf(model.ErrNotFound, "lyric url not found")
 	}
 
 	lyricURL := resp.Data[0].Lyric
-	lrcBody, err := utils.Get(lyricURL,
+	lrcBody, err := q.client.GetContext(ctx, lyricURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", q.cookie),
 	)
@@ -1,6 +1,7 @@
 package qianqian
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,21 +18,46 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultQianqian.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultQianqian.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) { return defaultQianqian.GetPlaylistSongs(id) }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultQianqian.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultQianqian.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultQianqian.ParsePlaylistContext(ctx, link)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultQianqian.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultQianqian.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultQianqian.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultQianqian.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (q *Qianqian) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return q.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext 与 SearchPlaylist 相同，但请求受 ctx 控制。
+func (q *Qianqian) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	// [参数修正] timestamp 是必须的，type=6 代表歌单 (之前可能用了 10000 导致报错)
 	params := url.Values{}
 	params.Set("word", keyword)
@@ -46,7 +72,7 @@ func (q *Qianqian) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 	apiURL := "https://music.91q.com/v1/search?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -125,11 +151,21 @@ func (q *Qianqian) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 }
 
 func (q *Qianqian) GetPlaylistSongs(id string) ([]model.Song, error) {
-	_, songs, err := q.fetchPlaylistDetail(id)
+	return q.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext 与 GetPlaylistSongs 相同，但请求受 ctx 控制。
+func (q *Qianqian) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := q.fetchPlaylistDetail(ctx, id)
 	return songs, err
 }
 
 func (q *Qianqian) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return q.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext 与 ParsePlaylist 相同，但请求受 ctx 控制。
+func (q *Qianqian) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`music\.91q\.com/(?:songlist|tracklist|playlist)/([A-Za-z0-9]+)`),
 		regexp.MustCompile(`(?:songlistid|tracklistid|playlistid|id)=([A-Za-z0-9]+)`),
@@ -138,24 +174,29 @@ func (q *Qianqian) ParsePlaylist(link string) (*model.Playlist, []model.Song, er
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return q.fetchPlaylistDetail(matches[1])
+			return q.fetchPlaylistDetail(ctx, matches[1])
 		}
 	}
 
 	if len(link) > 0 && !strings.Contains(link, "/") {
-		return q.fetchPlaylistDetail(link)
+		return q.fetchPlaylistDetail(ctx, link)
 	}
 
 	return nil, nil, errors.New("invalid qianqian playlist link")
 }
 
 func (q *Qianqian) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return q.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext 与 GetPlaylistCategories 相同，但请求受 ctx 控制。
+func (q *Qianqian) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	params := url.Values{}
 	params.Set("appid", AppID)
 	signParams(params)
 
 	apiURL := "https://music.91q.com/v1/tracklist/category?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -211,6 +252,11 @@ func (q *Qianqian) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 }
 
 func (q *Qianqian) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return q.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext 与 GetCategoryPlaylists 相同，但请求受 ctx 控制。
+func (q *Qianqian) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	categoryID = strings.TrimSpace(categoryID)
 	if page < 1 {
 		page = 1
@@ -232,7 +278,7 @@ func (q *Qianqian) GetCategoryPlaylists(categoryID string, page, limit int) ([]m
 	signParams(params)
 
 	apiURL := "https://music.91q.com/v1/tracklist/list?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
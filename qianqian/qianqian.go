@@ -1,6 +1,7 @@
 package qianqian
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
@@ -24,9 +25,12 @@ const (
 
 type Qianqian struct {
 	cookie string
+	client *utils.Client
 }
 
-func New(cookie string) *Qianqian { return &Qianqian{cookie: cookie} }
+func New(cookie string, opts ...utils.ClientOption) *Qianqian {
+	return &Qianqian{cookie: cookie, client: utils.NewClient(opts...)}
+}
 
 var defaultQianqian = New("")
 
@@ -55,7 +59,7 @@ type qianqianAlbumSearchItem struct {
 // [新增]
 
 // SearchPlaylist 搜索歌单
-func (q *Qianqian) searchAlbumItems(keyword string) ([]qianqianAlbumSearchItem, error) {
+func (q *Qianqian) searchAlbumItems(ctx context.Context, keyword string) ([]qianqianAlbumSearchItem, error) {
 	keywords := []string{keyword}
 	if sanitized := sanitizeQianqianAlbumKeyword(keyword); sanitized != "" && sanitized != keyword {
 		keywords = append(keywords, sanitized)
@@ -63,7 +67,7 @@ func (q *Qianqian) searchAlbumItems(keyword string) ([]qianqianAlbumSearchItem,
 
 	var lastErr error
 	for _, currentKeyword := range keywords {
-		items, retryWithSanitized, err := q.searchAlbumItemsOnce(currentKeyword)
+		items, retryWithSanitized, err := q.searchAlbumItemsOnce(ctx, currentKeyword)
 		if err == nil {
 			return items, nil
 		}
@@ -80,7 +84,7 @@ func (q *Qianqian) searchAlbumItems(keyword string) ([]qianqianAlbumSearchItem,
 	return nil, errors.New("no albums found")
 }
 
-func (q *Qianqian) searchAlbumItemsOnce(keyword string) ([]qianqianAlbumSearchItem, bool, error) {
+func (q *Qianqian) searchAlbumItemsOnce(ctx context.Context, keyword string) ([]qianqianAlbumSearchItem, bool, error) {
 	params := url.Values{}
 	params.Set("word", keyword)
 	params.Set("type", "3")
@@ -90,7 +94,7 @@ func (q *Qianqian) searchAlbumItemsOnce(keyword string) ([]qianqianAlbumSearchIt
 	signParams(params)
 
 	apiURL := "https://music.91q.com/v1/search?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -135,7 +139,7 @@ func (q *Qianqian) searchAlbumItemsOnce(keyword string) ([]qianqianAlbumSearchIt
 	return dataObj.TypeAlbum, false, nil
 }
 
-func (q *Qianqian) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (q *Qianqian) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	playlistID := strings.TrimSpace(id)
 	if playlistID == "" {
 		return nil, nil, errors.New("playlist id is empty")
@@ -149,7 +153,7 @@ func (q *Qianqian) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song
 
 	apiURL := "https://music.91q.com/v1/tracklist/info?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -216,7 +220,7 @@ func (q *Qianqian) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song
 	}
 
 	if len(songs) == 0 {
-		return nil, nil, errors.New("playlist is empty or invalid")
+		return nil, nil, model.Errorf(model.ErrNotFound, "playlist is empty or invalid")
 	}
 
 	trackCount := resp.Data.TrackCount
@@ -247,8 +251,8 @@ func (q *Qianqian) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song
 	return playlist, songs, nil
 }
 
-func (q *Qianqian) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
-	albumID, err := q.resolveAlbumAssetCode(id)
+func (q *Qianqian) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
+	albumID, err := q.resolveAlbumAssetCode(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -259,7 +263,7 @@ func (q *Qianqian) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, e
 	signParams(params)
 
 	apiURL := "https://music.91q.com/v1/album/info?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -300,7 +304,7 @@ func (q *Qianqian) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, e
 		return nil, nil, fmt.Errorf("api error: %s (code %d)", resp.ErrMsg, resp.Errno)
 	}
 	if normalizeQianqianAlbumAssetCode(resp.Data.AlbumAssetCode) == "" {
-		return nil, nil, errors.New("album not found")
+		return nil, nil, model.Errorf(model.ErrNotFound, "album not found")
 	}
 
 	album := &model.Playlist{
@@ -368,7 +372,7 @@ func (q *Qianqian) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, e
 }
 
 // fetchDownloadURL 内部方法：获取下载链接
-func (q *Qianqian) fetchDownloadURL(tsid string) (string, error) {
+func (q *Qianqian) fetchDownloadURL(ctx context.Context, tsid string) (string, error) {
 	qualities := []string{"3000", "320", "128", "64"}
 	for _, rate := range qualities {
 		params := url.Values{}
@@ -378,7 +382,7 @@ func (q *Qianqian) fetchDownloadURL(tsid string) (string, error) {
 		signParams(params)
 		apiURL := "https://music.91q.com/v1/song/tracklink?" + params.Encode()
 
-		body, err := utils.Get(apiURL,
+		body, err := q.client.GetContext(ctx, apiURL,
 			utils.WithHeader("User-Agent", UserAgent),
 			utils.WithHeader("Referer", Referer),
 			utils.WithHeader("Cookie", q.cookie),
@@ -409,18 +413,18 @@ func (q *Qianqian) fetchDownloadURL(tsid string) (string, error) {
 			return downloadURL, nil
 		}
 	}
-	return "", errors.New("download url not found")
+	return "", model.Errorf(model.ErrNotFound, "download url not found")
 }
 
 // fetchSongInfo 内部方法：获取元数据
-func (q *Qianqian) fetchSongInfo(tsid string) (*model.Song, error) {
+func (q *Qianqian) fetchSongInfo(ctx context.Context, tsid string) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("TSID", tsid)
 	params.Set("appid", AppID)
 	signParams(params)
 	apiURL := "https://music.91q.com/v1/song/info?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -444,7 +448,7 @@ func (q *Qianqian) fetchSongInfo(tsid string) (*model.Song, error) {
 		return nil, fmt.Errorf("qianqian song info parse error: %w", err)
 	}
 	if len(resp.Data) == 0 {
-		return nil, errors.New("song info not found")
+		return nil, model.Errorf(model.ErrNotFound, "song info not found")
 	}
 
 	item := resp.Data[0]
@@ -464,7 +468,7 @@ func (q *Qianqian) fetchSongInfo(tsid string) (*model.Song, error) {
 	}, nil
 }
 
-func (q *Qianqian) resolveAlbumAssetCode(id string) (string, error) {
+func (q *Qianqian) resolveAlbumAssetCode(ctx context.Context, id string) (string, error) {
 	id = strings.TrimSpace(id)
 	if id == "" {
 		return "", errors.New("album id is empty")
@@ -480,7 +484,7 @@ func (q *Qianqian) resolveAlbumAssetCode(id string) (string, error) {
 	signParams(params)
 
 	apiURL := "https://music.91q.com/v1/album/albumid2psid?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -1,6 +1,7 @@
 package qianqian
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,10 +13,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultQianqian.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultQianqian.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultQianqian.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultQianqian.ParseContext(ctx, link)
+}
+
 // Search 搜索歌曲
 func (q *Qianqian) Search(keyword string) ([]model.Song, error) {
+	return q.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext 与 Search 相同，但请求受 ctx 控制。
+func (q *Qianqian) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("word", keyword)
 	params.Set("type", "1")
@@ -25,7 +39,7 @@ func (q *Qianqian) Search(keyword string) ([]model.Song, error) {
 	signParams(params)
 	apiURL := "https://music.91q.com/v1/search?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", Referer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -85,6 +99,11 @@ func (q *Qianqian) Search(keyword string) ([]model.Song, error) {
 
 // Parse 解析链接并获取完整信息
 func (q *Qianqian) Parse(link string) (*model.Song, error) {
+	return q.ParseContext(context.Background(), link)
+}
+
+// ParseContext 与 Parse 相同，但请求受 ctx 控制。
+func (q *Qianqian) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	// 1. 提取 TSID
 	re := regexp.MustCompile(`music\.91q\.com/song/(\w+)`)
 	matches := re.FindStringSubmatch(link)
@@ -94,13 +113,13 @@ func (q *Qianqian) Parse(link string) (*model.Song, error) {
 	tsid := matches[1]
 
 	// 2. 获取 Metadata (通过 song/info 接口)
-	song, err := q.fetchSongInfo(tsid)
+	song, err := q.fetchSongInfo(ctx, tsid)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. 获取下载链接 (直接调用 fetchDownloadURL)
-	url, err := q.fetchDownloadURL(tsid)
+	url, err := q.fetchDownloadURL(ctx, tsid)
 	if err == nil {
 		song.URL = url
 	}
@@ -2,14 +2,21 @@ package qq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
 	"math/rand"
 	"time"
 )
 
 func (q *QQ) IsVipAccount() (bool, error) {
+	return q.IsVipAccountContext(context.Background())
+}
+
+// IsVipAccountContext is like IsVipAccount but carries ctx through its requests.
+func (q *QQ) IsVipAccountContext(ctx context.Context) (bool, error) {
 	if q.isVipCache != nil {
 		return *q.isVipCache, nil
 	}
@@ -65,7 +72,7 @@ func (q *QQ) IsVipAccount() (bool, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post("https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
+	body, err := q.client.PostContext(ctx, "https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
 	if err != nil {
 		return false, err
 	}
@@ -90,7 +97,7 @@ func (q *QQ) IsVipAccount() (bool, error) {
 	if len(result.Req1.Data.MidUrlInfo) > 0 && result.Req1.Data.MidUrlInfo[0].Purl != "" {
 		isVip = true
 	} else if result.Req1.Code != 0 {
-		return false, fmt.Errorf("api returned error code: %d", result.Req1.Code)
+		return false, model.CodeError(apiErrorKinds, result.Req1.Code, "api returned error code: %d", result.Req1.Code)
 	}
 
 	q.isVipCache = &isVip
@@ -1,6 +1,7 @@
 package qq
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,17 +16,34 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultQQ.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultQQ.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := defaultQQ.fetchAlbumDetail(id)
+	_, songs, err := defaultQQ.fetchAlbumDetail(context.Background(), id)
 	return songs, err
 }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultQQ.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultQQ.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultQQ.ParseAlbumContext(ctx, link)
+}
+
 // SearchAlbum searches albums.
 func (q *QQ) SearchAlbum(keyword string) ([]model.Playlist, error) {
+	return q.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext is like SearchAlbum but carries ctx through its requests.
+func (q *QQ) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("format", "json")
 	params.Set("p", "1")
@@ -34,7 +52,7 @@ func (q *QQ) SearchAlbum(keyword string) ([]model.Playlist, error) {
 	params.Set("t", "8")
 	apiURL := "http://c.y.qq.com/soso/fcgi-bin/search_for_qq_cp?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 		utils.WithHeader("Referer", "https://y.qq.com/portal/search.html"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -93,11 +111,21 @@ func (q *QQ) SearchAlbum(keyword string) ([]model.Playlist, error) {
 }
 
 func (q *QQ) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := q.fetchAlbumDetail(id)
+	return q.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext is like GetAlbumSongs but carries ctx through its requests.
+func (q *QQ) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := q.fetchAlbumDetail(ctx, id)
 	return songs, err
 }
 
 func (q *QQ) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return q.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext is like ParseAlbum but carries ctx through its requests.
+func (q *QQ) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	patterns := []*regexp.Regexp{
 		regexp.MustCompile(`albumDetail/([A-Za-z0-9]+)`),
 		regexp.MustCompile(`album/([A-Za-z0-9]+)`),
@@ -107,7 +135,7 @@ func (q *QQ) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	for _, pattern := range patterns {
 		matches := pattern.FindStringSubmatch(link)
 		if len(matches) >= 2 {
-			return q.fetchAlbumDetail(matches[1])
+			return q.fetchAlbumDetail(ctx, matches[1])
 		}
 	}
 
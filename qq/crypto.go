@@ -1,8 +1,12 @@
 package qq
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
+	"io"
+
+	"github.com/guohuiyuan/music-lib/audio"
 )
 
 var defaultQQMask58 = []byte{
@@ -22,48 +26,182 @@ const (
 )
 
 func DecryptQQ(encrypted []byte, ext string) ([]byte, string, error) {
+	return DecryptQQWithKey(encrypted, ext, "")
+}
+
+// DecryptQQWithKey 与 DecryptQQ 相同，但可以传入外部获取的 ekey。
+// ekey 为空时使用文件末尾内嵌的密钥，新版文件没有内嵌密钥时返回 ErrEKeyMissing。
+func DecryptQQWithKey(encrypted []byte, ext, ekey string) ([]byte, string, error) {
 	if len(encrypted) == 0 {
 		return nil, "", errors.New("empty input")
 	}
 
-	mask := newQQMask(defaultQQMask58, defaultQQSuper58A, defaultQQSuper58B)
-	if ext == "mflac" {
-		if detected := detectQQMaskFromEncrypted(encrypted); detected != nil {
-			mask = detected
+	trailer := qqTrailer{audioLen: len(encrypted)}
+	if isQQKeyedExt(ext) || ekey != "" {
+		trailer = parseQQTrailer(encrypted)
+	}
+	audio := encrypted[:trailer.audioLen]
+	cipher, err := selectQQCipher(audio, ext, ekey, trailer)
+	if err != nil {
+		return nil, "", err
+	}
+	plain := append([]byte(nil), audio...)
+	cipher.decrypt(plain, 0)
+	return plain, qqOutputExt(ext, plain), nil
+}
+
+// NewQQReader 边读边解密 QQ 音乐的加密音频，返回解密后的数据流和推荐的扩展名。
+// mflac 需要先读取最多 32KB 的数据来识别密钥。
+func NewQQReader(r io.Reader, ext string) (io.Reader, string, error) {
+	return NewQQReaderWithKey(r, ext, "")
+}
+
+// NewQQReaderWithKey 与 NewQQReader 相同，但可以传入外部获取的 ekey。
+// 只有 r 实现了 io.ReadSeeker 时才能读取并去掉文件末尾的密钥区块。
+func NewQQReaderWithKey(r io.Reader, ext, ekey string) (io.Reader, string, error) {
+	var trailer qqTrailer
+	if rs, ok := r.(io.ReadSeeker); ok && (isQQKeyedExt(ext) || ekey != "") {
+		var err error
+		if trailer, err = readQQTrailer(rs); err != nil {
+			return nil, "", err
+		}
+		if trailer.audioLen > 0 {
+			r = io.LimitReader(rs, int64(trailer.audioLen))
 		}
 	}
 
-	plain := mask.Decrypt(encrypted)
+	br := bufio.NewReaderSize(r, qqMaskSearchSize)
+	head, err := br.Peek(qqMaskSearchSize)
+	if len(head) == 0 {
+		if err == nil || err == io.EOF {
+			err = errors.New("empty input")
+		}
+		return nil, "", err
+	}
 
-	switch ext {
-	case "mflac", "qmcflac", "bkcflac":
-		return plain, "flac", nil
-	case "mgg", "qmcogg":
-		return plain, "ogg", nil
-	case "tkm":
-		return plain, "m4a", nil
-	default:
-		if extGuess := detectAudioExt(plain); extGuess != "" {
-			return plain, extGuess, nil
+	cipher, err := selectQQCipher(head, ext, ekey, trailer)
+	if err != nil {
+		return nil, "", err
+	}
+	plainHead := append([]byte(nil), head[:minInt(len(head), 12)]...)
+	cipher.decrypt(plainHead, 0)
+	return &qqReader{r: br, cipher: cipher}, qqOutputExt(ext, plainHead), nil
+}
+
+type qqReader struct {
+	r      io.Reader
+	cipher qqCipher
+	offset int
+}
+
+func (r *qqReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.cipher.decrypt(p[:n], r.offset)
+	r.offset += n
+	return n, err
+}
+
+// qqTrailerSearchSize 是从文件末尾读取的数据量，足够容纳各种密钥区块
+const qqTrailerSearchSize = 4096
+
+// readQQTrailer 读取 rs 末尾的密钥区块，并把读取位置恢复到文件开头
+func readQQTrailer(rs io.ReadSeeker) (qqTrailer, error) {
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return qqTrailer{}, err
+	}
+	n := int64(qqTrailerSearchSize)
+	if n > size {
+		n = size
+	}
+	tail := make([]byte, n)
+	if _, err := rs.Seek(size-n, io.SeekStart); err != nil {
+		return qqTrailer{}, err
+	}
+	if _, err := io.ReadFull(rs, tail); err != nil {
+		return qqTrailer{}, err
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return qqTrailer{}, err
+	}
+
+	t := parseQQTrailer(tail)
+	t.audioLen = int(size) - (len(tail) - t.audioLen)
+	return t, nil
+}
+
+// selectQQCipher 优先使用传入的 ekey，其次是文件内嵌的密钥，最后是静态掩码
+func selectQQCipher(head []byte, ext, ekey string, trailer qqTrailer) (qqCipher, error) {
+	if ekey == "" {
+		ekey = trailer.ekey
+	}
+	if ekey != "" {
+		cipher, err := newQQKeyCipher(ekey)
+		if err != nil {
+			return nil, err
+		}
+		if !checkQQCipher(cipher, head) {
+			return nil, errors.New("qq: ekey does not match the file")
 		}
-		return plain, "mp3", nil
+		return cipher, nil
+	}
+	if trailer.keyless {
+		return nil, ErrEKeyMissing
+	}
+
+	mask := selectQQMask(head, ext)
+	if isQQKeyedExt(ext) && !checkQQCipher(mask, head) {
+		return nil, ErrEKeyMissing
 	}
+	return mask, nil
 }
 
-func detectAudioExt(data []byte) string {
-	if len(data) >= 4 && bytes.Equal(data[:4], []byte{'f', 'L', 'a', 'C'}) {
-		return "flac"
+// checkQQCipher 检查解密后的文件头是否是已知的音频格式
+func checkQQCipher(cipher qqCipher, head []byte) bool {
+	plain := append([]byte(nil), head[:minInt(len(head), 12)]...)
+	cipher.decrypt(plain, 0)
+	return audio.DetectExt(plain) != ""
+}
+
+// isQQKeyedExt 判断扩展名是否属于需要 ekey 的新版格式
+func isQQKeyedExt(ext string) bool {
+	switch ext {
+	case "mflac", "mflac0", "mgg", "mgg1", "mmp4":
+		return true
 	}
-	if len(data) >= 3 && bytes.Equal(data[:3], []byte{'I', 'D', '3'}) {
-		return "mp3"
+	return false
+}
+
+func selectQQMask(head []byte, ext string) *qqMask {
+	if ext == "mflac" || ext == "mflac0" {
+		if detected := detectQQMaskFromEncrypted(head); detected != nil {
+			return detected
+		}
 	}
-	if len(data) >= 4 && bytes.Equal(data[:4], []byte{'O', 'g', 'g', 'S'}) {
+	return newQQMask(defaultQQMask58, defaultQQSuper58A, defaultQQSuper58B)
+}
+
+func qqOutputExt(ext string, plainHead []byte) string {
+	switch ext {
+	case "mflac", "mflac0", "qmcflac", "bkcflac":
+		return "flac"
+	case "mgg", "mgg1", "qmcogg":
 		return "ogg"
-	}
-	if len(data) >= 8 && bytes.Equal(data[4:8], []byte{'f', 't', 'y', 'p'}) {
+	case "tkm", "mmp4":
 		return "m4a"
+	default:
+		if extGuess := audio.DetectExt(plainHead); extGuess != "" {
+			return extGuess
+		}
+		return "mp3"
 	}
-	return "mp3"
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
 
 type qqMask struct {
@@ -142,27 +280,28 @@ func (m *qqMask) generateMask58From128() error {
 
 func (m *qqMask) Decrypt(encrypted []byte) []byte {
 	out := append([]byte(nil), encrypted...)
-	r := -1
-	n := -1
-	for i := 0; i < len(out); i++ {
-		r++
-		n++
-		if r == 32768 || (r > 32768 && (r+1)%32768 == 0) {
-			r++
-			n++
-		}
-		if n >= 128 {
-			n -= 128
+	m.decrypt(out, 0)
+	return out
+}
+
+// decrypt 按文件位置异或掩码：从第 32768 字节开始，每 32767 字节多跳过一个掩码位置
+func (m *qqMask) decrypt(buf []byte, offset int) {
+	for i := range buf {
+		pos := offset + i
+		if pos >= 32768 {
+			pos += pos / 32767
 		}
-		out[i] ^= m.matrix128[n]
+		buf[i] ^= m.matrix128[pos%128]
 	}
-	return out
 }
 
+// qqMaskSearchSize 是识别 mflac 密钥时检查的数据量
+const qqMaskSearchSize = 32768
+
 func detectQQMaskFromEncrypted(encrypted []byte) *qqMask {
 	max := len(encrypted)
-	if max > 32768 {
-		max = 32768
+	if max > qqMaskSearchSize {
+		max = qqMaskSearchSize
 	}
 
 	for i := 0; i+128 <= max; i += 128 {
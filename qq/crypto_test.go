@@ -0,0 +1,36 @@
+package qq
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestQQReaderMatchesDecryptQQ(t *testing.T) {
+	plain := append([]byte("fLaC"), bytes.Repeat([]byte("0123456789"), 10000)...)
+	mask := newQQMask(defaultQQMask58, defaultQQSuper58A, defaultQQSuper58B)
+	encrypted := mask.Decrypt(plain)
+
+	for _, ext := range []string{"qmcflac", "qmc0"} {
+		want, wantExt, err := DecryptQQ(encrypted, ext)
+		if err != nil || !bytes.Equal(want, plain) {
+			t.Fatalf("DecryptQQ(%s) = %v", ext, err)
+		}
+		r, gotExt, err := NewQQReader(iotest.HalfReader(bytes.NewReader(encrypted)), ext)
+		if err != nil {
+			t.Fatalf("NewQQReader(%s): %v", ext, err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) || gotExt != wantExt || gotExt != "flac" {
+			t.Fatalf("stream mismatch for %s (ext %q, want %q)", ext, gotExt, wantExt)
+		}
+	}
+
+	if _, _, err := NewQQReader(bytes.NewReader(nil), "qmc0"); err == nil {
+		t.Fatal("expected error for empty input")
+	}
+}
@@ -2,8 +2,8 @@ package qq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -13,10 +13,19 @@ import (
 
 func GetDownloadURL(s *model.Song) (string, error) { return defaultQQ.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultQQ.GetDownloadURLContext(ctx, s)
+}
+
 // GetDownloadURL returns a download URL.
 func (q *QQ) GetDownloadURL(s *model.Song) (string, error) {
+	return q.GetDownloadURLContext(context.Background(), s)
+}
+
+// GetDownloadURLContext is like GetDownloadURL but carries ctx through its requests.
+func (q *QQ) GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
 	if s.Source != "qq" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	songMID := s.ID
@@ -31,7 +40,7 @@ func (q *QQ) GetDownloadURL(s *model.Song) (string, error) {
 	var prefixes []string
 	var exts []string
 
-	isVip, _ := q.IsVipAccount()
+	isVip, _ := q.IsVipAccountContext(ctx)
 	if isVip {
 		prefixes = []string{"AI00", "Q001", "Q000", "F000", "O801", "M800", "M500"} // Master, Atmos5.1, Atmos2.0, FLAC, 640k, 320k, 128k
 		exts = []string{"flac", "flac", "flac", "flac", "ogg", "mp3", "mp3"}
@@ -87,13 +96,14 @@ func (q *QQ) GetDownloadURL(s *model.Song) (string, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post("https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
+	body, err := q.client.PostContext(ctx, "https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
 	if err != nil {
 		return "", err
 	}
 
 	var result struct {
 		Req1 struct {
+			Code int `json:"code"`
 			Data struct {
 				MidUrlInfo []struct {
 					Filename string `json:"filename"`
@@ -117,5 +127,9 @@ func (q *QQ) GetDownloadURL(s *model.Song) (string, error) {
 		}
 	}
 
-	return "", errors.New("no valid download url found or vip required")
+	kind, ok := apiErrorKinds[result.Req1.Code]
+	if !ok {
+		kind = model.ErrVIPRequired
+	}
+	return "", model.Errorf(kind, "no valid download url found or vip required")
 }
@@ -1,6 +1,7 @@
 package qq
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -27,39 +28,78 @@ const (
 
 func CreateQRLogin() (*model.QRLoginSession, error) { return defaultQQ.CreateQRLogin() }
 
+func CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
+	return defaultQQ.CreateQRLoginContext(ctx)
+}
+
 func CheckQRLogin(key string) (*model.QRLoginResult, error) { return defaultQQ.CheckQRLogin(key) }
 
+func CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
+	return defaultQQ.CheckQRLoginContext(ctx, key)
+}
+
 func CreateWXQRLogin() (*model.QRLoginSession, error) { return defaultQQ.CreateWXQRLogin() }
 
+func CreateWXQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
+	return defaultQQ.CreateWXQRLoginContext(ctx)
+}
+
 func CheckWXQRLogin(key string) (*model.QRLoginResult, error) { return defaultQQ.CheckWXQRLogin(key) }
 
+func CheckWXQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
+	return defaultQQ.CheckWXQRLoginContext(ctx, key)
+}
+
 func CreateQRLoginByType(loginType string) (*model.QRLoginSession, error) {
 	return defaultQQ.CreateQRLoginByType(loginType)
 }
 
+func CreateQRLoginByTypeContext(ctx context.Context, loginType string) (*model.QRLoginSession, error) {
+	return defaultQQ.CreateQRLoginByTypeContext(ctx, loginType)
+}
+
 func CheckQRLoginByType(loginType, key string) (*model.QRLoginResult, error) {
 	return defaultQQ.CheckQRLoginByType(loginType, key)
 }
 
+func CheckQRLoginByTypeContext(ctx context.Context, loginType, key string) (*model.QRLoginResult, error) {
+	return defaultQQ.CheckQRLoginByTypeContext(ctx, loginType, key)
+}
+
 func (q *QQ) CreateQRLoginByType(loginType string) (*model.QRLoginSession, error) {
+	return q.CreateQRLoginByTypeContext(context.Background(), loginType)
+}
+
+// CreateQRLoginByTypeContext is like CreateQRLoginByType but carries ctx through its requests.
+func (q *QQ) CreateQRLoginByTypeContext(ctx context.Context, loginType string) (*model.QRLoginSession, error) {
 	switch normalizeQQLoginType(loginType) {
 	case "wx":
-		return q.CreateWXQRLogin()
+		return q.CreateWXQRLoginContext(ctx)
 	default:
-		return q.CreateQRLogin()
+		return q.CreateQRLoginContext(ctx)
 	}
 }
 
 func (q *QQ) CheckQRLoginByType(loginType, key string) (*model.QRLoginResult, error) {
+	return q.CheckQRLoginByTypeContext(context.Background(), loginType, key)
+}
+
+// CheckQRLoginByTypeContext is like CheckQRLoginByType but carries ctx through its requests.
+func (q *QQ) CheckQRLoginByTypeContext(ctx context.Context, loginType, key string) (*model.QRLoginResult, error) {
 	switch normalizeQQLoginType(loginType) {
 	case "wx":
-		return q.CheckWXQRLogin(key)
+		return q.CheckWXQRLoginContext(ctx, key)
 	default:
-		return q.CheckQRLogin(key)
+		return q.CheckQRLoginContext(ctx, key)
 	}
 }
 
 func (q *QQ) CreateQRLogin() (*model.QRLoginSession, error) {
+	return q.CreateQRLoginContext(context.Background())
+}
+
+// CreateQRLoginContext is like CreateQRLogin but carries ctx through its requests.
+func (q *QQ) CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
 	params := url.Values{}
 	params.Set("appid", "716027609")
 	params.Set("e", "2")
@@ -71,13 +111,13 @@ func (q *QQ) CreateQRLogin() (*model.QRLoginSession, error) {
 	params.Set("daid", "383")
 	params.Set("pt_3rd_aid", "100497308")
 
-	req, err := http.NewRequest("GET", qqQRShowAPI+"?"+params.Encode(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", qqQRShowAPI+"?"+params.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", "https://y.qq.com/")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := q.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +149,11 @@ func (q *QQ) CreateQRLogin() (*model.QRLoginSession, error) {
 }
 
 func (q *QQ) CheckQRLogin(key string) (*model.QRLoginResult, error) {
+	return q.CheckQRLoginContext(context.Background(), key)
+}
+
+// CheckQRLoginContext is like CheckQRLogin but carries ctx through its requests.
+func (q *QQ) CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
 	values, err := url.ParseQuery(key)
 	if err != nil {
 		return nil, err
@@ -140,14 +185,14 @@ func (q *QQ) CheckQRLogin(key string) (*model.QRLoginResult, error) {
 	params.Set("service", "ptqrlogin")
 	params.Set("nodirect", "0")
 
-	req, err := http.NewRequest("GET", qqQRCheckAPI+"?"+params.Encode(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", qqQRCheckAPI+"?"+params.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", "https://xui.ptlogin2.qq.com/")
 	req.Header.Set("Cookie", "qrsig="+qrsig)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := q.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +218,7 @@ func (q *QQ) CheckQRLogin(key string) (*model.QRLoginResult, error) {
 
 	cookies := responseCookies(resp)
 	if redirectURL != "" {
-		redirectCookies, err := fetchQQRedirectCookies(redirectURL, cookies)
+		redirectCookies, err := q.fetchQQRedirectCookies(ctx, redirectURL, cookies)
 		if err == nil {
 			for k, v := range redirectCookies {
 				cookies[k] = v
@@ -190,6 +235,11 @@ func (q *QQ) CheckQRLogin(key string) (*model.QRLoginResult, error) {
 }
 
 func (q *QQ) CreateWXQRLogin() (*model.QRLoginSession, error) {
+	return q.CreateWXQRLoginContext(context.Background())
+}
+
+// CreateWXQRLoginContext is like CreateWXQRLogin but carries ctx through its requests.
+func (q *QQ) CreateWXQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
 	state := fmt.Sprintf("music-lib-%d", time.Now().UnixNano())
 	params := url.Values{}
 	params.Set("appid", qqWXAppID)
@@ -200,13 +250,13 @@ func (q *QQ) CreateWXQRLogin() (*model.QRLoginSession, error) {
 	params.Set("href", "https://y.qq.com/mediastyle/music_v17/src/css/popup_wechat.css#wechat_redirect")
 	loginURL := qqWXQRConnectAPI + "?" + params.Encode()
 
-	req, err := http.NewRequest("GET", loginURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", loginURL, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", "https://y.qq.com/")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := q.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -241,6 +291,11 @@ func (q *QQ) CreateWXQRLogin() (*model.QRLoginSession, error) {
 }
 
 func (q *QQ) CheckWXQRLogin(key string) (*model.QRLoginResult, error) {
+	return q.CheckWXQRLoginContext(context.Background(), key)
+}
+
+// CheckWXQRLoginContext is like CheckWXQRLogin but carries ctx through its requests.
+func (q *QQ) CheckWXQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
 	values, err := url.ParseQuery(key)
 	if err != nil {
 		return nil, err
@@ -257,13 +312,13 @@ func (q *QQ) CheckWXQRLogin(key string) (*model.QRLoginResult, error) {
 	params := url.Values{}
 	params.Set("uuid", uuid)
 	params.Set("_", strconv.FormatInt(time.Now().UnixMilli(), 10))
-	req, err := http.NewRequest("GET", qqWXQRCheckAPI+"?"+params.Encode(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", qqWXQRCheckAPI+"?"+params.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", qqWXQRConnectAPI)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := q.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +348,7 @@ func (q *QQ) CheckWXQRLogin(key string) (*model.QRLoginResult, error) {
 		return result, nil
 	}
 
-	cookies, extra, err := fetchQQWXLoginCookies(wxCode)
+	cookies, extra, err := q.fetchQQWXLoginCookies(ctx, wxCode)
 	if err != nil {
 		result.Status = model.QRLoginStatusFailed
 		result.Message = err.Error()
@@ -389,7 +444,7 @@ func parseQQWXQRCheck(raw string) (code, wxCode string) {
 	return code, wxCode
 }
 
-func fetchQQWXLoginCookies(wxCode string) (map[string]string, map[string]string, error) {
+func (q *QQ) fetchQQWXLoginCookies(ctx context.Context, wxCode string) (map[string]string, map[string]string, error) {
 	payload, err := json.Marshal(map[string]interface{}{
 		"comm": map[string]interface{}{
 			"tmeAppID":     "qqmusic",
@@ -419,7 +474,7 @@ func fetchQQWXLoginCookies(wxCode string) (map[string]string, map[string]string,
 	}
 	var lastErr error
 	for _, apiURL := range endpoints {
-		req, err := http.NewRequest("POST", apiURL, strings.NewReader(string(payload)))
+		req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(string(payload)))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -429,7 +484,7 @@ func fetchQQWXLoginCookies(wxCode string) (map[string]string, map[string]string,
 		req.Header.Set("Accept", "*/*")
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.Header.Set("Cookie", "login_type=2")
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := q.client.Do(req)
 		if err != nil {
 			lastErr = err
 			continue
@@ -529,8 +584,9 @@ func qqWXLoginDataCookies(data map[string]interface{}) map[string]string {
 	return result
 }
 
-func fetchQQRedirectCookies(redirectURL string, cookies map[string]string) (map[string]string, error) {
-	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse }}
+func (q *QQ) fetchQQRedirectCookies(ctx context.Context, redirectURL string, cookies map[string]string) (map[string]string, error) {
+	client := q.client.HTTPClient()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse }
 	currentURL := strings.TrimSpace(redirectURL)
 	collected := make(map[string]string, len(cookies)+8)
 	for k, v := range cookies {
@@ -539,7 +595,7 @@ func fetchQQRedirectCookies(redirectURL string, cookies map[string]string) (map[
 	referer := "https://y.qq.com/"
 
 	for i := 0; i < 8 && currentURL != ""; i++ {
-		req, err := http.NewRequest("GET", currentURL, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", currentURL, nil)
 		if err != nil {
 			return collected, err
 		}
@@ -2,6 +2,7 @@ package qq
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -15,10 +16,39 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultQQ.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultQQ.GetLyricsContext(ctx, s)
+}
+
+func GetLyricsData(s *model.Song) (*lyrics.Lyrics, error) { return defaultQQ.GetLyricsData(s) }
+
+func GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error) {
+	return defaultQQ.GetLyricsDataContext(ctx, s)
+}
+
 // GetLyrics fetches lyrics.
 func (q *QQ) GetLyrics(s *model.Song) (string, error) {
+	return q.GetLyricsContext(context.Background(), s)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (q *QQ) GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	l, err := q.GetLyricsDataContext(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	return l.LRC(), nil
+}
+
+// GetLyricsData 获取结构化歌词，保留逐字时间轴以及翻译、音译轨道
+func (q *QQ) GetLyricsData(s *model.Song) (*lyrics.Lyrics, error) {
+	return q.GetLyricsDataContext(context.Background(), s)
+}
+
+// GetLyricsDataContext is like GetLyricsData but carries ctx through its requests.
+func (q *QQ) GetLyricsDataContext(ctx context.Context, s *model.Song) (*lyrics.Lyrics, error) {
 	if s.Source != "qq" {
-		return "", errors.New("source mismatch")
+		return nil, model.ErrSourceMismatch
 	}
 
 	songMID := s.ID
@@ -31,7 +61,7 @@ func (q *QQ) GetLyrics(s *model.Song) (string, error) {
 		songID, _ = strconv.Atoi(s.Extra["song_id"])
 	}
 	if songID == 0 {
-		if parsed, err := q.fetchSongDetail(songMID); err == nil && parsed != nil {
+		if parsed, err := q.fetchSongDetail(ctx, songMID); err == nil && parsed != nil {
 			if parsed.Extra != nil && parsed.Extra["song_id"] != "" {
 				songID, _ = strconv.Atoi(parsed.Extra["song_id"])
 			}
@@ -50,7 +80,7 @@ func (q *QQ) GetLyrics(s *model.Song) (string, error) {
 		}
 	}
 	if songID == 0 {
-		return "", errors.New("qq song id not found")
+		return nil, model.Errorf(model.ErrNotFound, "qq song id not found")
 	}
 
 	reqData := map[string]interface{}{
@@ -102,9 +132,9 @@ func (q *QQ) GetLyrics(s *model.Song) (string, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post("https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
+	body, err := q.client.PostContext(ctx, "https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var resp struct {
@@ -123,13 +153,17 @@ func (q *QQ) GetLyrics(s *model.Song) (string, error) {
 		} `json:"request"`
 	}
 	if err := json.Unmarshal(body, &resp); err != nil {
-		return "", fmt.Errorf("qq lyric json parse error: %w", err)
+		return nil, fmt.Errorf("qq lyric json parse error: %w", err)
 	}
 	if resp.Code != 0 || resp.Request.Code != 0 {
-		return "", fmt.Errorf("qq lyric api error code: %d/%d", resp.Code, resp.Request.Code)
+		code := resp.Code
+		if code == 0 {
+			code = resp.Request.Code
+		}
+		return nil, model.CodeError(apiErrorKinds, code, "qq lyric api error code: %d/%d", resp.Code, resp.Request.Code)
 	}
 	if resp.Request.Data.Lyric == "" {
-		return "", errors.New("lyric is empty or not found")
+		return nil, model.Errorf(model.ErrNotFound, "lyric is empty or not found")
 	}
 
 	tags := map[string]string{"ti": s.Name, "ar": s.Artist, "al": s.Album}
@@ -160,7 +194,7 @@ func (q *QQ) GetLyrics(s *model.Song) (string, error) {
 		data[item.key] = qrcData
 	}
 	if len(data["orig"]) == 0 {
-		return "", errors.New("lyric is empty or qrc decrypt failed")
+		return nil, errors.New("lyric is empty or qrc decrypt failed")
 	}
-	return lyrics.ConvertVerbatimLRC(tags, data, lyrics.DefaultDisplayOrder()), nil
+	return lyrics.New(tags, data), nil
 }
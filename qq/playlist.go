@@ -2,6 +2,7 @@ package qq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,33 +19,62 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultQQ.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultQQ.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) {
 	return defaultQQ.GetPlaylistSongs(id)
 }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultQQ.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultQQ.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultQQ.ParsePlaylistContext(ctx, link)
+}
+
 // GetRecommendedPlaylists returns recommended playlists.
 func GetRecommendedPlaylists() ([]model.Playlist, error) { return defaultQQ.GetRecommendedPlaylists() }
 
+func GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
+	return defaultQQ.GetRecommendedPlaylistsContext(ctx)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultQQ.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultQQ.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultQQ.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultQQ.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (q *QQ) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return q.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext is like GetPlaylistCategories but carries ctx through its requests.
+func (q *QQ) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	params := url.Values{}
 	params.Set("format", "json")
 	params.Set("inCharset", "utf8")
 	params.Set("outCharset", "utf-8")
 	apiURL := "https://c.y.qq.com/splcloud/fcgi-bin/fcg_get_diss_tag_conf.fcg?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 		utils.WithHeader("Referer", "https://y.qq.com/"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -72,7 +102,7 @@ func (q *QQ) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 		return nil, fmt.Errorf("qq playlist category json parse error: %w", err)
 	}
 	if resp.Code != 0 {
-		return nil, fmt.Errorf("qq playlist category api error: %s (code %d)", resp.Message, resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "qq playlist category api error: %s (code %d)", resp.Message, resp.Code)
 	}
 
 	categories := []model.PlaylistCategory{{
@@ -107,6 +137,11 @@ func (q *QQ) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 }
 
 func (q *QQ) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return q.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext is like GetCategoryPlaylists but carries ctx through its requests.
+func (q *QQ) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	categoryID = strings.TrimSpace(categoryID)
 	if categoryID == "" {
 		categoryID = "10000000"
@@ -137,7 +172,7 @@ func (q *QQ) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.P
 	params.Set("ein", strconv.Itoa(offset+limit-1))
 	apiURL := "https://c.y.qq.com/splcloud/fcgi-bin/fcg_get_diss_by_tag.fcg?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 		utils.WithHeader("Referer", "https://y.qq.com/"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -169,7 +204,7 @@ func (q *QQ) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.P
 		return nil, fmt.Errorf("qq category playlist json parse error: %w", err)
 	}
 	if resp.Code != 0 {
-		return nil, fmt.Errorf("qq category playlist api error: %s (code %d)", resp.Message, resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "qq category playlist api error: %s (code %d)", resp.Message, resp.Code)
 	}
 
 	playlists := make([]model.Playlist, 0, len(resp.Data.List))
@@ -210,6 +245,11 @@ func (q *QQ) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.P
 
 // SearchPlaylist searches playlists.
 func (q *QQ) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return q.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext is like SearchPlaylist but carries ctx through its requests.
+func (q *QQ) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("query", keyword)
 	params.Set("page_no", "0")
@@ -220,7 +260,7 @@ func (q *QQ) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 	apiURL := "http://c.y.qq.com/soso/fcgi-bin/client_music_search_songlist?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36"),
 		utils.WithHeader("Referer", "https://y.qq.com/portal/search.html"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -289,24 +329,34 @@ func (q *QQ) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 // GetPlaylistSongs returns songs in a playlist.
 func (q *QQ) GetPlaylistSongs(id string) ([]model.Song, error) {
+	return q.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext is like GetPlaylistSongs but carries ctx through its requests.
+func (q *QQ) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
 	id = strings.TrimSpace(id)
 	if id == qqFavoriteSongsPlaylistID {
 		uin := normalizeQQUIN(q.cookie)
 		if uin == "" {
-			return nil, fmt.Errorf("qq favorite songs require uin cookie")
+			return nil, model.Errorf(model.ErrAuthExpired, "qq favorite songs require uin cookie")
 		}
-		_, songs, err := q.fetchProfileOrderSongs(uin, 1, 300)
+		_, songs, err := q.fetchProfileOrderSongs(ctx, uin, 1, 300)
 		return songs, err
 	}
 	if strings.HasPrefix(id, qqProfileDirPlaylistPrefix) {
-		return q.fetchProfileDirPlaylistSongs(strings.TrimPrefix(id, qqProfileDirPlaylistPrefix))
+		return q.fetchProfileDirPlaylistSongs(ctx, strings.TrimPrefix(id, qqProfileDirPlaylistPrefix))
 	}
-	_, songs, err := q.fetchPlaylistDetail(id)
+	_, songs, err := q.fetchPlaylistDetail(ctx, id)
 	return songs, err
 }
 
 // ParsePlaylist parses a playlist link.
 func (q *QQ) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
+	return q.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext is like ParsePlaylist but carries ctx through its requests.
+func (q *QQ) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	// Example: https://y.qq.com/n/ryqq/playlist/8825279434
 	re := regexp.MustCompile(`playlist/(\d+)`)
 	matches := re.FindStringSubmatch(link)
@@ -315,11 +365,16 @@ func (q *QQ) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	}
 	dissid := matches[1]
 
-	return q.fetchPlaylistDetail(dissid)
+	return q.fetchPlaylistDetail(ctx, dissid)
 }
 
 // GetRecommendedPlaylists returns QQ Music recommended playlists.
 func (q *QQ) GetRecommendedPlaylists() ([]model.Playlist, error) {
+	return q.GetRecommendedPlaylistsContext(context.Background())
+}
+
+// GetRecommendedPlaylistsContext is like GetRecommendedPlaylists but carries ctx through its requests.
+func (q *QQ) GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
 	// Build the musicu.fcg request body.
 	reqData := map[string]interface{}{
 		"comm": map[string]interface{}{
@@ -345,7 +400,7 @@ func (q *QQ) GetRecommendedPlaylists() ([]model.Playlist, error) {
 		utils.WithRandomIPHeader(),
 	}
 
-	body, err := utils.Post("https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
+	body, err := q.client.PostContext(ctx, "https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(jsonData), headers...)
 	if err != nil {
 		return nil, err
 	}
@@ -373,7 +428,7 @@ func (q *QQ) GetRecommendedPlaylists() ([]model.Playlist, error) {
 	}
 
 	if resp.Code != 0 {
-		return nil, fmt.Errorf("qq api error code: %d", resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "qq api error code: %d", resp.Code)
 	}
 
 	var playlists []model.Playlist
@@ -0,0 +1,205 @@
+package qq
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func teaEncryptBlock(dst, src []byte, k [4]uint32) {
+	const delta = 0x9E3779B9
+	v0 := binary.BigEndian.Uint32(src)
+	v1 := binary.BigEndian.Uint32(src[4:])
+	var sum uint32
+	for i := 0; i < 16; i++ {
+		sum += delta
+		v0 += ((v1 << 4) + k[0]) ^ (v1 + sum) ^ ((v1 >> 5) + k[1])
+		v1 += ((v0 << 4) + k[2]) ^ (v0 + sum) ^ ((v0 >> 5) + k[3])
+	}
+	binary.BigEndian.PutUint32(dst, v0)
+	binary.BigEndian.PutUint32(dst[4:], v1)
+}
+
+// encryptTencentTEA 是 decryptTencentTEA 的逆运算，填充和盐使用固定值
+func encryptTencentTEA(data, key []byte) []byte {
+	var k [4]uint32
+	for i := range k {
+		k[i] = binary.BigEndian.Uint32(key[i*4:])
+	}
+	padLen := (8 - (len(data)+10)%8) % 8
+	plain := []byte{0xA8 | byte(padLen)}
+	plain = append(plain, bytes.Repeat([]byte{0x5A}, padLen+2)...)
+	plain = append(plain, data...)
+	plain = append(plain, make([]byte, 7)...)
+
+	out := make([]byte, len(plain))
+	prevX := make([]byte, 8)
+	prevC := make([]byte, 8)
+	for i := 0; i < len(plain); i += 8 {
+		x := make([]byte, 8)
+		for j := range x {
+			x[j] = plain[i+j] ^ prevC[j]
+		}
+		c := out[i : i+8]
+		teaEncryptBlock(c, x, k)
+		for j := range c {
+			c[j] ^= prevX[j]
+		}
+		prevX, prevC = x, c
+	}
+	return out
+}
+
+func makeEKey(key []byte, v2 bool) string {
+	teaKey := make([]byte, 16)
+	for i := 0; i < 8; i++ {
+		teaKey[i*2] = ekeySimpleKey[i]
+		teaKey[i*2+1] = key[i]
+	}
+	raw := append(append([]byte(nil), key[:8]...), encryptTencentTEA(key[8:], teaKey)...)
+	if v2 {
+		inner := encryptTencentTEA([]byte(base64.StdEncoding.EncodeToString(raw)), ekeyV2Key2)
+		raw = append([]byte("QQMusic EncV2,Key:"), encryptTencentTEA(inner, ekeyV2Key1)...)
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func testKey(n int) []byte {
+	key := make([]byte, n)
+	for i := range key {
+		key[i] = byte(i*7 + 13)
+	}
+	return key
+}
+
+func TestDecodeEKey(t *testing.T) {
+	for _, v2 := range []bool{false, true} {
+		key := testKey(512)
+		got, err := DecodeEKey(makeEKey(key, v2))
+		if err != nil {
+			t.Fatalf("DecodeEKey(v2=%v): %v", v2, err)
+		}
+		if !bytes.Equal(got, key) {
+			t.Fatalf("DecodeEKey(v2=%v) returned wrong key", v2)
+		}
+	}
+	if _, err := DecodeEKey("not base64!"); err == nil {
+		t.Fatal("expected error for invalid ekey")
+	}
+}
+
+func TestQQMaskMatchesSequentialIndex(t *testing.T) {
+	mask := newQQMask(defaultQQMask58, defaultQQSuper58A, defaultQQSuper58B)
+	data := make([]byte, 200000)
+	mask.decrypt(data, 0)
+
+	// 逐字节推进的原始实现
+	r, n := -1, -1
+	for i := range data {
+		r++
+		n++
+		if r == 32768 || (r > 32768 && (r+1)%32768 == 0) {
+			r++
+			n++
+		}
+		if n >= 128 {
+			n -= 128
+		}
+		if data[i] != mask.matrix128[n] {
+			t.Fatalf("mask mismatch at %d", i)
+		}
+	}
+}
+
+func beU32(v int) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(v))
+	return b
+}
+
+func buildQMCv2(plain []byte, cipher qqCipher, trailer []byte) []byte {
+	out := append([]byte(nil), plain...)
+	cipher.decrypt(out, 0)
+	return append(out, trailer...)
+}
+
+func TestDecryptQMCv2(t *testing.T) {
+	plain := append([]byte("fLaC"), bytes.Repeat([]byte("0123456789"), 3000)...)
+
+	for _, size := range []int{128, 512} {
+		key := testKey(size)
+		ekey := makeEKey(key, size > 300)
+		cipher, err := newQQKeyCipher(ekey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		qtag := []byte(ekey + ",12345,2")
+		qtag = append(qtag, beU32(len(qtag))...)
+		raw := make([]byte, 4)
+		binary.LittleEndian.PutUint32(raw, uint32(len(ekey)))
+		raw = append([]byte(ekey), raw...)
+
+		for name, trailer := range map[string][]byte{"QTag": append(qtag, "QTag"...), "raw": raw} {
+			encrypted := buildQMCv2(plain, cipher, trailer)
+
+			got, ext, err := DecryptQQ(encrypted, "mflac")
+			if err != nil || ext != "flac" || !bytes.Equal(got, plain) {
+				t.Fatalf("DecryptQQ(%d, %s) = %q, %v", size, name, ext, err)
+			}
+
+			r, ext, err := NewQQReader(bytes.NewReader(encrypted), "mflac")
+			if err != nil {
+				t.Fatalf("NewQQReader(%d, %s): %v", size, name, err)
+			}
+			if got, _ := io.ReadAll(iotest.HalfReader(r)); !bytes.Equal(got, plain) || ext != "flac" {
+				t.Fatalf("NewQQReader(%d, %s) stream mismatch", size, name)
+			}
+		}
+	}
+}
+
+func TestDecryptQMCv2MissingKey(t *testing.T) {
+	plain := append([]byte("OggS"), bytes.Repeat([]byte{1, 2, 3}, 1000)...)
+	ekey := makeEKey(testKey(256), false)
+	cipher, _ := newQQKeyCipher(ekey)
+
+	stag := []byte("12345,0,songmid,1")
+	stag = append(stag, beU32(len(stag))...)
+	stag = append(stag, "STag"...)
+
+	musicex := bytes.Repeat([]byte{0}, 0xC0-16)
+	musicex = append(musicex, 0xC0, 0, 0, 0, 1, 0, 0, 0)
+	musicex = append(musicex, "musicex\x00"...)
+
+	for name, trailer := range map[string][]byte{"STag": stag, "musicex": musicex} {
+		encrypted := buildQMCv2(plain, cipher, trailer)
+
+		if _, _, err := DecryptQQ(encrypted, "mgg"); !errors.Is(err, ErrEKeyMissing) {
+			t.Fatalf("%s: expected ErrEKeyMissing, got %v", name, err)
+		}
+		if _, _, err := NewQQReader(bytes.NewReader(encrypted), "mgg"); !errors.Is(err, ErrEKeyMissing) {
+			t.Fatalf("%s: expected ErrEKeyMissing from reader, got %v", name, err)
+		}
+
+		got, ext, err := DecryptQQWithKey(encrypted, "mgg", ekey)
+		if err != nil || ext != "ogg" || !bytes.Equal(got, plain) {
+			t.Fatalf("%s: DecryptQQWithKey = %q, %v (len %d, want %d)", name, ext, err, len(got), len(plain))
+		}
+		r, _, err := NewQQReaderWithKey(bytes.NewReader(encrypted), "mgg", ekey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := io.ReadAll(r); !bytes.Equal(got, plain) {
+			t.Fatalf("%s: NewQQReaderWithKey stream mismatch", name)
+		}
+
+		if _, _, err := DecryptQQWithKey(encrypted, "mgg", makeEKey(testKey(200), false)); err == nil {
+			t.Fatalf("%s: expected error for wrong ekey", name)
+		}
+	}
+}
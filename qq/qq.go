@@ -2,6 +2,7 @@ package qq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,19 +24,29 @@ const (
 type QQ struct {
 	cookie     string
 	isVipCache *bool
+	client     *utils.Client
 }
 
-func New(cookie string) *QQ { return &QQ{cookie: cookie} }
+func New(cookie string, opts ...utils.ClientOption) *QQ {
+	return &QQ{cookie: cookie, client: utils.NewClient(opts...)}
+}
 
 var defaultQQ = New("")
 
+// apiErrorKinds QQ 音乐接口 code 字段对应的错误分类
+var apiErrorKinds = map[int]error{
+	1000:   model.ErrAuthExpired, // 登录态失效
+	1001:   model.ErrAuthExpired,
+	104003: model.ErrVIPRequired, // 无播放权限
+}
+
 // joinQQNames joins artist names for display.
 func joinQQNames(names []string) string {
 	return strings.Join(names, ", ")
 }
 
 // fetchAlbumDetail returns album metadata and songs.
-func (q *QQ) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
+func (q *QQ) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	albumMID := strings.TrimSpace(id)
 	if albumMID == "" {
 		return nil, nil, errors.New("album id is empty")
@@ -64,7 +75,7 @@ func (q *QQ) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error)
 	}
 	detailJSON, _ := json.Marshal(detailReq)
 
-	detailBody, err := utils.Post("https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(detailJSON), headers...)
+	detailBody, err := q.client.PostContext(ctx, "https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(detailJSON), headers...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -97,7 +108,7 @@ func (q *QQ) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error)
 		return nil, nil, fmt.Errorf("qq album detail json parse error: %w", err)
 	}
 	if detailResp.Album.Code != 0 {
-		return nil, nil, fmt.Errorf("qq album detail api error code: %d", detailResp.Album.Code)
+		return nil, nil, model.CodeError(apiErrorKinds, detailResp.Album.Code, "qq album detail api error code: %d", detailResp.Album.Code)
 	}
 
 	info := detailResp.Album.Data.BasicInfo
@@ -105,7 +116,7 @@ func (q *QQ) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error)
 		albumMID = info.AlbumMid
 	}
 	if info.AlbumName == "" {
-		return nil, nil, errors.New("album not found")
+		return nil, nil, model.Errorf(model.ErrNotFound, "album not found")
 	}
 
 	artistNames := make([]string, 0, len(detailResp.Album.Data.Singer.SingerList))
@@ -138,7 +149,7 @@ func (q *QQ) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error)
 		}
 		songJSON, _ := json.Marshal(songReq)
 
-		songBody, err := utils.Post("https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(songJSON), headers...)
+		songBody, err := q.client.PostContext(ctx, "https://u.y.qq.com/cgi-bin/musicu.fcg", bytes.NewReader(songJSON), headers...)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -180,7 +191,7 @@ func (q *QQ) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error)
 			return nil, nil, fmt.Errorf("qq album songs json parse error: %w", err)
 		}
 		if songResp.Album.Code != 0 {
-			return nil, nil, fmt.Errorf("qq album songs api error code: %d", songResp.Album.Code)
+			return nil, nil, model.CodeError(apiErrorKinds, songResp.Album.Code, "qq album songs api error code: %d", songResp.Album.Code)
 		}
 
 		if totalNum == 0 {
@@ -279,7 +290,7 @@ func (q *QQ) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error)
 }
 
 // fetchPlaylistDetail returns playlist metadata and songs.
-func (q *QQ) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
+func (q *QQ) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	params := url.Values{}
 	params.Set("type", "1")
 	params.Set("json", "1")
@@ -334,7 +345,7 @@ func (q *QQ) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, erro
 	var lastErr error
 	for _, endpoint := range endpoints {
 		apiURL := endpoint + "?" + params.Encode()
-		body, err := utils.Get(apiURL,
+		body, err := q.client.GetContext(ctx, apiURL,
 			utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 			utils.WithHeader("Referer", "https://y.qq.com/"),
 			utils.WithHeader("Cookie", q.cookie),
@@ -389,14 +400,14 @@ func (q *QQ) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, erro
 			lastErr = nil
 			break
 		}
-		lastErr = fmt.Errorf("qq playlist detail api error: subcode=%d msg=%s", resp.Subcode, resp.Msg)
+		lastErr = model.CodeError(apiErrorKinds, resp.Subcode, "qq playlist detail api error: subcode=%d msg=%s", resp.Subcode, resp.Msg)
 	}
 
 	if len(resp.Cdlist) == 0 {
 		if lastErr != nil {
 			return nil, nil, lastErr
 		}
-		return nil, nil, errors.New("playlist not found (empty cdlist)")
+		return nil, nil, model.Errorf(model.ErrNotFound, "playlist not found (empty cdlist)")
 	}
 
 	info := resp.Cdlist[0]
@@ -461,13 +472,13 @@ func (q *QQ) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, erro
 }
 
 // fetchSongDetail loads song metadata by songmid.
-func (q *QQ) fetchSongDetail(songMID string) (*model.Song, error) {
+func (q *QQ) fetchSongDetail(ctx context.Context, songMID string) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("songmid", songMID)
 	params.Set("format", "json")
 
 	apiURL := "https://c.y.qq.com/v8/fcg-bin/fcg_play_single_song.fcg?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", SearchReferer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -498,7 +509,7 @@ func (q *QQ) fetchSongDetail(songMID string) (*model.Song, error) {
 	}
 
 	if len(resp.Data) == 0 {
-		return nil, errors.New("song detail not found")
+		return nil, model.Errorf(model.ErrNotFound, "song detail not found")
 	}
 
 	item := resp.Data[0]
@@ -528,13 +539,13 @@ func (q *QQ) fetchSongDetail(songMID string) (*model.Song, error) {
 	}, nil
 }
 
-func (q *QQ) fetchSongDetailByID(songID string) (*model.Song, error) {
+func (q *QQ) fetchSongDetailByID(ctx context.Context, songID string) (*model.Song, error) {
 	params := url.Values{}
 	params.Set("songid", songID)
 	params.Set("format", "json")
 
 	apiURL := "https://c.y.qq.com/v8/fcg-bin/fcg_play_single_song.fcg?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", SearchReferer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -563,7 +574,7 @@ func (q *QQ) fetchSongDetailByID(songID string) (*model.Song, error) {
 		return nil, fmt.Errorf("qq detail json parse error: %w", err)
 	}
 	if len(resp.Data) == 0 {
-		return nil, errors.New("song detail not found")
+		return nil, model.Errorf(model.ErrNotFound, "song detail not found")
 	}
 
 	item := resp.Data[0]
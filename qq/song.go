@@ -1,6 +1,7 @@
 package qq
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,10 +15,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultQQ.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultQQ.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultQQ.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultQQ.ParseContext(ctx, link)
+}
+
 // Search searches songs.
 func (q *QQ) Search(keyword string) ([]model.Song, error) {
+	return q.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but carries ctx through its requests.
+func (q *QQ) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("w", keyword)
 	params.Set("format", "json")
@@ -25,7 +39,7 @@ func (q *QQ) Search(keyword string) ([]model.Song, error) {
 	params.Set("n", "10")
 	apiURL := "http://c.y.qq.com/soso/fcgi-bin/search_for_qq_cp?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Referer", SearchReferer),
 		utils.WithHeader("Cookie", q.cookie),
@@ -65,7 +79,7 @@ func (q *QQ) Search(keyword string) ([]model.Song, error) {
 		return nil, fmt.Errorf("qq json parse error: %w", err)
 	}
 
-	isVip, _ := q.IsVipAccount()
+	isVip, _ := q.IsVipAccountContext(ctx)
 
 	var songs []model.Song
 	for _, item := range resp.Data.Song.List {
@@ -120,6 +134,11 @@ func (q *QQ) Search(keyword string) ([]model.Song, error) {
 
 // Parse parses a song link and enriches it with download info when possible.
 func (q *QQ) Parse(link string) (*model.Song, error) {
+	return q.ParseContext(context.Background(), link)
+}
+
+// ParseContext is like Parse but carries ctx through its requests.
+func (q *QQ) ParseContext(ctx context.Context, link string) (*model.Song, error) {
 	var songMID string
 
 	// Try songDetail/xxx format
@@ -133,11 +152,11 @@ func (q *QQ) Parse(link string) (*model.Song, error) {
 		if u, err := url.Parse(link); err == nil {
 			if id := u.Query().Get("songid"); id != "" {
 				// Numeric songid — fetch detail by ID
-				song, err := q.fetchSongDetailByID(id)
+				song, err := q.fetchSongDetailByID(ctx, id)
 				if err != nil {
 					return nil, err
 				}
-				if downloadURL, dlErr := q.GetDownloadURL(song); dlErr == nil {
+				if downloadURL, dlErr := q.GetDownloadURLContext(ctx, song); dlErr == nil {
 					song.URL = downloadURL
 				}
 				return song, nil
@@ -152,12 +171,12 @@ func (q *QQ) Parse(link string) (*model.Song, error) {
 		return nil, errors.New("invalid qq music link")
 	}
 
-	song, err := q.fetchSongDetail(songMID)
+	song, err := q.fetchSongDetail(ctx, songMID)
 	if err != nil {
 		return nil, err
 	}
 
-	downloadURL, err := q.GetDownloadURL(song)
+	downloadURL, err := q.GetDownloadURLContext(ctx, song)
 	if err == nil {
 		song.URL = downloadURL
 	}
@@ -1,6 +1,7 @@
 package qq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -15,12 +16,21 @@ func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultQQ.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultQQ.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 const qqFavoriteSongsPlaylistID = "profile:favorites"
 const qqProfileDirPlaylistPrefix = "profile:dir:"
 
 func (q *QQ) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return q.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (q *QQ) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	if strings.TrimSpace(q.cookie) == "" {
-		return nil, fmt.Errorf("qq user playlists require cookie")
+		return nil, model.Errorf(model.ErrAuthExpired, "qq user playlists require cookie")
 	}
 	if page < 1 {
 		page = 1
@@ -33,7 +43,7 @@ func (q *QQ) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	}
 	uin := normalizeQQUIN(q.cookie)
 	if uin == "" {
-		return nil, fmt.Errorf("qq user playlists require uin cookie")
+		return nil, model.Errorf(model.ErrAuthExpired, "qq user playlists require uin cookie")
 	}
 	playlists := make([]model.Playlist, 0)
 	seen := make(map[string]bool)
@@ -47,7 +57,7 @@ func (q *QQ) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 		playlists = append(playlists, playlist)
 	}
 
-	if favorite, err := q.fetchFavoriteSongsPlaylistSummary(uin); err == nil && favorite.TrackCount > 0 {
+	if favorite, err := q.fetchFavoriteSongsPlaylistSummary(ctx, uin); err == nil && favorite.TrackCount > 0 {
 		addPlaylist(favorite)
 	}
 
@@ -59,7 +69,7 @@ func (q *QQ) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	params.Set("inCharset", "utf8")
 	params.Set("outCharset", "utf-8")
 	apiURL := "https://c.y.qq.com/rsc/fcgi-bin/fcg_user_created_diss?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 		utils.WithHeader("Referer", "https://y.qq.com/"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -105,7 +115,7 @@ func (q *QQ) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 		return nil, fmt.Errorf("qq user playlist json parse error: %w", err)
 	}
 	if resp.Code != 0 {
-		return nil, fmt.Errorf("qq user playlist api error: %s (code %d)", resp.Message, resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "qq user playlist api error: %s (code %d)", resp.Message, resp.Code)
 	}
 
 	for _, item := range resp.Data.DissList {
@@ -181,7 +191,7 @@ func (q *QQ) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 			},
 		})
 	}
-	if collected, err := q.fetchProfileOrderPlaylists(uin, page, limit); err == nil {
+	if collected, err := q.fetchProfileOrderPlaylists(ctx, uin, page, limit); err == nil {
 		for _, playlist := range collected {
 			addPlaylist(playlist)
 		}
@@ -189,8 +199,8 @@ func (q *QQ) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return playlists, nil
 }
 
-func (q *QQ) fetchFavoriteSongsPlaylistSummary(uin string) (model.Playlist, error) {
-	total, songs, err := q.fetchProfileOrderSongs(uin, 1, 1)
+func (q *QQ) fetchFavoriteSongsPlaylistSummary(ctx context.Context, uin string) (model.Playlist, error) {
+	total, songs, err := q.fetchProfileOrderSongs(ctx, uin, 1, 1)
 	if err != nil {
 		return model.Playlist{}, err
 	}
@@ -214,10 +224,10 @@ func (q *QQ) fetchFavoriteSongsPlaylistSummary(uin string) (model.Playlist, erro
 	}, nil
 }
 
-func (q *QQ) fetchProfileOrderPlaylists(uin string, page, limit int) ([]model.Playlist, error) {
+func (q *QQ) fetchProfileOrderPlaylists(ctx context.Context, uin string, page, limit int) ([]model.Playlist, error) {
 	params := q.profileOrderAssetParams(uin, "3", page, limit)
 	apiURL := "https://c.y.qq.com/fav/fcgi-bin/fcg_get_profile_order_asset.fcg?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 		utils.WithHeader("Referer", "https://y.qq.com/"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -246,7 +256,7 @@ func (q *QQ) fetchProfileOrderPlaylists(uin string, page, limit int) ([]model.Pl
 		return nil, fmt.Errorf("qq profile playlist json parse error: %w", err)
 	}
 	if resp.Code != 0 {
-		return nil, fmt.Errorf("qq profile playlist api error: %s (code %d)", resp.Msg, resp.Code)
+		return nil, model.CodeError(apiErrorKinds, resp.Code, "qq profile playlist api error: %s (code %d)", resp.Msg, resp.Code)
 	}
 
 	playlists := make([]model.Playlist, 0, len(resp.Data.CDList))
@@ -277,10 +287,10 @@ func (q *QQ) fetchProfileOrderPlaylists(uin string, page, limit int) ([]model.Pl
 	return playlists, nil
 }
 
-func (q *QQ) fetchProfileOrderSongs(uin string, page, limit int) (int, []model.Song, error) {
+func (q *QQ) fetchProfileOrderSongs(ctx context.Context, uin string, page, limit int) (int, []model.Song, error) {
 	params := q.profileOrderAssetParams(uin, "1", page, limit)
 	apiURL := "https://c.y.qq.com/fav/fcgi-bin/fcg_get_profile_order_asset.fcg?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"),
 		utils.WithHeader("Referer", "https://y.qq.com/"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -317,7 +327,7 @@ func (q *QQ) fetchProfileOrderSongs(uin string, page, limit int) (int, []model.S
 		return 0, nil, fmt.Errorf("qq profile songs json parse error: %w", err)
 	}
 	if resp.Code != 0 {
-		return 0, nil, fmt.Errorf("qq profile songs api error: %s (code %d)", resp.Msg, resp.Code)
+		return 0, nil, model.CodeError(apiErrorKinds, resp.Code, "qq profile songs api error: %s (code %d)", resp.Msg, resp.Code)
 	}
 
 	songs := make([]model.Song, 0, len(resp.Data.SongList))
@@ -330,14 +340,14 @@ func (q *QQ) fetchProfileOrderSongs(uin string, page, limit int) (int, []model.S
 	return resp.Data.TotalSong, songs, nil
 }
 
-func (q *QQ) fetchProfileDirPlaylistSongs(dirID string) ([]model.Song, error) {
+func (q *QQ) fetchProfileDirPlaylistSongs(ctx context.Context, dirID string) ([]model.Song, error) {
 	dirID = strings.TrimSpace(dirID)
 	if dirID == "" {
 		return nil, fmt.Errorf("qq profile dir playlist require dirid")
 	}
 	uin := firstNonEmptyQQ(qqCookieValue(q.cookie, "euin"), qqCookieValue(q.cookie, "wxuin"), normalizeQQUIN(q.cookie))
 	if uin == "" {
-		return nil, fmt.Errorf("qq profile dir playlist require uin cookie")
+		return nil, model.Errorf(model.ErrAuthExpired, "qq profile dir playlist require uin cookie")
 	}
 
 	params := url.Values{}
@@ -353,7 +363,7 @@ func (q *QQ) fetchProfileDirPlaylistSongs(dirID string) ([]model.Song, error) {
 	params.Set("format", "json")
 	params.Set("g_tk", "5381")
 	apiURL := "http://s.plcloud.music.qq.com/fcgi-bin/fcg_musiclist_getinfo.fcg?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := q.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"),
 		utils.WithHeader("Referer", "https://y.qq.com/w/myalbum.html"),
 		utils.WithHeader("Cookie", q.cookie),
@@ -0,0 +1,19 @@
+// Package all 导入全部平台包，使其注册到 registry。
+//
+//	import _ "github.com/guohuiyuan/music-lib/registry/all"
+package all
+
+import (
+	_ "github.com/guohuiyuan/music-lib/apple"
+	_ "github.com/guohuiyuan/music-lib/bilibili"
+	_ "github.com/guohuiyuan/music-lib/fivesing"
+	_ "github.com/guohuiyuan/music-lib/jamendo"
+	_ "github.com/guohuiyuan/music-lib/joox"
+	_ "github.com/guohuiyuan/music-lib/kugou"
+	_ "github.com/guohuiyuan/music-lib/kuwo"
+	_ "github.com/guohuiyuan/music-lib/migu"
+	_ "github.com/guohuiyuan/music-lib/netease"
+	_ "github.com/guohuiyuan/music-lib/qianqian"
+	_ "github.com/guohuiyuan/music-lib/qq"
+	_ "github.com/guohuiyuan/music-lib/soda"
+)
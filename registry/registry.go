@@ -0,0 +1,147 @@
+// Package registry 将 model.Song.Source 等来源名称映射回对应的平台实现。
+//
+// 各平台包在 init 中调用 Register 注册自己，调用方只需导入平台包
+// (或一次性导入 registry/all)，即可通过名称创建实例：
+//
+//	p, err := registry.Get("qq", cookie)
+//	if albums, ok := p.(provider.AlbumProvider); ok { ... }
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+// Constructor 创建平台实例，签名与各平台的 New 一致
+type Constructor func(cookie string, opts ...utils.ClientOption) interface{}
+
+// Entry 描述一个已注册的平台
+type Entry struct {
+	// Name 与 model.Song.Source 一致，例如 "netease"、"qq"
+	Name string
+	// New 创建平台实例
+	New Constructor
+	// Capabilities 平台真正可用的能力，运行时返回 Unsupported 的接口不在其中
+	Capabilities []provider.Capability
+	// Descriptor 与 README 支持矩阵对应的功能描述，包含不稳定标记
+	Descriptor provider.Descriptor
+}
+
+// Supports 判断平台是否声明了某项能力
+func (e Entry) Supports(c provider.Capability) bool {
+	for _, item := range e.Capabilities {
+		if item == c {
+			return true
+		}
+	}
+	return false
+}
+
+var registry = struct {
+	sync.RWMutex
+	entries map[string]Entry
+}{entries: make(map[string]Entry)}
+
+// Register 注册平台。名称重复、构造函数为空或声明了未实现的能力时会 panic，
+// 这些都属于编程错误，应在 init 阶段暴露出来。
+func Register(e Entry) {
+	if e.Name == "" || e.New == nil {
+		panic("registry: Register requires name and constructor")
+	}
+	probe := e.New("")
+	for _, c := range e.Capabilities {
+		if !provider.Implements(probe, c) {
+			panic(fmt.Sprintf("registry: %s declares %q but does not implement it", e.Name, c))
+		}
+		for _, f := range c.Features() {
+			if !e.Descriptor.Available(f) {
+				panic(fmt.Sprintf("registry: %s declares %q but descriptor marks %q unsupported", e.Name, c, f))
+			}
+		}
+	}
+	if e.Descriptor.Source == "" {
+		e.Descriptor.Source = e.Name
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.entries[e.Name]; dup {
+		panic("registry: Register called twice for " + e.Name)
+	}
+	e.Capabilities = append([]provider.Capability(nil), e.Capabilities...)
+	registry.entries[e.Name] = e
+}
+
+// Lookup 返回已注册的平台信息
+func Lookup(name string) (Entry, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	e, ok := registry.entries[name]
+	return e, ok
+}
+
+// Get 按名称创建平台实例，返回值可以断言为 provider 包中的各接口
+func Get(name, cookie string, opts ...utils.ClientOption) (interface{}, error) {
+	e, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("registry: unknown source %q", name)
+	}
+	return e.New(cookie, opts...), nil
+}
+
+// Describe 返回平台的能力描述
+func Describe(name string) (provider.Descriptor, bool) {
+	e, ok := Lookup(name)
+	if !ok {
+		return provider.Descriptor{}, false
+	}
+	d := e.Descriptor
+	// 复制一份，避免调用方修改注册表中的数据
+	d.Features = make(map[provider.Feature]provider.FeatureSupport, len(e.Descriptor.Features))
+	for f, fs := range e.Descriptor.Features {
+		d.Features[f] = fs
+	}
+	return d, true
+}
+
+// Descriptors 返回所有已注册平台的能力描述 (按名称排序)，可直接序列化为 JSON 供 UI 渲染
+func Descriptors() []provider.Descriptor {
+	names := Names()
+	descriptors := make([]provider.Descriptor, 0, len(names))
+	for _, name := range names {
+		if d, ok := Describe(name); ok {
+			descriptors = append(descriptors, d)
+		}
+	}
+	return descriptors
+}
+
+// Names 返回所有已注册平台名称 (按字母排序)
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.entries))
+	for name := range registry.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Supporting 返回声明了某项能力的平台名称 (按字母排序)
+func Supporting(c provider.Capability) []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	var names []string
+	for name, e := range registry.entries {
+		if e.Supports(c) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/qq"
+	"github.com/guohuiyuan/music-lib/registry"
+	_ "github.com/guohuiyuan/music-lib/registry/all"
+)
+
+func TestRegistryCoversAllPlatforms(t *testing.T) {
+	want := []string{"apple", "bilibili", "fivesing", "jamendo", "joox", "kugou", "kuwo", "migu", "netease", "qianqian", "qq", "soda"}
+	if got := registry.Names(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("registered sources = %v, want %v", got, want)
+	}
+
+	for _, name := range want {
+		p, err := registry.Get(name, "")
+		if err != nil {
+			t.Fatalf("Get(%s) failed: %v", name, err)
+		}
+		if _, ok := p.(provider.MusicProvider); !ok {
+			t.Errorf("%s does not implement provider.MusicProvider", name)
+		}
+	}
+}
+
+func TestRegistryGetReturnsTypedProvider(t *testing.T) {
+	p, err := registry.Get("qq", "uin=1")
+	if err != nil {
+		t.Fatalf("Get(qq) failed: %v", err)
+	}
+	if _, ok := p.(*qq.QQ); !ok {
+		t.Fatalf("expected *qq.QQ, got %T", p)
+	}
+	if _, ok := p.(provider.QRLoginProvider); !ok {
+		t.Fatal("qq should implement provider.QRLoginProvider")
+	}
+
+	if _, err := registry.Get("unknown", ""); err == nil {
+		t.Fatal("expected error for unknown source")
+	}
+}
+
+func TestRegistryCapabilities(t *testing.T) {
+	want := []string{"bilibili", "kugou", "netease", "qq"}
+	if got := registry.Supporting(provider.CapQRLogin); !reflect.DeepEqual(got, want) {
+		t.Fatalf("qr login sources = %v, want %v", got, want)
+	}
+
+	entry, ok := registry.Lookup("fivesing")
+	if !ok {
+		t.Fatal("fivesing not registered")
+	}
+	if entry.Supports(provider.CapAlbum) || entry.Supports(provider.CapPlaylistCategory) {
+		t.Fatalf("fivesing should not declare album or category support: %v", entry.Capabilities)
+	}
+}
+
+func TestRegistryDescriptorsMirrorSupportMatrix(t *testing.T) {
+	descriptors := registry.Descriptors()
+	if len(descriptors) != 12 {
+		t.Fatalf("expected 12 descriptors, got %d", len(descriptors))
+	}
+	for _, d := range descriptors {
+		for _, f := range provider.Features() {
+			if _, ok := d.Features[f]; !ok {
+				t.Errorf("%s descriptor missing feature %s", d.Source, f)
+			}
+		}
+	}
+
+	cases := []struct {
+		source  string
+		feature provider.Feature
+		want    provider.Support
+	}{
+		{"qianqian", provider.FeaturePlaylistSearch, provider.Unstable},
+		{"jamendo", provider.FeaturePlaylistSearch, provider.Unstable},
+		{"apple", provider.FeatureDownload, provider.Unstable},
+		{"soda", provider.FeatureQRLogin, provider.Unstable},
+		{"migu", provider.FeaturePlaylistRecommend, provider.Unsupported},
+		{"bilibili", provider.FeatureAlbumSearch, provider.Unsupported},
+		{"netease", provider.FeatureUserPlaylist, provider.Supported},
+	}
+	for _, tc := range cases {
+		d, ok := registry.Describe(tc.source)
+		if !ok {
+			t.Fatalf("%s not registered", tc.source)
+		}
+		if got := d.Support(tc.feature); got != tc.want {
+			t.Errorf("%s %s = %s, want %s", tc.source, tc.feature, got, tc.want)
+		}
+	}
+}
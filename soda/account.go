@@ -1,6 +1,7 @@
 package soda
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -9,7 +10,16 @@ import (
 
 func IsVipAccount() (bool, error) { return defaultSoda.IsVipAccount() }
 
+func IsVipAccountContext(ctx context.Context) (bool, error) {
+	return defaultSoda.IsVipAccountContext(ctx)
+}
+
 func (s *Soda) IsVipAccount() (bool, error) {
+	return s.IsVipAccountContext(context.Background())
+}
+
+// IsVipAccountContext is like IsVipAccount but carries ctx through its requests.
+func (s *Soda) IsVipAccountContext(ctx context.Context) (bool, error) {
 	if s.isVipCache != nil {
 		return *s.isVipCache, nil
 	}
@@ -20,7 +30,7 @@ func (s *Soda) IsVipAccount() (bool, error) {
 		return false, nil
 	}
 
-	info, err := s.GetDownloadInfo(&model.Song{
+	info, err := s.GetDownloadInfoContext(ctx, &model.Song{
 		ID:     vipProbeTrackID,
 		Source: "soda",
 		Link:   vipProbeTrackURL,
@@ -1,6 +1,7 @@
 package soda
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,16 +16,33 @@ func SearchAlbum(keyword string) ([]model.Playlist, error) {
 	return defaultSoda.SearchAlbum(keyword)
 }
 
+func SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultSoda.SearchAlbumContext(ctx, keyword)
+}
+
 func GetAlbumSongs(id string) ([]model.Song, error) {
 	return defaultSoda.GetAlbumSongs(id)
 }
 
+func GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultSoda.GetAlbumSongsContext(ctx, id)
+}
+
 func ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
 	return defaultSoda.ParseAlbum(link)
 }
 
+func ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultSoda.ParseAlbumContext(ctx, link)
+}
+
 // SearchAlbum 搜索专辑 (PC API)
 func (s *Soda) SearchAlbum(keyword string) ([]model.Playlist, error) {
+	return s.SearchAlbumContext(context.Background(), keyword)
+}
+
+// SearchAlbumContext 与 SearchAlbum 相同，但请求受 ctx 控制。
+func (s *Soda) SearchAlbumContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("q", keyword)
 	params.Set("cursor", "0")
@@ -34,7 +52,7 @@ func (s *Soda) SearchAlbum(keyword string) ([]model.Playlist, error) {
 	params.Set("channel", "pc_web")
 
 	apiURL := "https://api.qishui.com/luna/pc/search/album?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := s.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -99,15 +117,25 @@ func (s *Soda) SearchAlbum(keyword string) ([]model.Playlist, error) {
 
 // GetAlbumSongs 获取专辑所有歌曲
 func (s *Soda) GetAlbumSongs(id string) ([]model.Song, error) {
-	_, songs, err := s.fetchAlbumDetail(id)
+	return s.GetAlbumSongsContext(context.Background(), id)
+}
+
+// GetAlbumSongsContext 与 GetAlbumSongs 相同，但请求受 ctx 控制。
+func (s *Soda) GetAlbumSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := s.fetchAlbumDetail(ctx, id)
 	return songs, err
 }
 
 // ParseAlbum 解析专辑链接
 func (s *Soda) ParseAlbum(link string) (*model.Playlist, []model.Song, error) {
+	return s.ParseAlbumContext(context.Background(), link)
+}
+
+// ParseAlbumContext 与 ParseAlbum 相同，但请求受 ctx 控制。
+func (s *Soda) ParseAlbumContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
 	albumID := sodaExtractAlbumID(link)
 	if albumID == "" {
 		return nil, nil, errors.New("invalid soda album link")
 	}
-	return s.fetchAlbumDetail(albumID)
+	return s.fetchAlbumDetail(ctx, albumID)
 }
@@ -1,6 +1,7 @@
 package soda
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
@@ -8,105 +9,238 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"io"
+
+	"github.com/guohuiyuan/music-lib/mp4"
 )
 
 // DecryptAudio 核心解密函数
 func DecryptAudio(fileData []byte, playAuth string) ([]byte, error) {
-	hexKey, err := extractKey(playAuth)
+	block, err := sodaCipher(playAuth)
 	if err != nil {
 		return nil, err
 	}
-	keyBytes, err := hex.DecodeString(hexKey)
+	return decryptAudio(fileData, block)
+}
+
+func decryptAudio(fileData []byte, block cipher.Block) ([]byte, error) {
+	moov, err := mp4.FindBox(fileData, "moov", 0, len(fileData))
+	if err != nil {
+		return nil, errors.New("moov box not found")
+	}
+	track, err := parseSodaTrack(fileData, moov)
 	if err != nil {
 		return nil, err
 	}
 
-	moov, err := findBox(fileData, "moov", 0, len(fileData))
+	mdat, err := mp4.FindBox(fileData, "mdat", 0, len(fileData))
 	if err != nil {
-		return nil, errors.New("moov box not found")
+		return nil, errors.New("mdat box not found")
 	}
 
-	stbl, err := findBox(fileData, "stbl", moov.offset, moov.offset+moov.size)
-	if err != nil {
-		trak, _ := findBox(fileData, "trak", moov.offset+8, moov.offset+moov.size)
-		if trak != nil {
-			mdia, _ := findBox(fileData, "mdia", trak.offset+8, trak.offset+trak.size)
-			if mdia != nil {
-				minf, _ := findBox(fileData, "minf", mdia.offset+8, mdia.offset+mdia.size)
-				if minf != nil {
-					stbl, _ = findBox(fileData, "stbl", minf.offset+8, minf.offset+minf.size)
-				}
-			}
+	decryptedData := make([]byte, len(fileData))
+	copy(decryptedData, fileData)
+
+	readPtr := mdat.Offset + 8
+	decryptedMdat := make([]byte, 0, mdat.Size-8)
+
+	for i := 0; i < len(track.sampleSizes); i++ {
+		size := int(track.sampleSizes[i])
+		if readPtr+size > len(decryptedData) {
+			break
+		}
+		chunk := decryptedData[readPtr : readPtr+size]
+
+		if i < len(track.sencSamples) {
+			dst := decryptSencSample(block, chunk, track.sencSamples[i])
+			decryptedMdat = append(decryptedMdat, dst...)
+		} else {
+			decryptedMdat = append(decryptedMdat, chunk...)
 		}
+		readPtr += size
 	}
-	if stbl == nil {
-		return nil, errors.New("stbl box not found")
+
+	if len(decryptedMdat) == int(mdat.Size)-8 {
+		copy(decryptedData[mdat.Offset+8:], decryptedMdat)
+	} else {
+		return nil, errors.New("decrypted size mismatch")
 	}
 
-	stsz, err := findBox(fileData, "stsz", stbl.offset+8, stbl.offset+stbl.size)
+	restoreSampleEntry(decryptedData, track.stbl)
+	return decryptedData, nil
+}
+
+// NewDecryptReader 边读边解密汽水音乐的音频。
+// moov 位于 mdat 之前时只缓存文件头，之后逐个 sample 解密；否则退回到整体读入后调用 DecryptAudio。
+func NewDecryptReader(r io.Reader, playAuth string) (io.Reader, error) {
+	block, err := sodaCipher(playAuth)
 	if err != nil {
-		return nil, errors.New("stsz box not found")
+		return nil, err
 	}
-	sampleSizes := parseStsz(stsz.data)
+	return newDecryptReader(r, block)
+}
 
-	senc, err := findBox(fileData, "senc", moov.offset+8, moov.offset+moov.size)
-	if err != nil {
-		senc, err = findBox(fileData, "senc", stbl.offset+8, stbl.offset+stbl.size)
+func newDecryptReader(r io.Reader, block cipher.Block) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	var header []byte
+	for {
+		head, _ := br.Peek(16)
+		boxType, size, headerSize, ok := mp4.Header(head)
+		if !ok {
+			return nil, errors.New("mdat box not found")
+		}
+		if boxType == "mdat" {
+			if _, err := mp4.FindBox(header, "moov", 0, len(header)); err != nil || size < uint64(headerSize) {
+				break
+			}
+			mdatHead := append([]byte(nil), head[:headerSize]...)
+			br.Discard(headerSize)
+			return newSodaStream(block, br, header, mdatHead, size-uint64(headerSize))
+		}
+		if size == 0 || size > sodaMaxHeaderBox {
+			break
+		}
+		box := make([]byte, size)
+		if _, err := io.ReadFull(br, box); err != nil {
+			return nil, err
+		}
+		header = append(header, box...)
 	}
+
+	// moov 在 mdat 之后，只能整体解密
+	rest, err := io.ReadAll(br)
 	if err != nil {
-		return nil, errors.New("senc box not found")
+		return nil, err
 	}
-	sencSamples := parseSenc(senc.data, defaultPerSampleIVSize(fileData, stbl.offset, stbl.offset+stbl.size))
-
-	mdat, err := findBox(fileData, "mdat", 0, len(fileData))
+	plain, err := decryptAudio(append(header, rest...), block)
 	if err != nil {
-		return nil, errors.New("mdat box not found")
+		return nil, err
 	}
+	return bytes.NewReader(plain), nil
+}
 
-	block, err := aes.NewCipher(keyBytes)
+// sodaMaxHeaderBox 限制 mdat 之前单个 box 的大小，超过时按 moov 在后处理
+const sodaMaxHeaderBox = 64 << 20
+
+func newSodaStream(block cipher.Block, r io.Reader, header, mdatHead []byte, mdatSize uint64) (io.Reader, error) {
+	moov, _ := mp4.FindBox(header, "moov", 0, len(header))
+	track, err := parseSodaTrack(header, moov)
 	if err != nil {
 		return nil, err
 	}
+	var total uint64
+	for _, size := range track.sampleSizes {
+		total += uint64(size)
+	}
+	if total != mdatSize {
+		return nil, errors.New("decrypted size mismatch")
+	}
+	restoreSampleEntry(header, track.stbl)
 
-	decryptedData := make([]byte, len(fileData))
-	copy(decryptedData, fileData)
+	head := append(header, mdatHead...)
+	samples := &sodaSampleReader{r: r, block: block, track: track}
+	return io.MultiReader(bytes.NewReader(head), samples, r), nil
+}
 
-	readPtr := mdat.offset + 8
-	decryptedMdat := make([]byte, 0, mdat.size-8)
+// sodaSampleReader 每次读取并解密一个 sample
+type sodaSampleReader struct {
+	r     io.Reader
+	block cipher.Block
+	track *sodaCryptoTrack
+	next  int
+	buf   []byte
+}
 
-	for i := 0; i < len(sampleSizes); i++ {
-		size := int(sampleSizes[i])
-		if readPtr+size > len(decryptedData) {
-			break
+func (s *sodaSampleReader) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.next >= len(s.track.sampleSizes) {
+			return 0, io.EOF
 		}
-		chunk := decryptedData[readPtr : readPtr+size]
-
-		if i < len(sencSamples) {
-			dst := decryptSencSample(block, chunk, sencSamples[i])
-			decryptedMdat = append(decryptedMdat, dst...)
-		} else {
-			decryptedMdat = append(decryptedMdat, chunk...)
+		chunk := make([]byte, s.track.sampleSizes[s.next])
+		if _, err := io.ReadFull(s.r, chunk); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
 		}
-		readPtr += size
+		if s.next < len(s.track.sencSamples) {
+			chunk = decryptSencSample(s.block, chunk, s.track.sencSamples[s.next])
+		}
+		s.buf = chunk
+		s.next++
 	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
 
-	if len(decryptedMdat) == int(mdat.size)-8 {
-		copy(decryptedData[mdat.offset+8:], decryptedMdat)
-	} else {
-		return nil, errors.New("decrypted size mismatch")
+func sodaCipher(playAuth string) (cipher.Block, error) {
+	hexKey, err := extractKey(playAuth)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, err
 	}
+	return aes.NewCipher(keyBytes)
+}
+
+// sodaCryptoTrack 是解密所需的轨道信息
+type sodaCryptoTrack struct {
+	stbl        *mp4.Box
+	sampleSizes []uint32
+	sencSamples []sodaSencSample
+}
 
-	stsd, err := findBox(fileData, "stsd", stbl.offset+8, stbl.offset+stbl.size)
-	if err == nil {
-		stsdOffset := stsd.offset
-		stsdData := decryptedData[stsdOffset : stsdOffset+stsd.size]
-		if idx := bytes.Index(stsdData, []byte("enca")); idx != -1 {
-			copy(stsdData[idx:], encryptedSampleOriginalFormat(stsdData))
-			copy(decryptedData[stsdOffset:], stsdData)
+func parseSodaTrack(fileData []byte, moov *mp4.Box) (*sodaCryptoTrack, error) {
+	stbl, err := mp4.FindBox(fileData, "stbl", moov.Offset, moov.Offset+moov.Size)
+	if err != nil {
+		trak, _ := mp4.FindBox(fileData, "trak", moov.Offset+8, moov.Offset+moov.Size)
+		if trak != nil {
+			mdia, _ := mp4.FindBox(fileData, "mdia", trak.Offset+8, trak.Offset+trak.Size)
+			if mdia != nil {
+				minf, _ := mp4.FindBox(fileData, "minf", mdia.Offset+8, mdia.Offset+mdia.Size)
+				if minf != nil {
+					stbl, _ = mp4.FindBox(fileData, "stbl", minf.Offset+8, minf.Offset+minf.Size)
+				}
+			}
 		}
 	}
+	if stbl == nil {
+		return nil, errors.New("stbl box not found")
+	}
 
-	return decryptedData, nil
+	stsz, err := mp4.FindBox(fileData, "stsz", stbl.Offset+8, stbl.Offset+stbl.Size)
+	if err != nil {
+		return nil, errors.New("stsz box not found")
+	}
+
+	senc, err := mp4.FindBox(fileData, "senc", moov.Offset+8, moov.Offset+moov.Size)
+	if err != nil {
+		senc, err = mp4.FindBox(fileData, "senc", stbl.Offset+8, stbl.Offset+stbl.Size)
+	}
+	if err != nil {
+		return nil, errors.New("senc box not found")
+	}
+
+	return &sodaCryptoTrack{
+		stbl:        stbl,
+		sampleSizes: mp4.ParseStsz(stsz.Data),
+		sencSamples: parseSenc(senc.Data, defaultPerSampleIVSize(fileData, stbl.Offset, stbl.Offset+stbl.Size)),
+	}, nil
+}
+
+// restoreSampleEntry 把 stsd 中的 enca 还原为原始格式 (mp4a 等)
+func restoreSampleEntry(data []byte, stbl *mp4.Box) {
+	stsd, err := mp4.FindBox(data, "stsd", stbl.Offset+8, stbl.Offset+stbl.Size)
+	if err != nil {
+		return
+	}
+	stsdData := data[stsd.Offset : stsd.Offset+stsd.Size]
+	if idx := bytes.Index(stsdData, []byte("enca")); idx != -1 {
+		copy(stsdData[idx:], encryptedSampleOriginalFormat(stsdData))
+	}
 }
 
 func encryptedSampleOriginalFormat(stsdData []byte) []byte {
@@ -122,11 +256,11 @@ func encryptedSampleOriginalFormat(stsdData []byte) []byte {
 }
 
 func defaultPerSampleIVSize(data []byte, start, end int) int {
-	tenc, err := findBoxDeep(data, "tenc", start, end)
-	if err != nil || len(tenc.data) < 8 {
+	tenc, err := mp4.FindBoxDeep(data, "tenc", start, end)
+	if err != nil || len(tenc.Data) < 8 {
 		return 8
 	}
-	ivSize := int(tenc.data[7])
+	ivSize := int(tenc.Data[7])
 	if ivSize == 8 || ivSize == 16 {
 		return ivSize
 	}
@@ -187,103 +321,6 @@ func decryptSencSample(block cipher.Block, chunk []byte, sample sodaSencSample)
 	return dst
 }
 
-type mp4Box struct {
-	offset int
-	size   int
-	data   []byte
-}
-
-func findBox(data []byte, boxType string, start, end int) (*mp4Box, error) {
-	if end > len(data) {
-		end = len(data)
-	}
-	pos := start
-	target := []byte(boxType)
-	for pos+8 <= end {
-		size := int(binary.BigEndian.Uint32(data[pos : pos+4]))
-		if size < 8 {
-			break
-		}
-		if bytes.Equal(data[pos+4:pos+8], target) {
-			return &mp4Box{offset: pos, size: size, data: data[pos+8 : pos+size]}, nil
-		}
-		pos += size
-	}
-	return nil, errors.New("box not found")
-}
-
-func findBoxDeep(data []byte, boxType string, start, end int) (*mp4Box, error) {
-	if end > len(data) {
-		end = len(data)
-	}
-	pos := start
-	target := []byte(boxType)
-	for pos+8 <= end {
-		size := int(binary.BigEndian.Uint32(data[pos : pos+4]))
-		headerSize := 8
-		if size == 1 {
-			if pos+16 > end {
-				break
-			}
-			size64 := binary.BigEndian.Uint64(data[pos+8 : pos+16])
-			if size64 > uint64(end-pos) {
-				break
-			}
-			size = int(size64)
-			headerSize = 16
-		}
-		if size < headerSize || pos+size > end {
-			break
-		}
-		currentType := string(data[pos+4 : pos+8])
-		if bytes.Equal(data[pos+4:pos+8], target) {
-			return &mp4Box{offset: pos, size: size, data: data[pos+headerSize : pos+size]}, nil
-		}
-
-		if childStart, ok := boxChildStart(currentType, pos, headerSize); ok && childStart < pos+size {
-			if found, err := findBoxDeep(data, boxType, childStart, pos+size); err == nil {
-				return found, nil
-			}
-		}
-		pos += size
-	}
-	return nil, errors.New("box not found")
-}
-
-func boxChildStart(boxType string, offset, headerSize int) (int, bool) {
-	switch boxType {
-	case "moov", "trak", "mdia", "minf", "stbl", "sinf", "schi":
-		return offset + headerSize, true
-	case "stsd":
-		return offset + headerSize + 8, true
-	case "enca", "mp4a", "alac", "fLaC":
-		return offset + headerSize + 28, true
-	default:
-		return 0, false
-	}
-}
-
-func parseStsz(data []byte) []uint32 {
-	if len(data) < 12 {
-		return nil
-	}
-	sampleSizeFixed := binary.BigEndian.Uint32(data[4:8])
-	sampleCount := int(binary.BigEndian.Uint32(data[8:12]))
-	sizes := make([]uint32, sampleCount)
-	if sampleSizeFixed != 0 {
-		for i := 0; i < sampleCount; i++ {
-			sizes[i] = sampleSizeFixed
-		}
-	} else {
-		for i := 0; i < sampleCount; i++ {
-			if 12+i*4+4 <= len(data) {
-				sizes[i] = binary.BigEndian.Uint32(data[12+i*4 : 12+i*4+4])
-			}
-		}
-	}
-	return sizes
-}
-
 func parseSenc(data []byte, ivSize int) []sodaSencSample {
 	if len(data) < 8 {
 		return nil
@@ -0,0 +1,89 @@
+package soda
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func sodaTestBox(boxType string, data ...[]byte) []byte {
+	body := bytes.Join(data, nil)
+	out := make([]byte, 8, 8+len(body))
+	binary.BigEndian.PutUint32(out, uint32(8+len(body)))
+	copy(out[4:], boxType)
+	return append(out, body...)
+}
+
+// buildSodaEncrypted 生成一个用 block 加密的最小音频文件，返回密文和对应的明文
+func buildSodaEncrypted(block cipher.Block, moovFirst bool) ([]byte, []byte) {
+	samples := [][]byte{bytes.Repeat([]byte{1}, 40), bytes.Repeat([]byte{2}, 17), bytes.Repeat([]byte{3}, 33)}
+
+	stsz := make([]byte, 12+4*len(samples))
+	binary.BigEndian.PutUint32(stsz[8:], uint32(len(samples)))
+	senc := make([]byte, 8, 8+8*len(samples))
+	binary.BigEndian.PutUint32(senc[4:], uint32(len(samples)))
+	var plainMdat, encMdat []byte
+	for i, sample := range samples {
+		binary.BigEndian.PutUint32(stsz[12+4*i:], uint32(len(sample)))
+		iv := []byte{byte(i), 9, 8, 7, 6, 5, 4, 3}
+		senc = append(senc, iv...)
+		plainMdat = append(plainMdat, sample...)
+		encMdat = append(encMdat, decryptSencSample(block, sample, sodaSencSample{iv: iv})...)
+	}
+
+	build := func(sampleEntry string, mdat []byte) []byte {
+		entry := sodaTestBox(sampleEntry, make([]byte, 28), sodaTestBox("sinf", sodaTestBox("frma", []byte("mp4a"))))
+		stsd := sodaTestBox("stsd", make([]byte, 8), entry)
+		stbl := sodaTestBox("stbl", stsd, sodaTestBox("stsz", stsz), sodaTestBox("senc", senc))
+		moov := sodaTestBox("moov", sodaTestBox("trak", sodaTestBox("mdia", sodaTestBox("minf", stbl))))
+		ftyp := sodaTestBox("ftyp", []byte("M4A "))
+		if moovFirst {
+			return bytes.Join([][]byte{ftyp, moov, sodaTestBox("mdat", mdat)}, nil)
+		}
+		return bytes.Join([][]byte{ftyp, sodaTestBox("mdat", mdat), moov}, nil)
+	}
+	return build("enca", encMdat), build("mp4a", plainMdat)
+}
+
+func TestSodaDecryptReader(t *testing.T) {
+	block, _ := aes.NewCipher([]byte("0123456789abcdef"))
+	for _, moovFirst := range []bool{true, false} {
+		encrypted, want := buildSodaEncrypted(block, moovFirst)
+
+		plain, err := decryptAudio(encrypted, block)
+		if err != nil {
+			t.Fatalf("decryptAudio(moovFirst=%v): %v", moovFirst, err)
+		}
+		if !bytes.Equal(plain, want) {
+			t.Fatalf("decryptAudio(moovFirst=%v) mismatch", moovFirst)
+		}
+
+		r, err := newDecryptReader(iotest.OneByteReader(bytes.NewReader(encrypted)), block)
+		if err != nil {
+			t.Fatalf("newDecryptReader(moovFirst=%v): %v", moovFirst, err)
+		}
+		streamed, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		if !bytes.Equal(streamed, want) {
+			t.Fatalf("stream (moovFirst=%v) mismatch:\n got %x\nwant %x", moovFirst, streamed, want)
+		}
+	}
+}
+
+func TestSodaDecryptReaderTruncated(t *testing.T) {
+	block, _ := aes.NewCipher([]byte("0123456789abcdef"))
+	encrypted, _ := buildSodaEncrypted(block, true)
+	r, err := newDecryptReader(bytes.NewReader(encrypted[:len(encrypted)-5]), block)
+	if err != nil {
+		t.Fatalf("newDecryptReader: %v", err)
+	}
+	if _, err := io.ReadAll(r); err != io.ErrUnexpectedEOF {
+		t.Fatalf("err = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
@@ -1,36 +1,53 @@
 package soda
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
-	"os"
 	"strings"
 
+	"github.com/guohuiyuan/music-lib/download"
 	"github.com/guohuiyuan/music-lib/model"
 )
 
 func GetDownloadInfo(s *model.Song) (*DownloadInfo, error) { return defaultSoda.GetDownloadInfo(s) }
 
+func GetDownloadInfoContext(ctx context.Context, s *model.Song) (*DownloadInfo, error) {
+	return defaultSoda.GetDownloadInfoContext(ctx, s)
+}
+
 func GetDownloadURL(s *model.Song) (string, error) { return defaultSoda.GetDownloadURL(s) }
 
+func GetDownloadURLContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultSoda.GetDownloadURLContext(ctx, s)
+}
+
 func Download(s *model.Song, outputPath string) error { return defaultSoda.Download(s, outputPath) }
 
+func DownloadContext(ctx context.Context, s *model.Song, outputPath string) error {
+	return defaultSoda.DownloadContext(ctx, s, outputPath)
+}
+
 func (s *Soda) GetDownloadInfo(song *model.Song) (*DownloadInfo, error) {
+	return s.GetDownloadInfoContext(context.Background(), song)
+}
+
+// GetDownloadInfoContext 与 GetDownloadInfo 相同，但请求受 ctx 控制。
+func (s *Soda) GetDownloadInfoContext(ctx context.Context, song *model.Song) (*DownloadInfo, error) {
 	if song == nil {
 		return nil, errors.New("song is nil")
 	}
 
 	if song.Source != "" && song.Source != "soda" {
-		return nil, errors.New("source mismatch")
+		return nil, model.ErrSourceMismatch
 	}
 
 	trackID := sodaSongTrackID(song)
 	cachedInfo := sodaCachedDownloadInfo(song)
 	if trackID != "" {
-		info, err := s.resolveDownloadInfo(trackID, nil)
+		info, err := s.resolveDownloadInfo(ctx, trackID, nil)
 		if err == nil {
 			return info, nil
 		}
@@ -79,7 +96,7 @@ func sodaSongTrackID(song *model.Song) string {
 	return strings.TrimSpace(song.ID)
 }
 
-func (s *Soda) resolveDownloadInfo(trackID string, webResp *sodaTrackV2Response) (*DownloadInfo, error) {
+func (s *Soda) resolveDownloadInfo(ctx context.Context, trackID string, webResp *sodaTrackV2Response) (*DownloadInfo, error) {
 	trackID = strings.TrimSpace(trackID)
 	if trackID == "" {
 		return nil, errors.New("track id is empty")
@@ -87,7 +104,7 @@ func (s *Soda) resolveDownloadInfo(trackID string, webResp *sodaTrackV2Response)
 
 	var err error
 	if webResp == nil {
-		webResp, err = s.fetchWebTrackV2(trackID)
+		webResp, err = s.fetchWebTrackV2(ctx, trackID)
 		if err != nil {
 			return nil, err
 		}
@@ -103,7 +120,7 @@ func (s *Soda) resolveDownloadInfo(trackID string, webResp *sodaTrackV2Response)
 		webInfo = videoInfo
 	}
 	if (webInfo == nil || sodaDownloadInfoIsPreview(webInfo, fullDuration)) && webResp.TrackPlayer.URLPlayerInfo != "" {
-		webInfo, err = s.fetchPlayerInfo(webResp.TrackPlayer.URLPlayerInfo)
+		webInfo, err = s.fetchPlayerInfo(ctx, webResp.TrackPlayer.URLPlayerInfo)
 		if err != nil {
 			lastErr = err
 		}
@@ -111,7 +128,7 @@ func (s *Soda) resolveDownloadInfo(trackID string, webResp *sodaTrackV2Response)
 
 	webIsPreview := sodaDownloadInfoIsPreview(webInfo, fullDuration)
 	if strings.TrimSpace(s.cookie) != "" && (isVIPTrack || webIsPreview || !sodaDownloadInfoIsLossless(webInfo)) {
-		if pcResp, pcErr := s.fetchPCTrackV2(trackID); pcErr == nil {
+		if pcResp, pcErr := s.fetchPCTrackV2(ctx, trackID); pcErr == nil {
 			pcTrack := pcResp.primaryTrack()
 			if fullDuration == 0 {
 				fullDuration = sodaTrackDurationSeconds(pcTrack)
@@ -132,7 +149,7 @@ func (s *Soda) resolveDownloadInfo(trackID string, webResp *sodaTrackV2Response)
 			}
 
 			if pcResp.TrackPlayer.URLPlayerInfo != "" {
-				if pcInfo, infoErr := s.fetchPlayerInfo(pcResp.TrackPlayer.URLPlayerInfo); infoErr == nil {
+				if pcInfo, infoErr := s.fetchPlayerInfo(ctx, pcResp.TrackPlayer.URLPlayerInfo); infoErr == nil {
 					if !sodaDownloadInfoIsPreview(pcInfo, fullDuration) {
 						if isVIPTrack {
 							isVip := true
@@ -159,12 +176,12 @@ func (s *Soda) resolveDownloadInfo(trackID string, webResp *sodaTrackV2Response)
 				s.isVipCache = &isVip
 			}
 			if lastErr != nil {
-				return nil, fmt.Errorf("soda vip full stream unavailable: %w", lastErr)
+				return nil, model.Errorf(model.ErrVIPRequired, "soda vip full stream unavailable: %w", lastErr)
 			}
 			if strings.TrimSpace(s.cookie) == "" {
-				return nil, errors.New("soda vip download requires cookie")
+				return nil, model.Errorf(model.ErrAuthExpired, "soda vip download requires cookie")
 			}
-			return nil, errors.New("soda vip full stream unavailable")
+			return nil, model.Errorf(model.ErrVIPRequired, "soda vip full stream unavailable")
 		}
 		return webInfo, nil
 	}
@@ -184,7 +201,12 @@ func sodaDownloadInfoIsLossless(info *DownloadInfo) bool {
 
 // GetDownloadURL 返回下载链接
 func (s *Soda) GetDownloadURL(song *model.Song) (string, error) {
-	info, err := s.GetDownloadInfo(song)
+	return s.GetDownloadURLContext(context.Background(), song)
+}
+
+// GetDownloadURLContext 与 GetDownloadURL 相同，但请求受 ctx 控制。
+func (s *Soda) GetDownloadURLContext(ctx context.Context, song *model.Song) (string, error) {
+	info, err := s.GetDownloadInfoContext(ctx, song)
 	if err != nil {
 		return "", err
 	}
@@ -193,40 +215,33 @@ func (s *Soda) GetDownloadURL(song *model.Song) (string, error) {
 
 // Download 下载并解密歌曲
 func (s *Soda) Download(song *model.Song, outputPath string) error {
-	info, err := s.GetDownloadInfo(song)
-	if err != nil {
-		return fmt.Errorf("get download info failed: %w", err)
-	}
-
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", info.URL, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("User-Agent", UserAgent)
+	return s.DownloadContext(context.Background(), song, outputPath)
+}
 
-	resp, err := client.Do(req)
+// DownloadContext 与 Download 相同，但请求受 ctx 控制。
+func (s *Soda) DownloadContext(ctx context.Context, song *model.Song, outputPath string) error {
+	info, err := s.GetDownloadInfoContext(ctx, song)
 	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download status: %d", resp.StatusCode)
+		return fmt.Errorf("get download info failed: %w", err)
 	}
 
-	encryptedData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
+	// 汽水的歌曲可能未填写 Source，这里补上以便匹配下载时的 UA 和解密处理
+	target := *song
+	target.Source = "soda"
+	d := &download.Downloader{Client: s.client}
+	_, err = d.DownloadURL(ctx, &target, sodaDownloadInfoURL(info), outputPath)
+	return err
+}
 
-	decryptedData, err := DecryptAudio(encryptedData, info.PlayAuth)
-	if err != nil {
-		return fmt.Errorf("decrypt failed: %w", err)
+// decryptDownload 按下载地址中 #auth= 携带的 PlayAuth 边读边解密音频，没有时原样返回
+func decryptDownload(r io.Reader, downloadURL string) (io.Reader, error) {
+	i := strings.Index(downloadURL, "#auth=")
+	if i < 0 {
+		return r, nil
 	}
-
-	err = os.WriteFile(outputPath, decryptedData, 0644)
+	playAuth, err := url.QueryUnescape(downloadURL[i+len("#auth="):])
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return NewDecryptReader(r, playAuth)
 }
@@ -1,6 +1,7 @@
 package soda
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -122,23 +123,37 @@ func sodaQRPollBackoff(token string, duration time.Duration) {
 }
 
 func CreateQRLogin() (*model.QRLoginSession, error) { return defaultSoda.CreateQRLogin() }
+
+func CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
+	return defaultSoda.CreateQRLoginContext(ctx)
+}
 func CheckQRLogin(key string) (*model.QRLoginResult, error) {
 	return defaultSoda.CheckQRLogin(key)
 }
 
+func CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
+	return defaultSoda.CheckQRLoginContext(ctx, key)
+}
+
 // CreateQRLogin creates a QR login session for Soda.
 func (s *Soda) CreateQRLogin() (*model.QRLoginSession, error) {
+	return s.CreateQRLoginContext(context.Background())
+}
+
+// CreateQRLoginContext is like CreateQRLogin but carries ctx through its requests.
+func (s *Soda) CreateQRLoginContext(ctx context.Context) (*model.QRLoginSession, error) {
 	cleanupSodaQRLoginPending()
 
 	createURL := sodaQRCreateAPI + "?" + buildSodaQRCreateQuery()
-	req, err := http.NewRequest("GET", createURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", createURL, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", sodaPassportUA)
 	req.Header.Set("Accept", "application/json, text/javascript")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := s.client.HTTPClient()
+	client.Timeout = 30 * time.Second
 	httpResp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -224,26 +239,31 @@ func (s *Soda) CreateQRLogin() (*model.QRLoginSession, error) {
 // The key format is: "token" for initial check, "token|send_code|encrypt_uid|verify_params" for send_code,
 // "token|validate|encrypt_uid|verify_params|code" for validate.
 func (s *Soda) CheckQRLogin(key string) (*model.QRLoginResult, error) {
+	return s.CheckQRLoginContext(context.Background(), key)
+}
+
+// CheckQRLoginContext is like CheckQRLogin but carries ctx through its requests.
+func (s *Soda) CheckQRLoginContext(ctx context.Context, key string) (*model.QRLoginResult, error) {
 	parts := strings.SplitN(key, "|", 5)
 
 	switch {
 	case len(parts) >= 5 && parts[1] == "validate":
-		return s.sodaValidateCode(parts[0], parts[2], parts[3], parts[4])
+		return s.sodaValidateCode(ctx, parts[0], parts[2], parts[3], parts[4])
 	case len(parts) >= 4 && parts[1] == "up_sms":
-		return s.sodaVerifyUpSMS(parts[0], parts[2], parts[3])
+		return s.sodaVerifyUpSMS(ctx, parts[0], parts[2], parts[3])
 	case len(parts) >= 4 && parts[1] == "send_code":
-		return s.sodaSendCode(parts[0], parts[2], parts[3])
+		return s.sodaSendCode(ctx, parts[0], parts[2], parts[3])
 	default:
-		return s.sodaCheckQRConnect(parts[0])
+		return s.sodaCheckQRConnect(ctx, parts[0])
 	}
 }
 
-func (s *Soda) sodaCheckQRConnect(token string) (*model.QRLoginResult, error) {
+func (s *Soda) sodaCheckQRConnect(ctx context.Context, token string) (*model.QRLoginResult, error) {
 	pending, _ := getSodaQRLoginPending(token)
 	if !sodaQRPollAllowed(token) {
 		return sodaThrottledResult(token, pending), nil
 	}
-	return s.sodaCheckQRConnectWithState(token, pending, false)
+	return s.sodaCheckQRConnectWithState(ctx, token, pending, false)
 }
 
 // sodaThrottledResult returns the most useful cached state when the caller
@@ -309,7 +329,7 @@ func sodaThrottledResult(token string, pending sodaQRLoginPendingState) *model.Q
 	}
 }
 
-func (s *Soda) sodaCheckQRConnectWithState(token string, state sodaQRLoginPendingState, includeVerifyParams bool) (*model.QRLoginResult, error) {
+func (s *Soda) sodaCheckQRConnectWithState(ctx context.Context, token string, state sodaQRLoginPendingState, includeVerifyParams bool) (*model.QRLoginResult, error) {
 	token = strings.TrimSpace(token)
 	params := sodaQRConnectForm(token)
 	if includeVerifyParams {
@@ -321,7 +341,7 @@ func (s *Soda) sodaCheckQRConnectWithState(token string, state sodaQRLoginPendin
 
 	apiURL := sodaQRCheckAPI + "?" + buildSodaQRCheckQuery()
 
-	body, cookies, err := s.postSodaPassportWithCookie(apiURL, params, sodaCookieHeader(s.cookie, state.Cookies))
+	body, cookies, err := s.postSodaPassportWithCookie(ctx, apiURL, params, sodaCookieHeader(s.cookie, state.Cookies))
 	if err != nil {
 		return nil, err
 	}
@@ -521,7 +541,7 @@ func sodaMFASMSMode(hasMobileSMSVerify bool, upSMSMobile, upSMSContent string) s
 	return ""
 }
 
-func (s *Soda) sodaSendCode(token, encryptUID, verifyParams string) (*model.QRLoginResult, error) {
+func (s *Soda) sodaSendCode(ctx context.Context, token, encryptUID, verifyParams string) (*model.QRLoginResult, error) {
 	pending, hasPending := getSodaQRLoginPending(token)
 	if strings.TrimSpace(encryptUID) == "" {
 		encryptUID = pending.EncryptUID
@@ -555,7 +575,7 @@ func (s *Soda) sodaSendCode(token, encryptUID, verifyParams string) (*model.QRLo
 
 	apiURL := sodaSendCodeAPI + "?" + buildSodaPassportLiteQueryFor("/passport/web/send_code/")
 
-	body, cookies, err := s.postSodaPassportWithCookie(apiURL, params, sodaCookieHeader(s.cookie, pending.Cookies))
+	body, cookies, err := s.postSodaPassportWithCookie(ctx, apiURL, params, sodaCookieHeader(s.cookie, pending.Cookies))
 	if err != nil {
 		return nil, err
 	}
@@ -604,7 +624,7 @@ func (s *Soda) sodaSendCode(token, encryptUID, verifyParams string) (*model.QRLo
 	return result, nil
 }
 
-func (s *Soda) sodaVerifyUpSMS(token, encryptUID, verifyParams string) (*model.QRLoginResult, error) {
+func (s *Soda) sodaVerifyUpSMS(ctx context.Context, token, encryptUID, verifyParams string) (*model.QRLoginResult, error) {
 	pending, _ := getSodaQRLoginPending(token)
 	if strings.TrimSpace(encryptUID) == "" {
 		encryptUID = pending.EncryptUID
@@ -633,7 +653,7 @@ func (s *Soda) sodaVerifyUpSMS(token, encryptUID, verifyParams string) (*model.Q
 	params.Set("std_verify_way", "mobile_up_sms_verify")
 
 	apiURL := sodaUpSMSVerifyAPI + "?" + buildSodaPassportLiteQueryFor("/passport/upsms/verify/")
-	body, cookies, err := s.postSodaPassportWithCookie(apiURL, params, sodaCookieHeader(s.cookie, pending.Cookies))
+	body, cookies, err := s.postSodaPassportWithCookie(ctx, apiURL, params, sodaCookieHeader(s.cookie, pending.Cookies))
 	if err != nil {
 		return nil, err
 	}
@@ -664,7 +684,7 @@ func (s *Soda) sodaVerifyUpSMS(token, encryptUID, verifyParams string) (*model.Q
 	pending.VerifyParams = verifyParams
 	rememberSodaQRLoginPending(token, pending)
 
-	finalResult, err := s.sodaCheckQRConnectWithState(token, pending, true)
+	finalResult, err := s.sodaCheckQRConnectWithState(ctx, token, pending, true)
 	if err != nil {
 		return nil, err
 	}
@@ -678,7 +698,7 @@ func (s *Soda) sodaVerifyUpSMS(token, encryptUID, verifyParams string) (*model.Q
 	return finalResult, nil
 }
 
-func (s *Soda) sodaValidateCode(token, encryptUID, verifyParams, code string) (*model.QRLoginResult, error) {
+func (s *Soda) sodaValidateCode(ctx context.Context, token, encryptUID, verifyParams, code string) (*model.QRLoginResult, error) {
 	pending, _ := getSodaQRLoginPending(token)
 	if strings.TrimSpace(encryptUID) == "" {
 		encryptUID = pending.EncryptUID
@@ -712,7 +732,7 @@ func (s *Soda) sodaValidateCode(token, encryptUID, verifyParams, code string) (*
 
 	apiURL := sodaValidateAPI + "?" + buildSodaPassportLiteQueryFor("/passport/web/validate_code/")
 
-	body, cookies, err := s.postSodaPassportWithCookie(apiURL, params, sodaCookieHeader(s.cookie, pending.Cookies))
+	body, cookies, err := s.postSodaPassportWithCookie(ctx, apiURL, params, sodaCookieHeader(s.cookie, pending.Cookies))
 	if err != nil {
 		return nil, err
 	}
@@ -743,7 +763,7 @@ func (s *Soda) sodaValidateCode(token, encryptUID, verifyParams, code string) (*
 	pending.VerifyParams = verifyParams
 	rememberSodaQRLoginPending(token, pending)
 
-	finalResult, err := s.sodaCheckQRConnectWithState(token, pending, true)
+	finalResult, err := s.sodaCheckQRConnectWithState(ctx, token, pending, true)
 	if err != nil {
 		return nil, err
 	}
@@ -757,12 +777,12 @@ func (s *Soda) sodaValidateCode(token, encryptUID, verifyParams, code string) (*
 	return finalResult, nil
 }
 
-func (s *Soda) postSodaPassport(apiURL string, form url.Values) ([]byte, map[string]string, error) {
-	return s.postSodaPassportWithCookie(apiURL, form, s.cookie)
+func (s *Soda) postSodaPassport(ctx context.Context, apiURL string, form url.Values) ([]byte, map[string]string, error) {
+	return s.postSodaPassportWithCookie(ctx, apiURL, form, s.cookie)
 }
 
-func (s *Soda) postSodaPassportWithCookie(apiURL string, form url.Values, cookie string) ([]byte, map[string]string, error) {
-	req, err := http.NewRequest("POST", apiURL, strings.NewReader(sodaEncodePassportForm(apiURL, form)))
+func (s *Soda) postSodaPassportWithCookie(ctx context.Context, apiURL string, form url.Values, cookie string) ([]byte, map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(sodaEncodePassportForm(apiURL, form)))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -793,7 +813,8 @@ func (s *Soda) postSodaPassportWithCookie(apiURL string, form url.Values, cookie
 		req.Header.Set("Cookie", strings.TrimSpace(cookie))
 	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := s.client.HTTPClient()
+	client.Timeout = 30 * time.Second
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, nil, err
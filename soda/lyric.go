@@ -1,8 +1,8 @@
 package soda
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/guohuiyuan/music-lib/model"
 	"github.com/guohuiyuan/music-lib/utils"
@@ -11,10 +11,19 @@ import (
 
 func GetLyrics(s *model.Song) (string, error) { return defaultSoda.GetLyrics(s) }
 
+func GetLyricsContext(ctx context.Context, s *model.Song) (string, error) {
+	return defaultSoda.GetLyricsContext(ctx, s)
+}
+
 // GetLyrics 获取歌词
 func (s *Soda) GetLyrics(song *model.Song) (string, error) {
+	return s.GetLyricsContext(context.Background(), song)
+}
+
+// GetLyricsContext is like GetLyrics but carries ctx through its requests.
+func (s *Soda) GetLyricsContext(ctx context.Context, song *model.Song) (string, error) {
 	if song.Source != "soda" {
-		return "", errors.New("source mismatch")
+		return "", model.ErrSourceMismatch
 	}
 
 	trackID := song.ID
@@ -30,7 +39,7 @@ func (s *Soda) GetLyrics(song *model.Song) (string, error) {
 	params.Set("channel", "pc_web")
 
 	v2URL := "https://api.qishui.com/luna/pc/track_v2?" + params.Encode()
-	body, err := utils.Get(v2URL,
+	body, err := s.client.GetContext(ctx, v2URL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -1,6 +1,7 @@
 package soda
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,38 +15,77 @@ func SearchPlaylist(keyword string) ([]model.Playlist, error) {
 	return defaultSoda.SearchPlaylist(keyword)
 }
 
+func SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
+	return defaultSoda.SearchPlaylistContext(ctx, keyword)
+}
+
 func GetPlaylistSongs(id string) ([]model.Song, error) {
 	// 复用 fetchPlaylistDetail，只返回歌曲列表
-	_, songs, err := defaultSoda.fetchPlaylistDetail(id)
+	_, songs, err := defaultSoda.fetchPlaylistDetail(context.Background(), id)
 	return songs, err
 }
 
+func GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	return defaultSoda.GetPlaylistSongsContext(ctx, id)
+}
+
 func ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
 	return defaultSoda.ParsePlaylist(link)
 }
 
+func ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	return defaultSoda.ParsePlaylistContext(ctx, link)
+}
+
 // GetRecommendedPlaylists [新增] 获取推荐歌单 (空实现)
 func GetRecommendedPlaylists() ([]model.Playlist, error) {
 	return defaultSoda.GetRecommendedPlaylists()
 }
 
+func GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
+	return defaultSoda.GetRecommendedPlaylistsContext(ctx)
+}
+
 func GetPlaylistCategories() ([]model.PlaylistCategory, error) {
 	return defaultSoda.GetPlaylistCategories()
 }
 
+func GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
+	return defaultSoda.GetPlaylistCategoriesContext(ctx)
+}
+
 func GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
 	return defaultSoda.GetCategoryPlaylists(categoryID, page, limit)
 }
 
+func GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
+	return defaultSoda.GetCategoryPlaylistsContext(ctx, categoryID, page, limit)
+}
+
 func (s *Soda) GetPlaylistCategories() ([]model.PlaylistCategory, error) {
+	return s.GetPlaylistCategoriesContext(context.Background())
+}
+
+// GetPlaylistCategoriesContext 与 GetPlaylistCategories 相同，但请求受 ctx 控制。
+func (s *Soda) GetPlaylistCategoriesContext(ctx context.Context) ([]model.PlaylistCategory, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 func (s *Soda) GetCategoryPlaylists(categoryID string, page, limit int) ([]model.Playlist, error) {
+	return s.GetCategoryPlaylistsContext(context.Background(), categoryID, page, limit)
+}
+
+// GetCategoryPlaylistsContext 与 GetCategoryPlaylists 相同，但请求受 ctx 控制。
+func (s *Soda) GetCategoryPlaylistsContext(ctx context.Context, categoryID string, page, limit int) ([]model.Playlist, error) {
 	return nil, model.ErrPlaylistCategoriesUnsupported
 }
 
 func (s *Soda) SearchPlaylist(keyword string) ([]model.Playlist, error) {
+	return s.SearchPlaylistContext(context.Background(), keyword)
+}
+
+// SearchPlaylistContext 与 SearchPlaylist 相同，但请求受 ctx 控制。
+func (s *Soda) SearchPlaylistContext(ctx context.Context, keyword string) ([]model.Playlist, error) {
 	params := url.Values{}
 	params.Set("q", keyword)
 	params.Set("cursor", "0")
@@ -56,7 +96,7 @@ func (s *Soda) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 
 	apiURL := "https://api.qishui.com/luna/pc/search/playlist?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := s.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -135,20 +175,35 @@ func (s *Soda) SearchPlaylist(keyword string) ([]model.Playlist, error) {
 }
 
 func (s *Soda) GetPlaylistSongs(id string) ([]model.Song, error) {
-	_, songs, err := s.fetchPlaylistDetail(id)
+	return s.GetPlaylistSongsContext(context.Background(), id)
+}
+
+// GetPlaylistSongsContext 与 GetPlaylistSongs 相同，但请求受 ctx 控制。
+func (s *Soda) GetPlaylistSongsContext(ctx context.Context, id string) ([]model.Song, error) {
+	_, songs, err := s.fetchPlaylistDetail(ctx, id)
 	return songs, err
 }
 
 func (s *Soda) ParsePlaylist(link string) (*model.Playlist, []model.Song, error) {
-	playlistID, err := s.extractPlaylistID(link)
+	return s.ParsePlaylistContext(context.Background(), link)
+}
+
+// ParsePlaylistContext 与 ParsePlaylist 相同，但请求受 ctx 控制。
+func (s *Soda) ParsePlaylistContext(ctx context.Context, link string) (*model.Playlist, []model.Song, error) {
+	playlistID, err := s.extractPlaylistID(ctx, link)
 	if err != nil || playlistID == "" {
 		return nil, nil, errors.New("invalid soda playlist link")
 	}
-	return s.fetchPlaylistDetail(playlistID)
+	return s.fetchPlaylistDetail(ctx, playlistID)
 }
 
 // GetRecommendedPlaylists [新增] 获取推荐歌单 (空实现)
 func (s *Soda) GetRecommendedPlaylists() ([]model.Playlist, error) {
+	return s.GetRecommendedPlaylistsContext(context.Background())
+}
+
+// GetRecommendedPlaylistsContext 与 GetRecommendedPlaylists 相同，但请求受 ctx 控制。
+func (s *Soda) GetRecommendedPlaylistsContext(ctx context.Context) ([]model.Playlist, error) {
 	// 汽水音乐目前没有公开的每日推荐歌单 PC 接口
-	return nil, errors.New("soda daily recommendation not supported")
+	return nil, model.Errorf(model.ErrUnsupported, "soda daily recommendation not supported")
 }
@@ -0,0 +1,57 @@
+package soda
+
+import (
+	"net/http"
+
+	"github.com/guohuiyuan/music-lib/download"
+	"github.com/guohuiyuan/music-lib/provider"
+	"github.com/guohuiyuan/music-lib/registry"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+func init() {
+	registry.Register(registry.Entry{
+		Name: "soda",
+		New: func(cookie string, opts ...utils.ClientOption) interface{} {
+			return New(cookie, opts...)
+		},
+		Capabilities: []provider.Capability{
+			provider.CapSearch,
+			provider.CapParse,
+			provider.CapDownload,
+			provider.CapLyrics,
+			provider.CapAlbum,
+			provider.CapPlaylist,
+			provider.CapUserPlaylist,
+		},
+		Descriptor: provider.Descriptor{
+			Source: "soda",
+			Name:   "汽水音乐",
+			Note:   "音频解密，支持短链和个人歌单",
+			Features: map[provider.Feature]provider.FeatureSupport{
+				provider.FeatureSearch:            {Level: provider.Supported},
+				provider.FeatureDownload:          {Level: provider.Supported},
+				provider.FeatureLyrics:            {Level: provider.Supported},
+				provider.FeatureSongParse:         {Level: provider.Supported},
+				provider.FeaturePlaylistSearch:    {Level: provider.Supported},
+				provider.FeaturePlaylistRecommend: {Level: provider.Unsupported},
+				provider.FeaturePlaylistSongs:     {Level: provider.Supported},
+				provider.FeaturePlaylistParse:     {Level: provider.Supported},
+				provider.FeatureAlbumSearch:       {Level: provider.Supported},
+				provider.FeatureAlbumSongs:        {Level: provider.Supported},
+				provider.FeatureAlbumParse:        {Level: provider.Supported},
+				provider.FeatureUserPlaylist:      {Level: provider.Supported},
+				provider.FeatureQRLogin:           {Level: provider.Unstable, Note: "未调通，依赖动态风控签名"},
+				provider.FeaturePlaylistCategory:  {Level: provider.Unsupported},
+			},
+		},
+	})
+
+	// 汽水音乐的音频需要 PC 端 UA 下载，GetDownloadURL 返回的地址带有 #auth= 解密参数
+	download.RegisterProfile("soda", download.Profile{
+		Header: http.Header{
+			"User-Agent": {UserAgent},
+		},
+		DecryptReader: decryptDownload,
+	})
+}
@@ -2,6 +2,7 @@ package soda
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +29,7 @@ const (
 type Soda struct {
 	cookie     string
 	isVipCache *bool
+	client     *utils.Client
 }
 
 type sodaArtist struct {
@@ -198,7 +200,9 @@ type sodaShareAlbumPage struct {
 	} `json:"loaderData"`
 }
 
-func New(cookie string) *Soda { return &Soda{cookie: cookie} }
+func New(cookie string, opts ...utils.ClientOption) *Soda {
+	return &Soda{cookie: cookie, client: utils.NewClient(opts...)}
+}
 
 var defaultSoda = New("")
 
@@ -271,8 +275,8 @@ func parseSodaTrackV2Response(body []byte) (*sodaTrackV2Response, error) {
 }
 
 // fetchPlaylistDetail [内部通用] 获取歌单详情
-func (s *Soda) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error) {
-	body, err := utils.Get(sodaAlbumLink(id),
+func (s *Soda) fetchAlbumDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
+	body, err := s.client.GetContext(ctx, sodaAlbumLink(id),
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -287,7 +291,7 @@ func (s *Soda) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error
 
 	info := pageData.LoaderData.AlbumPage.AlbumInfo
 	if info.ID == "" {
-		return nil, nil, errors.New("album not found")
+		return nil, nil, model.Errorf(model.ErrNotFound, "album not found")
 	}
 
 	description := strings.TrimSpace(strings.Join(info.PCLines, " "))
@@ -375,11 +379,11 @@ func (s *Soda) fetchAlbumDetail(id string) (*model.Playlist, []model.Song, error
 	return album, songs, nil
 }
 
-func (s *Soda) fetchPlaylistDetail(id string) (*model.Playlist, []model.Song, error) {
-	return s.fetchPlaylistDetailPaged(id)
+func (s *Soda) fetchPlaylistDetail(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
+	return s.fetchPlaylistDetailPaged(ctx, id)
 }
 
-func (s *Soda) fetchPlaylistDetailWeb(id string) (*model.Playlist, []model.Song, error) {
+func (s *Soda) fetchPlaylistDetailWeb(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	params := url.Values{}
 	params.Set("playlist_id", id)
 	params.Set("cursor", "0")
@@ -390,7 +394,7 @@ func (s *Soda) fetchPlaylistDetailWeb(id string) (*model.Playlist, []model.Song,
 
 	apiURL := "https://api.qishui.com/luna/pc/playlist/detail?" + params.Encode()
 
-	body, err := utils.Get(apiURL,
+	body, err := s.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -557,7 +561,7 @@ func (s *Soda) fetchPlaylistDetailWeb(id string) (*model.Playlist, []model.Song,
 }
 
 // GetDownloadInfo 获取下载信息
-func (s *Soda) fetchPlaylistDetailPaged(id string) (*model.Playlist, []model.Song, error) {
+func (s *Soda) fetchPlaylistDetailPaged(ctx context.Context, id string) (*model.Playlist, []model.Song, error) {
 	playlistID := strings.TrimSpace(id)
 	if playlistID == "" {
 		return nil, nil, errors.New("playlist id is empty")
@@ -571,10 +575,10 @@ func (s *Soda) fetchPlaylistDetailPaged(id string) (*model.Playlist, []model.Son
 	songs := make([]model.Song, 0)
 
 	for page := 0; page < 20; page++ {
-		resp, err := s.fetchPlaylistDetailPage(playlistID, cursor, pageSize)
+		resp, err := s.fetchPlaylistDetailPage(ctx, playlistID, cursor, pageSize)
 		if err != nil {
 			if page == 0 {
-				return s.fetchPlaylistDetailWeb(playlistID)
+				return s.fetchPlaylistDetailWeb(ctx, playlistID)
 			}
 			return nil, nil, err
 		}
@@ -616,7 +620,7 @@ func (s *Soda) fetchPlaylistDetailPaged(id string) (*model.Playlist, []model.Son
 	}
 
 	if playlist == nil || playlist.ID == "" {
-		return nil, nil, errors.New("playlist not found")
+		return nil, nil, model.Errorf(model.ErrNotFound, "playlist not found")
 	}
 	if playlist.TrackCount == 0 {
 		playlist.TrackCount = len(songs)
@@ -624,8 +628,8 @@ func (s *Soda) fetchPlaylistDetailPaged(id string) (*model.Playlist, []model.Son
 	return playlist, songs, nil
 }
 
-func (s *Soda) fetchPlaylistDetailPage(playlistID, cursor string, count int) (*sodaPlaylistDetailResponse, error) {
-	body, err := utils.Get(sodaPCPlaylistDetailURL(playlistID, cursor, count), s.pcRequestOptions()...)
+func (s *Soda) fetchPlaylistDetailPage(ctx context.Context, playlistID, cursor string, count int) (*sodaPlaylistDetailResponse, error) {
+	body, err := s.client.GetContext(ctx, sodaPCPlaylistDetailURL(playlistID, cursor, count), s.pcRequestOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -1018,12 +1022,12 @@ func sodaExtractPlaylistIDFromText(text string) string {
 	return ""
 }
 
-func (s *Soda) extractPlaylistID(link string) (string, error) {
+func (s *Soda) extractPlaylistID(ctx context.Context, link string) (string, error) {
 	if playlistID := sodaExtractPlaylistIDFromText(link); playlistID != "" {
 		return playlistID, nil
 	}
 
-	finalURL, body, err := s.fetchSharePage(link)
+	finalURL, body, err := s.fetchSharePage(ctx, link)
 	if err != nil {
 		return "", err
 	}
@@ -1036,12 +1040,12 @@ func (s *Soda) extractPlaylistID(link string) (string, error) {
 	return "", errors.New("soda playlist id not found")
 }
 
-func (s *Soda) extractTrackID(link string) (string, error) {
+func (s *Soda) extractTrackID(ctx context.Context, link string) (string, error) {
 	if trackID := sodaExtractTrackIDFromText(link); trackID != "" {
 		return trackID, nil
 	}
 
-	finalURL, body, err := s.fetchSharePage(link)
+	finalURL, body, err := s.fetchSharePage(ctx, link)
 	if err != nil {
 		return "", err
 	}
@@ -1054,9 +1058,10 @@ func (s *Soda) extractTrackID(link string) (string, error) {
 	return "", errors.New("soda track id not found")
 }
 
-func (s *Soda) fetchSharePage(link string) (string, []byte, error) {
-	client := &http.Client{Timeout: 30 * time.Second}
-	req, err := http.NewRequest("GET", link, nil)
+func (s *Soda) fetchSharePage(ctx context.Context, link string) (string, []byte, error) {
+	client := s.client.HTTPClient()
+	client.Timeout = 30 * time.Second
+	req, err := http.NewRequestWithContext(ctx, "GET", link, nil)
 	if err != nil {
 		return "", nil, err
 	}
@@ -1071,7 +1076,7 @@ func (s *Soda) fetchSharePage(link string) (string, []byte, error) {
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return "", nil, fmt.Errorf("http request failed: status %d", resp.StatusCode)
+		return "", nil, &utils.StatusError{Method: http.MethodGet, StatusCode: resp.StatusCode}
 	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -1467,8 +1472,8 @@ func sodaPCPlaylistDetailURL(playlistID, cursor string, count int) string {
 	return "https://api.qishui.com/luna/pc/playlist/detail?" + params.Encode()
 }
 
-func (s *Soda) fetchWebTrackV2(trackID string) (*sodaTrackV2Response, error) {
-	body, err := utils.Get(sodaWebTrackV2URL(trackID),
+func (s *Soda) fetchWebTrackV2(ctx context.Context, trackID string) (*sodaTrackV2Response, error) {
+	body, err := s.client.GetContext(ctx, sodaWebTrackV2URL(trackID),
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -1492,9 +1497,9 @@ func (s *Soda) pcRequestOptions(extra ...utils.RequestOption) []utils.RequestOpt
 	return opts
 }
 
-func (s *Soda) fetchPCTrackV2(trackID string) (*sodaTrackV2Response, error) {
+func (s *Soda) fetchPCTrackV2(ctx context.Context, trackID string) (*sodaTrackV2Response, error) {
 	if strings.TrimSpace(s.cookie) == "" {
-		return nil, errors.New("soda pc track_v2 requires cookie")
+		return nil, model.Errorf(model.ErrAuthExpired, "soda pc track_v2 requires cookie")
 	}
 
 	reqData := map[string]string{
@@ -1508,7 +1513,7 @@ func (s *Soda) fetchPCTrackV2(trackID string) (*sodaTrackV2Response, error) {
 		return nil, err
 	}
 
-	body, err := utils.Post(sodaPCTrackV2URL(), bytes.NewReader(jsonData),
+	body, err := s.client.PostContext(ctx, sodaPCTrackV2URL(), bytes.NewReader(jsonData),
 		s.pcRequestOptions(utils.WithHeader("Content-Type", "application/json; charset=utf-8"))...,
 	)
 	if err != nil {
@@ -1517,8 +1522,8 @@ func (s *Soda) fetchPCTrackV2(trackID string) (*sodaTrackV2Response, error) {
 	return parseSodaTrackV2Response(body)
 }
 
-func (s *Soda) fetchPlayerInfo(playerInfoURL string) (*DownloadInfo, error) {
-	infoBody, err := utils.Get(playerInfoURL,
+func (s *Soda) fetchPlayerInfo(ctx context.Context, playerInfoURL string) (*DownloadInfo, error) {
+	infoBody, err := s.client.GetContext(ctx, playerInfoURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -1548,7 +1553,7 @@ func (s *Soda) fetchPlayerInfo(playerInfoURL string) (*DownloadInfo, error) {
 		if infoResp.ResponseMetadata.Error.Message != "" {
 			return nil, errors.New(infoResp.ResponseMetadata.Error.Message)
 		}
-		return nil, errors.New("no audio stream found")
+		return nil, model.Errorf(model.ErrNotFound, "no audio stream found")
 	}
 
 	best, ok := sodaBestPlayerInfo(list)
@@ -1574,19 +1579,19 @@ func (s *Soda) fetchPlayerInfo(playerInfoURL string) (*DownloadInfo, error) {
 	}, nil
 }
 
-func (s *Soda) fetchSongDetail(trackID string) (*model.Song, error) {
-	v2Resp, err := s.fetchWebTrackV2(trackID)
+func (s *Soda) fetchSongDetail(ctx context.Context, trackID string) (*model.Song, error) {
+	v2Resp, err := s.fetchWebTrackV2(ctx, trackID)
 	if err != nil {
 		return nil, err
 	}
 
 	track := v2Resp.primaryTrack()
 	if track.ID == "" {
-		return nil, errors.New("track info not found")
+		return nil, model.Errorf(model.ErrNotFound, "track info not found")
 	}
 
 	song := sodaBuildSongFromTrack(track)
-	if info, err := s.resolveDownloadInfo(track.ID, v2Resp); err == nil {
+	if info, err := s.resolveDownloadInfo(ctx, track.ID, v2Resp); err == nil {
 		applySodaDownloadInfo(&song, info)
 	}
 
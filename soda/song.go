@@ -1,6 +1,7 @@
 package soda
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,10 +12,23 @@ import (
 
 func Search(keyword string) ([]model.Song, error) { return defaultSoda.Search(keyword) }
 
+func SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
+	return defaultSoda.SearchContext(ctx, keyword)
+}
+
 func Parse(link string) (*model.Song, error) { return defaultSoda.Parse(link) }
 
+func ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	return defaultSoda.ParseContext(ctx, link)
+}
+
 // Search 搜索歌曲 (PC API)
 func (s *Soda) Search(keyword string) ([]model.Song, error) {
+	return s.SearchContext(context.Background(), keyword)
+}
+
+// SearchContext 与 Search 相同，但请求受 ctx 控制。
+func (s *Soda) SearchContext(ctx context.Context, keyword string) ([]model.Song, error) {
 	params := url.Values{}
 	params.Set("q", keyword)
 	params.Set("cursor", "0")
@@ -24,7 +38,7 @@ func (s *Soda) Search(keyword string) ([]model.Song, error) {
 	params.Set("channel", "pc_web")
 
 	apiURL := "https://api.qishui.com/luna/pc/search/track?" + params.Encode()
-	body, err := utils.Get(apiURL,
+	body, err := s.client.GetContext(ctx, apiURL,
 		utils.WithHeader("User-Agent", UserAgent),
 		utils.WithHeader("Cookie", s.cookie),
 	)
@@ -62,9 +76,14 @@ func (s *Soda) Search(keyword string) ([]model.Song, error) {
 
 // Parse 解析链接并获取完整信息
 func (s *Soda) Parse(link string) (*model.Song, error) {
-	trackID, err := s.extractTrackID(link)
+	return s.ParseContext(context.Background(), link)
+}
+
+// ParseContext 与 Parse 相同，但请求受 ctx 控制。
+func (s *Soda) ParseContext(ctx context.Context, link string) (*model.Song, error) {
+	trackID, err := s.extractTrackID(ctx, link)
 	if err != nil || trackID == "" {
 		return nil, errors.New("invalid soda link")
 	}
-	return s.fetchSongDetail(trackID)
+	return s.fetchSongDetail(ctx, trackID)
 }
@@ -1,20 +1,23 @@
 package soda
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/guohuiyuan/music-lib/model"
-	"github.com/guohuiyuan/music-lib/utils"
 )
 
 func GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return defaultSoda.GetUserPlaylists(page, limit)
 }
 
+func GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
+	return defaultSoda.GetUserPlaylistsContext(ctx, page, limit)
+}
+
 type sodaPCMeResponse struct {
 	StatusCode int               `json:"status_code"`
 	StatusInfo sodaAPIStatusInfo `json:"status_info"`
@@ -35,8 +38,13 @@ type sodaUserPlaylistResponse struct {
 }
 
 func (s *Soda) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
+	return s.GetUserPlaylistsContext(context.Background(), page, limit)
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists but carries ctx through its requests.
+func (s *Soda) GetUserPlaylistsContext(ctx context.Context, page, limit int) ([]model.Playlist, error) {
 	if strings.TrimSpace(s.cookie) == "" {
-		return nil, errors.New("soda user playlists require cookie")
+		return nil, model.Errorf(model.ErrAuthExpired, "soda user playlists require cookie")
 	}
 	if page < 1 {
 		page = 1
@@ -48,13 +56,13 @@ func (s *Soda) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 		limit = 100
 	}
 
-	me, err := s.fetchPCMe()
+	me, err := s.fetchPCMe(ctx)
 	if err != nil {
 		return nil, err
 	}
 	userID := strings.TrimSpace(me.MyInfo.ID)
 	if userID == "" {
-		return nil, errors.New("soda user playlists require logged-in user id")
+		return nil, model.Errorf(model.ErrAuthExpired, "soda user playlists require logged-in user id")
 	}
 
 	targetCount := page * limit
@@ -71,7 +79,7 @@ func (s *Soda) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	seenPlaylists := make(map[string]bool)
 	playlists := make([]model.Playlist, 0, targetCount)
 	for attempts := 0; attempts < 20 && len(playlists) < targetCount; attempts++ {
-		resp, err := s.fetchUserPlaylistPage(userID, cursor, requestCount)
+		resp, err := s.fetchUserPlaylistPage(ctx, userID, cursor, requestCount)
 		if err != nil {
 			return nil, err
 		}
@@ -106,8 +114,8 @@ func (s *Soda) GetUserPlaylists(page, limit int) ([]model.Playlist, error) {
 	return playlists[start:end], nil
 }
 
-func (s *Soda) fetchPCMe() (*sodaPCMeResponse, error) {
-	body, err := utils.Get(sodaPCMeURL(), s.pcRequestOptions()...)
+func (s *Soda) fetchPCMe(ctx context.Context) (*sodaPCMeResponse, error) {
+	body, err := s.client.GetContext(ctx, sodaPCMeURL(), s.pcRequestOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,8 +134,8 @@ func (s *Soda) fetchPCMe() (*sodaPCMeResponse, error) {
 	return &resp, nil
 }
 
-func (s *Soda) fetchUserPlaylistPage(userID, cursor string, count int) (*sodaUserPlaylistResponse, error) {
-	body, err := utils.Get(sodaPCUserPlaylistURL(userID, cursor, count), s.pcRequestOptions()...)
+func (s *Soda) fetchUserPlaylistPage(ctx context.Context, userID, cursor string, count int) (*sodaUserPlaylistResponse, error) {
+	body, err := s.client.GetContext(ctx, sodaPCUserPlaylistURL(userID, cursor, count), s.pcRequestOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,308 @@
+package tag
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // 注册解码器，用于读取封面尺寸
+	_ "image/png"
+	"io"
+	"os"
+	"strings"
+)
+
+// FLAC 元数据块类型
+const (
+	flacStreamInfo    = 0
+	flacPadding       = 1
+	flacVorbisComment = 4
+	flacPicture       = 6
+)
+
+// flacDefaultPadding 是原文件没有足够填充时新写入的填充大小
+const flacDefaultPadding = 4096
+
+const flacMaxBlockSize = 1<<24 - 1
+
+// ErrInvalidFLAC 表示文件不是合法的 FLAC 流
+var ErrInvalidFLAC = errors.New("tag: invalid flac stream")
+
+type flacBlock struct {
+	typ  byte
+	data []byte
+}
+
+// WriteFLAC 替换 FLAC 文件中的 VORBIS_COMMENT 和封面 PICTURE 块，音频帧保持不变。
+// 与 Metadata 无关的注释字段和其他类型的图片会被保留。
+// 新的元数据能放进原有的填充中时直接原地改写，否则重写整个文件。
+func WriteFLAC(path string, m *Metadata) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	blocks, metaSize, err := readFLACBlocks(br)
+	if err != nil {
+		return err
+	}
+
+	oldPadding := 0
+	var out []flacBlock
+	hasCover := m.Cover != nil && len(m.Cover.Data) > 0
+	commentWritten := false
+	for _, b := range blocks {
+		switch b.typ {
+		case flacPadding:
+			oldPadding += 4 + len(b.data)
+			continue
+		case flacVorbisComment:
+			if commentWritten {
+				continue
+			}
+			b.data = encodeVorbisComment(vorbisFields(m), b.data)
+			commentWritten = true
+		case flacPicture:
+			if hasCover && flacPictureType(b.data) == 3 {
+				continue
+			}
+		}
+		out = append(out, b)
+	}
+	if !commentWritten {
+		out = insertAfterStreamInfo(out, flacBlock{typ: flacVorbisComment, data: encodeVorbisComment(vorbisFields(m), nil)})
+	}
+	if hasCover {
+		out = append(out, flacBlock{typ: flacPicture, data: encodeFLACPicture(m.Cover)})
+	}
+
+	size := 0
+	for _, b := range out {
+		if len(b.data) > flacMaxBlockSize {
+			return fmt.Errorf("tag: flac metadata block too large (%d bytes)", len(b.data))
+		}
+		size += 4 + len(b.data)
+	}
+
+	// 原有空间 (含填充) 足够时用填充补齐差值，音频数据的位置不变
+	if room := metaSize - size; room == 0 || room >= 4 {
+		if room >= 4 {
+			out = append(out, flacBlock{typ: flacPadding, data: make([]byte, room-4)})
+		}
+		if _, err := f.WriteAt(encodeFLACHeader(out), 0); err != nil {
+			return err
+		}
+		return f.Sync()
+	}
+
+	padding := oldPadding - 4
+	if padding < flacDefaultPadding {
+		padding = flacDefaultPadding
+	}
+	out = append(out, flacBlock{typ: flacPadding, data: make([]byte, padding)})
+	return replaceFile(path, f, func(w io.Writer) error {
+		if _, err := w.Write(encodeFLACHeader(out)); err != nil {
+			return err
+		}
+		_, err := io.Copy(w, br)
+		return err
+	})
+}
+
+// readFLACBlocks 读取所有元数据块，返回块列表和元数据部分的总字节数 (不含 "fLaC")
+func readFLACBlocks(r io.Reader) ([]flacBlock, int, error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil || string(magic[:]) != "fLaC" {
+		return nil, 0, ErrInvalidFLAC
+	}
+	var blocks []flacBlock
+	total := 0
+	for {
+		var head [4]byte
+		if _, err := io.ReadFull(r, head[:]); err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrInvalidFLAC, err)
+		}
+		n := int(head[1])<<16 | int(head[2])<<8 | int(head[3])
+		data := make([]byte, n)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrInvalidFLAC, err)
+		}
+		typ := head[0] & 0x7F
+		if len(blocks) == 0 && typ != flacStreamInfo {
+			return nil, 0, fmt.Errorf("%w: first block is not STREAMINFO", ErrInvalidFLAC)
+		}
+		blocks = append(blocks, flacBlock{typ: typ, data: data})
+		total += 4 + n
+		if head[0]&0x80 != 0 {
+			return blocks, total, nil
+		}
+	}
+}
+
+func encodeFLACHeader(blocks []flacBlock) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("fLaC")
+	for i, b := range blocks {
+		typ := b.typ
+		if i == len(blocks)-1 {
+			typ |= 0x80
+		}
+		n := len(b.data)
+		buf.Write([]byte{typ, byte(n >> 16), byte(n >> 8), byte(n)})
+		buf.Write(b.data)
+	}
+	return buf.Bytes()
+}
+
+func insertAfterStreamInfo(blocks []flacBlock, b flacBlock) []flacBlock {
+	out := make([]flacBlock, 0, len(blocks)+1)
+	out = append(out, blocks[0], b)
+	return append(out, blocks[1:]...)
+}
+
+// vorbisFields 返回 Metadata 对应的 Vorbis 注释字段，FLAC 和 Ogg 共用
+func vorbisFields(m *Metadata) [][2]string {
+	var fields [][2]string
+	add := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			fields = append(fields, [2]string{key, value})
+		}
+	}
+	add("TITLE", m.Title)
+	add("ARTIST", m.Artist)
+	add("ALBUM", m.Album)
+	add("DATE", m.Date)
+	add("TRACKNUMBER", m.Track)
+	add("LYRICS", m.lrcText())
+	for _, key := range sortedKeys(m.Comments) {
+		add(strings.ToUpper(key), m.Comments[key])
+	}
+	return fields
+}
+
+// encodeVorbisComment 生成注释数据 (小端长度，不含 Ogg 的 framing bit)。
+// old 为原有的注释块，其中的 vendor 和不由 Metadata 管理的字段会被保留。
+func encodeVorbisComment(fields [][2]string, old []byte) []byte {
+	managed := map[string]bool{"TITLE": true, "ARTIST": true, "ALBUM": true, "DATE": true, "TRACKNUMBER": true, "LYRICS": true, "UNSYNCEDLYRICS": true}
+	for _, f := range fields {
+		managed[f[0]] = true
+	}
+
+	vendor := "music-lib"
+	var comments []string
+	if oldVendor, oldComments, ok := parseVorbisComment(old); ok {
+		vendor = oldVendor
+		for _, c := range oldComments {
+			key := c
+			if i := strings.IndexByte(c, '='); i >= 0 {
+				key = c[:i]
+			}
+			if !managed[strings.ToUpper(key)] {
+				comments = append(comments, c)
+			}
+		}
+	}
+	for _, f := range fields {
+		comments = append(comments, f[0]+"="+f[1])
+	}
+
+	var buf bytes.Buffer
+	writeLE := func(s string) {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(s)))
+		buf.WriteString(s)
+	}
+	writeLE(vendor)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(comments)))
+	for _, c := range comments {
+		writeLE(c)
+	}
+	return buf.Bytes()
+}
+
+func parseVorbisComment(data []byte) (string, []string, bool) {
+	next := func() (string, bool) {
+		if len(data) < 4 {
+			return "", false
+		}
+		n := binary.LittleEndian.Uint32(data)
+		if uint64(n) > uint64(len(data)-4) {
+			return "", false
+		}
+		s := string(data[4 : 4+n])
+		data = data[4+n:]
+		return s, true
+	}
+	vendor, ok := next()
+	if !ok || len(data) < 4 {
+		return "", nil, false
+	}
+	count := binary.LittleEndian.Uint32(data)
+	data = data[4:]
+	var comments []string
+	for i := uint32(0); i < count; i++ {
+		c, ok := next()
+		if !ok {
+			return "", nil, false
+		}
+		comments = append(comments, c)
+	}
+	return vendor, comments, true
+}
+
+// encodeFLACPicture 生成 PICTURE 块 (大端)，Ogg 的 METADATA_BLOCK_PICTURE 使用同样的格式
+func encodeFLACPicture(p *Picture) []byte {
+	mime := p.MIME
+	if mime == "" {
+		mime = DetectImageMIME(p.Data)
+	}
+	var width, height, depth, colors uint32
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(p.Data)); err == nil {
+		width, height = uint32(cfg.Width), uint32(cfg.Height)
+		depth, colors = colorDepth(cfg.ColorModel)
+	}
+
+	var buf bytes.Buffer
+	put := func(v uint32) { binary.Write(&buf, binary.BigEndian, v) }
+	put(3) // 封面 (front cover)
+	put(uint32(len(mime)))
+	buf.WriteString(mime)
+	put(uint32(len(p.Description)))
+	buf.WriteString(p.Description)
+	put(width)
+	put(height)
+	put(depth)
+	put(colors)
+	put(uint32(len(p.Data)))
+	buf.Write(p.Data)
+	return buf.Bytes()
+}
+
+func flacPictureType(data []byte) uint32 {
+	if len(data) < 4 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(data)
+}
+
+// colorDepth 返回每像素位数，以及调色板图片的颜色数
+func colorDepth(model color.Model) (uint32, uint32) {
+	switch model {
+	case color.GrayModel:
+		return 8, 0
+	case color.Gray16Model:
+		return 16, 0
+	case color.RGBAModel, color.NRGBAModel:
+		return 32, 0
+	case color.RGBA64Model, color.NRGBA64Model:
+		return 64, 0
+	}
+	if p, ok := model.(color.Palette); ok {
+		return 8, uint32(len(p))
+	}
+	return 24, 0
+}
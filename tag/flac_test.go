@@ -0,0 +1,147 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+)
+
+var fakeFLACFrames = []byte{0xFF, 0xF8, 0x69, 0x08, 0x00, 1, 2, 3, 4}
+
+func buildFLAC(blocks ...flacBlock) []byte {
+	streamInfo := flacBlock{typ: flacStreamInfo, data: make([]byte, 34)}
+	return append(encodeFLACHeader(append([]flacBlock{streamInfo}, blocks...)), fakeFLACFrames...)
+}
+
+func readFLACFile(t *testing.T, path string) ([]flacBlock, []byte) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(data)
+	blocks, _, err := readFLACBlocks(r)
+	if err != nil {
+		t.Fatalf("readFLACBlocks: %v", err)
+	}
+	rest := make([]byte, r.Len())
+	r.Read(rest)
+	return blocks, rest
+}
+
+func flacComments(t *testing.T, blocks []flacBlock) (string, []string) {
+	t.Helper()
+	for _, b := range blocks {
+		if b.typ == flacVorbisComment {
+			vendor, comments, ok := parseVorbisComment(b.data)
+			if !ok {
+				t.Fatalf("invalid vorbis comment: %x", b.data)
+			}
+			return vendor, comments
+		}
+	}
+	t.Fatal("no VORBIS_COMMENT block")
+	return "", nil
+}
+
+func TestWriteFLACInPlace(t *testing.T) {
+	old := encodeVorbisComment(vorbisFields(&Metadata{Title: "旧标题", Comments: map[string]string{"GENRE": "Pop"}}), nil)
+	src := buildFLAC(
+		flacBlock{typ: flacVorbisComment, data: old},
+		flacBlock{typ: flacPadding, data: make([]byte, 8192)},
+	)
+	path := writeTemp(t, "a.flac", src)
+
+	var cover bytes.Buffer
+	png.Encode(&cover, image.NewNRGBA(image.Rect(0, 0, 4, 3)))
+	m := &Metadata{
+		Title: "晴天", Artist: "周杰伦", Album: "叶惠美",
+		SyncedLyrics: []SyncedLine{{Time: 61500 * time.Millisecond, Text: "故事的小黄花"}},
+		Cover:        &Picture{Data: cover.Bytes()},
+		Comments:     map[string]string{"QQ_ID": "0039MnYb0qxYhV"},
+	}
+	if err := WriteFile(path, m); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if len(data) != len(src) {
+		t.Fatalf("file size changed from %d to %d, padding not reused", len(src), len(data))
+	}
+	blocks, rest := readFLACFile(t, path)
+	if !bytes.Equal(rest, fakeFLACFrames) {
+		t.Fatalf("audio frames changed: %x", rest)
+	}
+	if blocks[0].typ != flacStreamInfo || blocks[len(blocks)-1].typ != flacPadding {
+		t.Fatalf("unexpected block order: %+v", blocks)
+	}
+
+	vendor, comments := flacComments(t, blocks)
+	want := []string{"GENRE=Pop", "TITLE=晴天", "ARTIST=周杰伦", "ALBUM=叶惠美", "LYRICS=[01:01.50]故事的小黄花", "QQ_ID=0039MnYb0qxYhV"}
+	if vendor != "music-lib" || len(comments) != len(want) {
+		t.Fatalf("comments = %q (vendor %q)", comments, vendor)
+	}
+	for i := range want {
+		if comments[i] != want[i] {
+			t.Errorf("comment[%d] = %q, want %q", i, comments[i], want[i])
+		}
+	}
+
+	var pic []byte
+	for _, b := range blocks {
+		if b.typ == flacPicture {
+			pic = b.data
+		}
+	}
+	if pic == nil {
+		t.Fatal("no PICTURE block")
+	}
+	if typ := binary.BigEndian.Uint32(pic); typ != 3 {
+		t.Errorf("picture type = %d", typ)
+	}
+	if !bytes.HasPrefix(pic[4:], []byte("\x00\x00\x00\x09image/png\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x03\x00\x00\x00\x20")) {
+		t.Errorf("picture header = %x", pic[:40])
+	}
+	if !bytes.HasSuffix(pic, cover.Bytes()) {
+		t.Error("picture data mismatch")
+	}
+}
+
+func TestWriteFLACGrowsFile(t *testing.T) {
+	path := writeTemp(t, "a.flac", buildFLAC())
+	m := &Metadata{Title: "晴天", Lyrics: "故事的小黄花"}
+	if err := WriteFLAC(path, m); err != nil {
+		t.Fatalf("WriteFLAC: %v", err)
+	}
+	blocks, rest := readFLACFile(t, path)
+	if !bytes.Equal(rest, fakeFLACFrames) {
+		t.Fatalf("audio frames changed: %x", rest)
+	}
+	if len(blocks) != 3 || blocks[1].typ != flacVorbisComment || blocks[2].typ != flacPadding || len(blocks[2].data) != flacDefaultPadding {
+		t.Fatalf("unexpected blocks: %d", len(blocks))
+	}
+	if _, comments := flacComments(t, blocks); len(comments) != 2 || comments[1] != "LYRICS=故事的小黄花" {
+		t.Errorf("comments = %q", comments)
+	}
+
+	// 再次写入时使用上次留下的填充，不再改变文件大小
+	before, _ := os.ReadFile(path)
+	if err := WriteFLAC(path, &Metadata{Title: "七里香"}); err != nil {
+		t.Fatalf("WriteFLAC: %v", err)
+	}
+	after, _ := os.ReadFile(path)
+	if len(after) != len(before) {
+		t.Errorf("size changed from %d to %d", len(before), len(after))
+	}
+}
+
+func TestWriteFLACRejectsInvalid(t *testing.T) {
+	path := writeTemp(t, "a.flac", []byte("fLaC\x04\x00\x00"))
+	if err := WriteFLAC(path, &Metadata{Title: "x"}); err == nil {
+		t.Fatal("expected error for truncated flac")
+	}
+}
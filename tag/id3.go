@@ -0,0 +1,352 @@
+package tag
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf16"
+)
+
+// ID3 版本号，对应标签头中的主版本
+const (
+	ID3v23 = 3
+	ID3v24 = 4
+)
+
+// id3Padding 写入标签后预留的空白，方便播放器原地修改
+const id3Padding = 1024
+
+const (
+	id3EncodingUTF16 = 0x01
+	id3EncodingUTF8  = 0x03
+)
+
+// ErrInvalidID3 表示文件开头的 ID3v2 标签头已损坏
+var ErrInvalidID3 = errors.New("tag: invalid id3v2 header")
+
+// managedID3Frames 是由 Metadata 生成的文本帧，改写时总是先删除旧帧。
+// APIC、USLT、SYLT 和 TXXX 只在写入对应字段时才删除，参见 replacedID3Frame
+var managedID3Frames = map[string]bool{
+	"TIT2": true, "TPE1": true, "TALB": true, "TDRC": true, "TYER": true, "TDAT": true,
+	"TRCK": true,
+}
+
+// WriteID3 把标签写入 MP3 文件开头，version 为 ID3v23 或 ID3v24。
+// 已有的 ID3v2 标签会被替换，其中与 Metadata 无关且版本相同的帧会被保留，
+// m 中为空的封面、歌词以及不在 Comments 中的 TXXX 字段也不会被删除。
+func WriteID3(path string, m *Metadata, version int) error {
+	if version != ID3v23 && version != ID3v24 {
+		return fmt.Errorf("tag: unsupported id3 version 2.%d", version)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	kept, err := skipID3(br, version, m)
+	if err != nil {
+		return err
+	}
+	tag := EncodeID3(m, version, kept...)
+	return replaceFile(path, f, func(w io.Writer) error {
+		if _, err := w.Write(tag); err != nil {
+			return err
+		}
+		_, err := io.Copy(w, br)
+		return err
+	})
+}
+
+// EncodeID3 生成完整的 ID3v2 标签 (含标签头和填充)，extra 为原样追加的已编码帧
+func EncodeID3(m *Metadata, version int, extra ...[]byte) []byte {
+	var frames bytes.Buffer
+	text := func(id, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			writeID3Frame(&frames, version, id, encodeID3Text(version, value))
+		}
+	}
+
+	text("TIT2", m.Title)
+	text("TPE1", m.Artist)
+	text("TALB", m.Album)
+	if version == ID3v24 {
+		text("TDRC", m.Date)
+	} else if m.Date != "" {
+		// v2.3 没有 TDRC，年份写入 TYER，月日写入 TDAT (DDMM)
+		text("TYER", m.Date[:minInt(4, len(m.Date))])
+		if len(m.Date) == len("2006-01-02") {
+			text("TDAT", m.Date[8:10]+m.Date[5:7])
+		}
+	}
+	text("TRCK", m.Track)
+	for _, key := range sortedKeys(m.Comments) {
+		if v := strings.TrimSpace(m.Comments[key]); v != "" {
+			writeID3Frame(&frames, version, "TXXX", encodeID3Text(version, key, v))
+		}
+	}
+	if m.Cover != nil && len(m.Cover.Data) > 0 {
+		writeID3Frame(&frames, version, "APIC", encodeAPIC(version, m.Cover))
+	}
+	if m.Lyrics != "" {
+		writeID3Frame(&frames, version, "USLT", encodeUSLT(version, m.Lyrics))
+	}
+	if len(m.SyncedLyrics) > 0 {
+		writeID3Frame(&frames, version, "SYLT", encodeSYLT(version, m.SyncedLyrics))
+	}
+	for _, frame := range extra {
+		frames.Write(frame)
+	}
+
+	size := frames.Len() + id3Padding
+	out := make([]byte, 10, 10+size)
+	copy(out, "ID3")
+	out[3] = byte(version)
+	putSyncsafe(out[6:10], size)
+	out = append(out, frames.Bytes()...)
+	return append(out, make([]byte, id3Padding)...)
+}
+
+// skipID3 跳过 r 开头已有的 ID3v2 标签，返回其中写入 m 后仍需保留的帧
+func skipID3(r *bufio.Reader, version int, m *Metadata) ([][]byte, error) {
+	head, err := r.Peek(10)
+	if err != nil || string(head[:3]) != "ID3" {
+		// 文件过短或没有标签，直接从头复制
+		return nil, nil
+	}
+	if head[3] < 2 || head[3] > 4 || !syncsafe(head[6:10]) {
+		return nil, ErrInvalidID3
+	}
+	oldVersion, flags := int(head[3]), head[5]
+	size := getSyncsafe(head[6:10])
+	if flags&0x10 != 0 {
+		// v2.4 footer
+		size += 10
+	}
+	if _, err := r.Discard(10); err != nil {
+		return nil, err
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("tag: truncated id3v2 tag: %w", err)
+	}
+	// 不同版本的帧格式不兼容，非同步化和扩展头也不值得处理，这些情况下只替换不保留
+	if oldVersion != version || flags&0xC0 != 0 {
+		return nil, nil
+	}
+	return keptID3Frames(body, version, m), nil
+}
+
+// keptID3Frames 拆分帧数据，返回不会被 m 替换的帧
+func keptID3Frames(body []byte, version int, m *Metadata) [][]byte {
+	var kept [][]byte
+	for len(body) >= 10 && body[0] != 0 {
+		id := string(body[:4])
+		var n int
+		if version == ID3v24 {
+			n = getSyncsafe(body[4:8])
+		} else {
+			n = int(binary.BigEndian.Uint32(body[4:8]))
+		}
+		if n < 0 || 10+n > len(body) {
+			break
+		}
+		if !managedID3Frames[id] && !replacedID3Frame(id, body[9], body[10:10+n], m) {
+			kept = append(kept, body[:10+n])
+		}
+		body = body[10+n:]
+	}
+	return kept
+}
+
+// replacedID3Frame 判断旧帧是否会被 m 生成的帧替换：封面只替换 front cover，
+// TXXX 只替换描述与 Comments 中的键相同的帧 (值为空时即删除该字段)。
+// format 是帧的格式标志，压缩或加密的帧无法解析内容。
+func replacedID3Frame(id string, format byte, data []byte, m *Metadata) bool {
+	switch id {
+	case "APIC":
+		if m.Cover == nil || len(m.Cover.Data) == 0 {
+			return false
+		}
+		return format != 0 || apicPictureType(data) == 0x03
+	case "USLT":
+		return m.Lyrics != ""
+	case "SYLT":
+		return len(m.SyncedLyrics) > 0
+	case "TXXX":
+		if format != 0 || len(data) == 0 {
+			return false
+		}
+		desc, ok := decodeID3String(data[0], data[1:])
+		if !ok {
+			return false
+		}
+		_, replaced := m.Comments[desc]
+		return replaced
+	}
+	return false
+}
+
+// apicPictureType 返回 APIC 帧的图片类型，无法解析时返回 -1
+func apicPictureType(data []byte) int {
+	if len(data) < 2 {
+		return -1
+	}
+	mime := bytes.IndexByte(data[1:], 0)
+	if mime < 0 || 1+mime+1 >= len(data) {
+		return -1
+	}
+	return int(data[1+mime+1])
+}
+
+// decodeID3String 解码 data 开头以终止符结尾的字符串，enc 为帧的文本编码
+func decodeID3String(enc byte, data []byte) (string, bool) {
+	switch enc {
+	case 0x00:
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			return "", false
+		}
+		runes := make([]rune, end)
+		for i, c := range data[:end] {
+			runes[i] = rune(c)
+		}
+		return string(runes), true
+	case id3EncodingUTF8:
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			return "", false
+		}
+		return string(data[:end]), true
+	case id3EncodingUTF16, 0x02:
+		bigEndian := enc == 0x02
+		var units []uint16
+		for i := 0; i+1 < len(data); i += 2 {
+			u := uint16(data[i])<<8 | uint16(data[i+1])
+			if !bigEndian {
+				u = uint16(data[i]) | uint16(data[i+1])<<8
+			}
+			switch {
+			case u == 0:
+				return string(utf16.Decode(units)), true
+			case i == 0 && enc == id3EncodingUTF16 && (u == 0xFEFF || u == 0xFFFE):
+				// BOM 决定字节序，按小端读出 0xFFFE 表示大端
+				bigEndian = u == 0xFFFE
+			default:
+				units = append(units, u)
+			}
+		}
+	}
+	return "", false
+}
+
+func writeID3Frame(w *bytes.Buffer, version int, id string, data []byte) {
+	var head [10]byte
+	copy(head[:4], id)
+	if version == ID3v24 {
+		putSyncsafe(head[4:8], len(data))
+	} else {
+		binary.BigEndian.PutUint32(head[4:8], uint32(len(data)))
+	}
+	w.Write(head[:])
+	w.Write(data)
+}
+
+// encodeID3Text 编码文本帧，多个值之间以编码对应的终止符分隔
+func encodeID3Text(version int, values ...string) []byte {
+	out := []byte{id3TextEncoding(version)}
+	for i, v := range values {
+		if i > 0 {
+			out = append(out, id3Terminator(version)...)
+		}
+		out = append(out, id3String(version, v)...)
+	}
+	return out
+}
+
+func encodeAPIC(version int, p *Picture) []byte {
+	mime := p.MIME
+	if mime == "" {
+		mime = DetectImageMIME(p.Data)
+	}
+	out := []byte{id3TextEncoding(version)}
+	out = append(out, mime...)
+	out = append(out, 0, 0x03) // 0x03: 封面 (front cover)
+	out = append(out, id3String(version, p.Description)...)
+	out = append(out, id3Terminator(version)...)
+	return append(out, p.Data...)
+}
+
+func encodeUSLT(version int, lyric string) []byte {
+	out := []byte{id3TextEncoding(version), 'X', 'X', 'X'}
+	out = append(out, id3Terminator(version)...) // 空的内容描述
+	return append(out, id3String(version, lyric)...)
+}
+
+// encodeSYLT 以毫秒时间戳 (格式 2) 编码逐行歌词，内容类型为歌词 (1)
+func encodeSYLT(version int, lines []SyncedLine) []byte {
+	out := []byte{id3TextEncoding(version), 'X', 'X', 'X', 0x02, 0x01}
+	out = append(out, id3Terminator(version)...)
+	var ts [4]byte
+	for _, line := range lines {
+		out = append(out, id3String(version, line.Text)...)
+		out = append(out, id3Terminator(version)...)
+		binary.BigEndian.PutUint32(ts[:], uint32(line.Time.Milliseconds()))
+		out = append(out, ts[:]...)
+	}
+	return out
+}
+
+// v2.4 使用 UTF-8，v2.3 只支持 ISO-8859-1 和 UTF-16，统一写带 BOM 的 UTF-16
+func id3TextEncoding(version int) byte {
+	if version == ID3v24 {
+		return id3EncodingUTF8
+	}
+	return id3EncodingUTF16
+}
+
+func id3Terminator(version int) []byte {
+	if version == ID3v24 {
+		return []byte{0}
+	}
+	return []byte{0, 0}
+}
+
+func id3String(version int, s string) []byte {
+	if version == ID3v24 {
+		return []byte(s)
+	}
+	units := utf16.Encode([]rune(s))
+	out := make([]byte, 2, 2+2*len(units))
+	out[0], out[1] = 0xFF, 0xFE
+	for _, u := range units {
+		out = append(out, byte(u), byte(u>>8))
+	}
+	return out
+}
+
+func syncsafe(b []byte) bool {
+	for _, c := range b {
+		if c&0x80 != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func getSyncsafe(b []byte) int {
+	return int(b[0])<<21 | int(b[1])<<14 | int(b[2])<<7 | int(b[3])
+}
+
+func putSyncsafe(b []byte, n int) {
+	b[0] = byte(n >> 21 & 0x7F)
+	b[1] = byte(n >> 14 & 0x7F)
+	b[2] = byte(n >> 7 & 0x7F)
+	b[3] = byte(n & 0x7F)
+}
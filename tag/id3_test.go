@@ -0,0 +1,265 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/guohuiyuan/music-lib/model"
+)
+
+var fakeMP3Audio = []byte{0xFF, 0xFB, 0x90, 0x64, 1, 2, 3, 4, 5, 6, 7, 8}
+
+// parseID3Frames 解析标签中的帧，只用于测试
+func parseID3Frames(t *testing.T, data []byte) (int, map[string][][]byte, []byte) {
+	t.Helper()
+	if string(data[:3]) != "ID3" {
+		t.Fatalf("missing ID3 header: %q", data[:3])
+	}
+	version := int(data[3])
+	size := getSyncsafe(data[6:10])
+	body := data[10 : 10+size]
+	frames := map[string][][]byte{}
+	for len(body) >= 10 && body[0] != 0 {
+		var n int
+		if version == ID3v24 {
+			n = getSyncsafe(body[4:8])
+		} else {
+			n = int(binary.BigEndian.Uint32(body[4:8]))
+		}
+		id := string(body[:4])
+		frames[id] = append(frames[id], body[10:10+n])
+		body = body[10+n:]
+	}
+	return version, frames, data[10+size:]
+}
+
+func decodeUTF16(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xFF && b[1] == 0xFE {
+		b = b[2:]
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+	}
+	return string(utf16.Decode(units))
+}
+
+func writeTemp(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFromSong(t *testing.T) {
+	s := &model.Song{
+		ID: "123", Source: "netease", Name: "晴天", Artist: "周杰伦", Album: "叶惠美",
+		Extra: map[string]string{"publish_time": "1059580800000", "track": "3"},
+	}
+	m := FromSong(s, "[ti:晴天]\n[00:01.50]故事的小黄花\n[00:05.00]从出生那年就飘着\n")
+	if m.Date != "2003-07-31" || m.Track != "3" {
+		t.Fatalf("date/track = %q/%q", m.Date, m.Track)
+	}
+	if m.Comments["NETEASE_ID"] != "123" {
+		t.Fatalf("comments = %v", m.Comments)
+	}
+	if m.Lyrics != "故事的小黄花\n从出生那年就飘着" {
+		t.Fatalf("lyrics = %q", m.Lyrics)
+	}
+	if len(m.SyncedLyrics) != 2 || m.SyncedLyrics[0].Time != 1500*time.Millisecond {
+		t.Fatalf("synced = %+v", m.SyncedLyrics)
+	}
+
+	for in, want := range map[string]string{
+		"2020-1-2":   "2020-01-02",
+		"2019":       "2019",
+		"2018.05":    "2018-05",
+		"1577836800": "2020-01-01",
+		"0":          "",
+		"unknown":    "",
+	} {
+		if got := normalizeDate(in); got != want {
+			t.Errorf("normalizeDate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteID3v24(t *testing.T) {
+	path := writeTemp(t, "a.mp3", fakeMP3Audio)
+	m := &Metadata{
+		Title: "晴天", Artist: "周杰伦", Album: "叶惠美", Date: "2003-07-31", Track: "3",
+		Lyrics:       "故事的小黄花",
+		SyncedLyrics: []SyncedLine{{Time: 1500 * time.Millisecond, Text: "故事的小黄花"}},
+		Cover:        &Picture{Data: []byte{0xFF, 0xD8, 0xFF, 0xE0, 1, 2}},
+	}
+	if err := WriteFile(path, m); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	version, frames, rest := parseID3Frames(t, data)
+	if version != ID3v24 {
+		t.Fatalf("version = %d", version)
+	}
+	if !bytes.Equal(rest, fakeMP3Audio) {
+		t.Fatalf("audio data changed: %x", rest)
+	}
+	for id, want := range map[string]string{"TIT2": "晴天", "TPE1": "周杰伦", "TALB": "叶惠美", "TDRC": "2003-07-31", "TRCK": "3"} {
+		got := frames[id]
+		if len(got) != 1 || got[0][0] != id3EncodingUTF8 || string(got[0][1:]) != want {
+			t.Errorf("%s = %q, want %q", id, got, want)
+		}
+	}
+	apic := frames["APIC"][0]
+	if !bytes.HasPrefix(apic, []byte("\x03image/jpeg\x00\x03\x00")) || !bytes.HasSuffix(apic, m.Cover.Data) {
+		t.Errorf("APIC = %x", apic)
+	}
+	if uslt := frames["USLT"][0]; string(uslt) != "\x03XXX\x00故事的小黄花" {
+		t.Errorf("USLT = %q", uslt)
+	}
+	sylt := frames["SYLT"][0]
+	want := append([]byte("\x03XXX\x02\x01\x00故事的小黄花\x00"), 0, 0, 0x05, 0xDC)
+	if !bytes.Equal(sylt, want) {
+		t.Errorf("SYLT = %x, want %x", sylt, want)
+	}
+}
+
+func TestWriteID3v23(t *testing.T) {
+	path := writeTemp(t, "a.mp3", fakeMP3Audio)
+	if err := WriteID3(path, &Metadata{Title: "晴天", Date: "2003-07-31"}, ID3v23); err != nil {
+		t.Fatalf("WriteID3: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	version, frames, rest := parseID3Frames(t, data)
+	if version != ID3v23 || !bytes.Equal(rest, fakeMP3Audio) {
+		t.Fatalf("version = %d, rest = %x", version, rest)
+	}
+	if f := frames["TIT2"][0]; f[0] != id3EncodingUTF16 || decodeUTF16(f[1:]) != "晴天" {
+		t.Errorf("TIT2 = %x", f)
+	}
+	if _, ok := frames["TDRC"]; ok {
+		t.Error("v2.3 tag should not contain TDRC")
+	}
+	if decodeUTF16(frames["TYER"][0][1:]) != "2003" || decodeUTF16(frames["TDAT"][0][1:]) != "3107" {
+		t.Errorf("TYER/TDAT = %x/%x", frames["TYER"], frames["TDAT"])
+	}
+}
+
+func TestWriteID3ReplacesExistingTag(t *testing.T) {
+	var old bytes.Buffer
+	writeID3Frame(&old, ID3v24, "TIT2", encodeID3Text(ID3v24, "旧标题"))
+	writeID3Frame(&old, ID3v24, "TCON", encodeID3Text(ID3v24, "Pop"))
+	head := make([]byte, 10)
+	copy(head, "ID3")
+	head[3] = ID3v24
+	putSyncsafe(head[6:], old.Len())
+	path := writeTemp(t, "a.mp3", append(append(head, old.Bytes()...), fakeMP3Audio...))
+
+	for i := 0; i < 2; i++ {
+		if err := WriteID3(path, &Metadata{Title: "新标题"}, ID3v24); err != nil {
+			t.Fatalf("WriteID3: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	_, frames, rest := parseID3Frames(t, data)
+	if !bytes.Equal(rest, fakeMP3Audio) {
+		t.Fatalf("audio data changed: %x", rest)
+	}
+	if len(frames["TIT2"]) != 1 || string(frames["TIT2"][0][1:]) != "新标题" {
+		t.Errorf("TIT2 = %q", frames["TIT2"])
+	}
+	if len(frames["TCON"]) != 1 || string(frames["TCON"][0][1:]) != "Pop" {
+		t.Errorf("unrelated frame not kept: %q", frames["TCON"])
+	}
+}
+
+func TestWriteID3KeepsUnreplacedFrames(t *testing.T) {
+	var old bytes.Buffer
+	writeID3Frame(&old, ID3v23, "TIT2", encodeID3Text(ID3v23, "旧标题"))
+	writeID3Frame(&old, ID3v23, "APIC", encodeAPIC(ID3v23, &Picture{Data: []byte{0xFF, 0xD8, 0xFF, 0xE0, 9}}))
+	writeID3Frame(&old, ID3v23, "USLT", encodeUSLT(ID3v23, "旧歌词"))
+	writeID3Frame(&old, ID3v23, "SYLT", encodeSYLT(ID3v23, []SyncedLine{{Time: time.Second, Text: "旧歌词"}}))
+	writeID3Frame(&old, ID3v23, "TXXX", encodeID3Text(ID3v23, "REPLAYGAIN_TRACK_GAIN", "-6.50 dB"))
+	writeID3Frame(&old, ID3v23, "TXXX", encodeID3Text(ID3v23, "NETEASE_ID", "1"))
+	head := make([]byte, 10)
+	copy(head, "ID3")
+	head[3] = ID3v23
+	putSyncsafe(head[6:], old.Len())
+	path := writeTemp(t, "a.mp3", append(append(head, old.Bytes()...), fakeMP3Audio...))
+
+	m := &Metadata{Title: "新标题", Comments: map[string]string{"NETEASE_ID": "2"}}
+	if err := WriteID3(path, m, ID3v23); err != nil {
+		t.Fatalf("WriteID3: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	_, frames, rest := parseID3Frames(t, data)
+	if !bytes.Equal(rest, fakeMP3Audio) {
+		t.Fatalf("audio data changed: %x", rest)
+	}
+	if len(frames["TIT2"]) != 1 || decodeUTF16(frames["TIT2"][0][1:]) != "新标题" {
+		t.Errorf("TIT2 = %x", frames["TIT2"])
+	}
+	for _, id := range []string{"APIC", "USLT", "SYLT"} {
+		if len(frames[id]) != 1 {
+			t.Errorf("%s frames = %d, want the old frame kept", id, len(frames[id]))
+		}
+	}
+	var txxx []string
+	for _, f := range frames["TXXX"] {
+		desc, _ := decodeID3String(f[0], f[1:])
+		txxx = append(txxx, desc)
+	}
+	if len(txxx) != 2 || txxx[0] != "NETEASE_ID" || txxx[1] != "REPLAYGAIN_TRACK_GAIN" {
+		t.Errorf("TXXX = %q", txxx)
+	}
+
+	// 写入封面和歌词时替换旧帧
+	m = &Metadata{Lyrics: "新歌词", Cover: &Picture{Data: []byte{0xFF, 0xD8, 0xFF, 0xE0, 1}}}
+	if err := WriteID3(path, m, ID3v23); err != nil {
+		t.Fatalf("WriteID3: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	_, frames, _ = parseID3Frames(t, data)
+	if len(frames["APIC"]) != 1 || !bytes.HasSuffix(frames["APIC"][0], m.Cover.Data) {
+		t.Errorf("APIC = %x", frames["APIC"])
+	}
+	if len(frames["USLT"]) != 1 || len(frames["SYLT"]) != 1 || len(frames["TXXX"]) != 2 {
+		t.Errorf("USLT/SYLT/TXXX = %d/%d/%d", len(frames["USLT"]), len(frames["SYLT"]), len(frames["TXXX"]))
+	}
+}
+
+type closeRecorder struct {
+	t      *testing.T
+	path   string
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	if data, _ := os.ReadFile(c.path); string(data) != "old" {
+		c.t.Errorf("source closed after replacing: %q", data)
+	}
+	c.closed = true
+	return nil
+}
+
+func TestReplaceFileClosesSourceFirst(t *testing.T) {
+	path := writeTemp(t, "a.mp3", []byte("old"))
+	src := &closeRecorder{t: t, path: path}
+	err := replaceFile(path, src, func(w io.Writer) error {
+		_, err := w.Write([]byte("new"))
+		return err
+	})
+	if err != nil || !src.closed {
+		t.Fatalf("replaceFile: %v, closed = %v", err, src.closed)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Fatalf("content = %q", data)
+	}
+}
@@ -0,0 +1,294 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/guohuiyuan/music-lib/mp4"
+)
+
+// ilst 中 data box 的类型标识
+const (
+	mp4DataImplicit = 0
+	mp4DataUTF8     = 1
+	mp4DataJPEG     = 13
+	mp4DataPNG      = 14
+)
+
+// ErrInvalidMP4 表示文件中找不到可写入标签的 moov box
+var ErrInvalidMP4 = errors.New("tag: invalid mp4 file")
+
+// managedMP4Items 是由 Metadata 生成的 ilst 条目，改写时会先删除旧条目
+var managedMP4Items = map[string]bool{
+	"\xa9nam": true, "\xa9ART": true, "\xa9alb": true, "\xa9day": true,
+	"trkn": true, "covr": true, "\xa9lyr": true,
+}
+
+// WriteMP4 把 iTunes 风格的标签 (©nam、©ART、©alb、covr、©lyr 等) 写入 moov/udta/meta/ilst。
+// moov 变大或变小时会同步修正 stco/co64 中的 chunk 偏移，音频数据本身不会改动。
+func WriteMP4(path string, m *Metadata) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	moovOffset, moovSize, err := locateMoov(f, info.Size())
+	if err != nil {
+		return err
+	}
+	moov := make([]byte, moovSize)
+	if _, err := f.ReadAt(moov, moovOffset); err != nil {
+		return err
+	}
+
+	newMoov, err := rebuildMoov(moov, m)
+	if err != nil {
+		return err
+	}
+	// moov 位于 mdat 之前时，其后所有数据都会整体移动
+	if delta := int64(len(newMoov)) - moovSize; delta != 0 {
+		if err := shiftChunkOffsets(newMoov, moovOffset+moovSize, delta); err != nil {
+			return err
+		}
+	}
+
+	return replaceFile(path, f, func(w io.Writer) error {
+		if _, err := io.Copy(w, io.NewSectionReader(f, 0, moovOffset)); err != nil {
+			return err
+		}
+		if _, err := w.Write(newMoov); err != nil {
+			return err
+		}
+		rest := moovOffset + moovSize
+		_, err := io.Copy(w, io.NewSectionReader(f, rest, info.Size()-rest))
+		return err
+	})
+}
+
+// locateMoov 遍历顶层 box，返回 moov 的位置和大小
+func locateMoov(r io.ReaderAt, fileSize int64) (int64, int64, error) {
+	head := make([]byte, 16)
+	for pos := int64(0); pos+8 <= fileSize; {
+		n, err := r.ReadAt(head, pos)
+		if n < 8 {
+			return 0, 0, err
+		}
+		boxType, size, _, ok := mp4.Header(head[:n])
+		if !ok {
+			break
+		}
+		if size == 0 {
+			size = uint64(fileSize - pos)
+		}
+		if size > uint64(fileSize-pos) {
+			break
+		}
+		if boxType == "moov" {
+			if size > math.MaxInt32 {
+				break
+			}
+			return pos, int64(size), nil
+		}
+		pos += int64(size)
+	}
+	return 0, 0, ErrInvalidMP4
+}
+
+// rebuildMoov 替换 moov 中的 udta/meta/ilst，其余子 box 原样保留
+func rebuildMoov(moov []byte, m *Metadata) ([]byte, error) {
+	if _, size, headerSize, ok := mp4.Header(moov); !ok || headerSize != 8 || int(size) != len(moov) {
+		return nil, ErrInvalidMP4
+	}
+
+	var udta []byte
+	if box, err := mp4.FindBox(moov, "udta", 8, len(moov)); err == nil {
+		udta = box.Data
+		moov = append(append([]byte(nil), moov[:box.Offset]...), moov[box.End():]...)
+	}
+
+	var meta []byte
+	udta = removeBox(udta, "meta", func(data []byte) { meta = data })
+	if len(meta) < 4 {
+		meta = []byte{0, 0, 0, 0}
+	}
+
+	// meta: version/flags + hdlr + ilst + 其他子 box
+	var hdlr, ilst []byte
+	children := removeBox(meta[4:], "hdlr", func(data []byte) { hdlr = data })
+	children = removeBox(children, "ilst", func(data []byte) { ilst = data })
+	if hdlr == nil {
+		hdlr = append(make([]byte, 8), "mdirappl"...)
+		hdlr = append(hdlr, make([]byte, 9)...)
+	}
+
+	var newMeta bytes.Buffer
+	newMeta.Write(meta[:4])
+	newMeta.Write(encodeMP4Box("hdlr", hdlr))
+	newMeta.Write(encodeMP4Box("ilst", encodeIlst(m, ilst)))
+	newMeta.Write(children)
+
+	udta = append(udta, encodeMP4Box("meta", newMeta.Bytes())...)
+	out := append(moov[8:len(moov):len(moov)], encodeMP4Box("udta", udta)...)
+	return encodeMP4Box("moov", out), nil
+}
+
+// removeBox 从同级 box 列表中移除第一个 boxType，并把它的内容交给 fn
+func removeBox(data []byte, boxType string, fn func([]byte)) []byte {
+	box, err := mp4.FindBox(data, boxType, 0, len(data))
+	if err != nil {
+		return data
+	}
+	fn(box.Data)
+	out := make([]byte, 0, len(data)-box.Size)
+	out = append(out, data[:box.Offset]...)
+	return append(out, data[box.End():]...)
+}
+
+func encodeMP4Box(boxType string, data []byte) []byte {
+	out := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint32(out, uint32(8+len(data)))
+	copy(out[4:], boxType)
+	return append(out, data...)
+}
+
+// encodeIlst 生成新的 ilst 内容，old 中不由 Metadata 管理的条目保留在前面
+func encodeIlst(m *Metadata, old []byte) []byte {
+	var buf bytes.Buffer
+	comments := map[string]bool{}
+	for key := range m.Comments {
+		comments[strings.ToUpper(key)] = true
+	}
+	for pos := 0; pos+8 <= len(old); {
+		size := int(binary.BigEndian.Uint32(old[pos:]))
+		if size < 8 || pos+size > len(old) {
+			break
+		}
+		item := old[pos : pos+size]
+		boxType := string(item[4:8])
+		if !managedMP4Items[boxType] && !(boxType == "----" && comments[strings.ToUpper(freeformName(item[8:]))]) {
+			buf.Write(item)
+		}
+		pos += size
+	}
+
+	text := func(boxType, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			buf.Write(encodeMP4Box(boxType, encodeMP4Data(mp4DataUTF8, []byte(value))))
+		}
+	}
+	text("\xa9nam", m.Title)
+	text("\xa9ART", m.Artist)
+	text("\xa9alb", m.Album)
+	text("\xa9day", m.Date)
+	if track, total := parseTrack(m.Track); track > 0 {
+		data := make([]byte, 8)
+		binary.BigEndian.PutUint16(data[2:], uint16(track))
+		binary.BigEndian.PutUint16(data[4:], uint16(total))
+		buf.Write(encodeMP4Box("trkn", encodeMP4Data(mp4DataImplicit, data)))
+	}
+	text("\xa9lyr", m.lrcText())
+	if m.Cover != nil && len(m.Cover.Data) > 0 {
+		kind := mp4DataJPEG
+		if mime := m.Cover.MIME; mime == "image/png" || (mime == "" && DetectImageMIME(m.Cover.Data) == "image/png") {
+			kind = mp4DataPNG
+		}
+		buf.Write(encodeMP4Box("covr", encodeMP4Data(kind, m.Cover.Data)))
+	}
+	for _, key := range sortedKeys(m.Comments) {
+		if v := strings.TrimSpace(m.Comments[key]); v != "" {
+			var item []byte
+			item = append(item, encodeMP4Box("mean", append([]byte{0, 0, 0, 0}, "com.apple.iTunes"...))...)
+			item = append(item, encodeMP4Box("name", append([]byte{0, 0, 0, 0}, strings.ToUpper(key)...))...)
+			item = append(item, encodeMP4Box("data", append([]byte{0, 0, 0, mp4DataUTF8, 0, 0, 0, 0}, v...))...)
+			buf.Write(encodeMP4Box("----", item))
+		}
+	}
+	return buf.Bytes()
+}
+
+func encodeMP4Data(kind int, payload []byte) []byte {
+	data := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(data, uint32(kind))
+	return encodeMP4Box("data", append(data, payload...))
+}
+
+// freeformName 返回自定义条目 (----) 的 name 字段
+func freeformName(item []byte) string {
+	box, err := mp4.FindBox(item, "name", 0, len(item))
+	if err != nil || len(box.Data) < 4 {
+		return ""
+	}
+	return string(box.Data[4:])
+}
+
+func parseTrack(track string) (int, int) {
+	parts := strings.SplitN(strings.TrimSpace(track), "/", 2)
+	n, _ := strconv.Atoi(strings.TrimSpace(parts[0]))
+	total := 0
+	if len(parts) == 2 {
+		total, _ = strconv.Atoi(strings.TrimSpace(parts[1]))
+	}
+	if n < 0 || n > math.MaxUint16 || total < 0 || total > math.MaxUint16 {
+		return 0, 0
+	}
+	return n, total
+}
+
+// shiftChunkOffsets 把所有轨道中指向 from 之后的 chunk 偏移加上 delta
+func shiftChunkOffsets(moov []byte, from, delta int64) error {
+	for pos := 8; ; {
+		trak, err := mp4.FindBox(moov, "trak", pos, len(moov))
+		if err != nil {
+			return nil
+		}
+		pos = trak.End()
+		stbl, err := mp4.FindPath(moov, trak.Offset+8, trak.End(), "mdia", "minf", "stbl")
+		if err != nil {
+			continue
+		}
+		if stco, err := mp4.FindBox(moov, "stco", stbl.Offset+8, stbl.End()); err == nil {
+			for _, entry := range chunkEntries(stco.Data, 4) {
+				v := int64(binary.BigEndian.Uint32(entry))
+				if v >= from {
+					if v+delta > math.MaxUint32 {
+						return fmt.Errorf("tag: chunk offset overflows stco, file too large")
+					}
+					binary.BigEndian.PutUint32(entry, uint32(v+delta))
+				}
+			}
+		}
+		if co64, err := mp4.FindBox(moov, "co64", stbl.Offset+8, stbl.End()); err == nil {
+			for _, entry := range chunkEntries(co64.Data, 8) {
+				if v := int64(binary.BigEndian.Uint64(entry)); v >= from {
+					binary.BigEndian.PutUint64(entry, uint64(v+delta))
+				}
+			}
+		}
+	}
+}
+
+// chunkEntries 把 stco/co64 的内容拆成可原地修改的偏移项
+func chunkEntries(data []byte, width int) [][]byte {
+	if len(data) < 8 {
+		return nil
+	}
+	count := int(binary.BigEndian.Uint32(data[4:8]))
+	count = minInt(count, (len(data)-8)/width)
+	entries := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		entries = append(entries, data[8+i*width:8+(i+1)*width])
+	}
+	return entries
+}
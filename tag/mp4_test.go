@@ -0,0 +1,146 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/guohuiyuan/music-lib/mp4"
+)
+
+var fakeMP4Samples = [][]byte{[]byte("chunk-one"), []byte("chunk-two")}
+
+// buildMP4 生成只有一条音轨的最小 m4a，moovFirst 决定 moov 是否位于 mdat 之前
+func buildMP4(moovFirst bool, udta []byte) []byte {
+	ftyp := encodeMP4Box("ftyp", []byte("M4A \x00\x00\x00\x00M4A mp42isom"))
+	mdatBody := bytes.Join(fakeMP4Samples, nil)
+
+	build := func(mdatOffset int) []byte {
+		stco := make([]byte, 8+4*len(fakeMP4Samples))
+		binary.BigEndian.PutUint32(stco[4:], uint32(len(fakeMP4Samples)))
+		pos := mdatOffset + 8
+		for i, sample := range fakeMP4Samples {
+			binary.BigEndian.PutUint32(stco[8+4*i:], uint32(pos))
+			pos += len(sample)
+		}
+		stbl := encodeMP4Box("stbl", encodeMP4Box("stco", stco))
+		trak := encodeMP4Box("trak", encodeMP4Box("mdia", encodeMP4Box("minf", stbl)))
+		body := append(encodeMP4Box("mvhd", make([]byte, 100)), trak...)
+		if udta != nil {
+			body = append(body, encodeMP4Box("udta", udta)...)
+		}
+		return encodeMP4Box("moov", body)
+	}
+
+	mdat := encodeMP4Box("mdat", mdatBody)
+	if moovFirst {
+		moov := build(0)
+		moov = build(len(ftyp) + len(moov))
+		return bytes.Join([][]byte{ftyp, moov, mdat}, nil)
+	}
+	return bytes.Join([][]byte{ftyp, mdat, build(len(ftyp))}, nil)
+}
+
+// checkChunks 确认 stco 中的偏移仍然指向原来的 sample
+func checkChunks(t *testing.T, data []byte) {
+	t.Helper()
+	moov, err := mp4.FindBox(data, "moov", 0, len(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stco, err := mp4.FindPath(data, moov.Offset+8, moov.End(), "trak", "mdia", "minf", "stbl", "stco")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, entry := range chunkEntries(stco.Data, 4) {
+		off := int(binary.BigEndian.Uint32(entry))
+		want := fakeMP4Samples[i]
+		if off+len(want) > len(data) || !bytes.Equal(data[off:off+len(want)], want) {
+			t.Errorf("chunk %d at %d does not point to %q", i, off, want)
+		}
+	}
+}
+
+func ilstItems(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	moov, _ := mp4.FindBox(data, "moov", 0, len(data))
+	ilst, err := mp4.FindPath(data, moov.Offset+8, moov.End(), "udta", "meta", "ilst")
+	if err != nil {
+		t.Fatalf("ilst not found: %v", err)
+	}
+	items := map[string][]byte{}
+	for pos := ilst.Offset + 8; pos < ilst.End(); {
+		size := int(binary.BigEndian.Uint32(data[pos:]))
+		item := data[pos : pos+size]
+		payload := item[8:]
+		if box, err := mp4.FindBox(item, "data", 8, len(item)); err == nil && string(item[4:8]) != "----" {
+			payload = box.Data
+		}
+		items[string(item[4:8])] = payload
+		pos += size
+	}
+	return items
+}
+
+func TestWriteMP4(t *testing.T) {
+	for _, moovFirst := range []bool{true, false} {
+		path := writeTemp(t, "a.m4a", buildMP4(moovFirst, nil))
+		m := &Metadata{
+			Title: "晴天", Artist: "周杰伦", Album: "叶惠美", Track: "3/11", Lyrics: "故事的小黄花",
+			Cover:    &Picture{Data: []byte{0xFF, 0xD8, 0xFF, 0xE0}},
+			Comments: map[string]string{"SODA_ID": "7012345"},
+		}
+		if err := WriteFile(path, m); err != nil {
+			t.Fatalf("WriteFile(moovFirst=%v): %v", moovFirst, err)
+		}
+		data, _ := os.ReadFile(path)
+		checkChunks(t, data)
+
+		items := ilstItems(t, data)
+		for id, want := range map[string]string{"\xa9nam": "晴天", "\xa9ART": "周杰伦", "\xa9alb": "叶惠美", "\xa9lyr": "故事的小黄花"} {
+			if got := items[id]; len(got) < 8 || got[3] != mp4DataUTF8 || string(got[8:]) != want {
+				t.Errorf("%q = %q, want %q", id, got, want)
+			}
+		}
+		if got := items["trkn"]; !bytes.Equal(got, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 11, 0, 0}) {
+			t.Errorf("trkn = %x", got)
+		}
+		if got := items["covr"]; len(got) < 8 || got[3] != mp4DataJPEG || !bytes.Equal(got[8:], m.Cover.Data) {
+			t.Errorf("covr = %x", got)
+		}
+		if ff := items["----"]; freeformName(ff) != "SODA_ID" {
+			t.Errorf("freeform item = %q", ff)
+		}
+	}
+}
+
+func TestWriteMP4KeepsUnmanagedItems(t *testing.T) {
+	var ilst []byte
+	ilst = append(ilst, encodeMP4Box("\xa9nam", encodeMP4Data(mp4DataUTF8, []byte("旧标题")))...)
+	ilst = append(ilst, encodeMP4Box("\xa9too", encodeMP4Data(mp4DataUTF8, []byte("Lavf58")))...)
+	meta := append([]byte{0, 0, 0, 0}, encodeMP4Box("ilst", ilst)...)
+	path := writeTemp(t, "a.m4a", buildMP4(true, encodeMP4Box("meta", meta)))
+
+	for i := 0; i < 2; i++ {
+		if err := WriteMP4(path, &Metadata{Title: "新标题"}); err != nil {
+			t.Fatalf("WriteMP4: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	checkChunks(t, data)
+	if n := bytes.Count(data, []byte("udta")); n != 1 {
+		t.Errorf("found %d udta boxes", n)
+	}
+	items := ilstItems(t, data)
+	if string(items["\xa9nam"][8:]) != "新标题" || string(items["\xa9too"][8:]) != "Lavf58" {
+		t.Errorf("items = %q", items)
+	}
+}
+
+func TestWriteMP4RejectsWithoutMoov(t *testing.T) {
+	path := writeTemp(t, "a.m4a", encodeMP4Box("ftyp", []byte("M4A ")))
+	if err := WriteMP4(path, &Metadata{Title: "x"}); err != ErrInvalidMP4 {
+		t.Fatalf("err = %v, want ErrInvalidMP4", err)
+	}
+}
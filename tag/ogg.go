@@ -0,0 +1,242 @@
+package tag
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrInvalidOgg 表示文件不是可识别的 Ogg Vorbis/Opus 流
+var ErrInvalidOgg = errors.New("tag: invalid ogg stream")
+
+// oggMaxPageBody 是重新分页时单页的最大数据量 (255 个 lacing 值)
+const oggMaxPageBody = 255 * 255
+
+var oggCRCTable = func() (t [256]uint32) {
+	for i := range t {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = r<<1 ^ 0x04C11DB7
+			} else {
+				r <<= 1
+			}
+		}
+		t[i] = r
+	}
+	return
+}()
+
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+type oggPage struct {
+	headerType byte
+	granule    uint64
+	serial     uint32
+	seq        uint32
+	segments   []byte
+	body       []byte
+}
+
+func readOggPage(r io.Reader) (*oggPage, error) {
+	var head [27]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	if string(head[:4]) != "OggS" || head[4] != 0 {
+		return nil, ErrInvalidOgg
+	}
+	p := &oggPage{
+		headerType: head[5],
+		granule:    binary.LittleEndian.Uint64(head[6:14]),
+		serial:     binary.LittleEndian.Uint32(head[14:18]),
+		seq:        binary.LittleEndian.Uint32(head[18:22]),
+		segments:   make([]byte, head[26]),
+	}
+	if _, err := io.ReadFull(r, p.segments); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOgg, err)
+	}
+	n := 0
+	for _, s := range p.segments {
+		n += int(s)
+	}
+	p.body = make([]byte, n)
+	if _, err := io.ReadFull(r, p.body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOgg, err)
+	}
+	return p, nil
+}
+
+// encode 生成完整的页并重新计算 CRC
+func (p *oggPage) encode() []byte {
+	out := make([]byte, 27, 27+len(p.segments)+len(p.body))
+	copy(out, "OggS")
+	out[5] = p.headerType
+	binary.LittleEndian.PutUint64(out[6:], p.granule)
+	binary.LittleEndian.PutUint32(out[14:], p.serial)
+	binary.LittleEndian.PutUint32(out[18:], p.seq)
+	out[26] = byte(len(p.segments))
+	out = append(out, p.segments...)
+	out = append(out, p.body...)
+	binary.LittleEndian.PutUint32(out[22:], oggCRC(out))
+	return out
+}
+
+// WriteOgg 替换 Ogg Vorbis/Opus 文件中的注释头，封面以 METADATA_BLOCK_PICTURE 写入。
+// 注释头之后的页只更新序号和 CRC，音频数据不变。
+func WriteOgg(path string, m *Metadata) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	br := bufio.NewReader(f)
+
+	first, err := readOggPage(br)
+	if err != nil || first.headerType&0x02 == 0 {
+		return ErrInvalidOgg
+	}
+	var commentPrefix []byte
+	headerPackets := 0
+	switch {
+	case bytes.HasPrefix(first.body, []byte("\x01vorbis")):
+		commentPrefix, headerPackets = []byte("\x03vorbis"), 3
+	case bytes.HasPrefix(first.body, []byte("OpusHead")):
+		commentPrefix, headerPackets = []byte("OpusTags"), 2
+	default:
+		return fmt.Errorf("%w: unsupported codec", ErrInvalidOgg)
+	}
+	if len(first.segments) == 0 || first.segments[len(first.segments)-1] == 255 {
+		return fmt.Errorf("%w: identification header spans pages", ErrInvalidOgg)
+	}
+
+	// 读取注释头 (Vorbis 还有 setup 头)，它们结束的页之后才是音频数据
+	var others [][]byte
+	// packets 的最后一项是正在拼接的包
+	packets := [][]byte{nil}
+	oldPages := 0
+	for len(packets) < headerPackets {
+		p, err := readOggPage(br)
+		if err != nil {
+			return fmt.Errorf("%w: truncated header", ErrInvalidOgg)
+		}
+		if p.serial != first.serial {
+			// 复用流中其他逻辑流的页原样保留
+			others = append(others, p.encode())
+			continue
+		}
+		oldPages++
+		pos := 0
+		for _, s := range p.segments {
+			if len(packets) == headerPackets {
+				return fmt.Errorf("%w: audio data shares a page with headers", ErrInvalidOgg)
+			}
+			last := len(packets) - 1
+			packets[last] = append(packets[last], p.body[pos:pos+int(s)]...)
+			pos += int(s)
+			if s < 255 {
+				packets = append(packets, nil)
+			}
+		}
+	}
+	packets = packets[:len(packets)-1]
+	if len(packets) == 0 || !bytes.HasPrefix(packets[0], commentPrefix) {
+		return fmt.Errorf("%w: comment header not found", ErrInvalidOgg)
+	}
+
+	fields := vorbisFields(m)
+	if m.Cover != nil && len(m.Cover.Data) > 0 {
+		fields = append(fields, [2]string{"METADATA_BLOCK_PICTURE", base64.StdEncoding.EncodeToString(encodeFLACPicture(m.Cover))})
+	}
+	comment := append([]byte(nil), commentPrefix...)
+	comment = append(comment, encodeVorbisComment(fields, packets[0][len(commentPrefix):])...)
+	if headerPackets == 3 {
+		comment = append(comment, 0x01) // framing bit
+	}
+	packets[0] = comment
+
+	pages := paginateOgg(packets, first.serial, first.seq+1)
+	shift := uint32(len(pages) - oldPages)
+	return replaceFile(path, f, func(w io.Writer) error {
+		if _, err := w.Write(first.encode()); err != nil {
+			return err
+		}
+		for _, p := range pages {
+			if _, err := w.Write(p.encode()); err != nil {
+				return err
+			}
+		}
+		for _, p := range others {
+			if _, err := w.Write(p); err != nil {
+				return err
+			}
+		}
+		for {
+			p, err := readOggPage(br)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if p.serial == first.serial {
+				p.seq += shift
+			}
+			if _, err := w.Write(p.encode()); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// paginateOgg 把头部数据包依次排入新页，最后一个包结束时恰好结束一页
+func paginateOgg(packets [][]byte, serial, seq uint32) []*oggPage {
+	var lacing []byte
+	var body []byte
+	for _, pkt := range packets {
+		n := len(pkt)
+		for ; n >= 255; n -= 255 {
+			lacing = append(lacing, 255)
+		}
+		lacing = append(lacing, byte(n))
+		body = append(body, pkt...)
+	}
+
+	var pages []*oggPage
+	continued := false
+	for len(lacing) > 0 {
+		count := minInt(len(lacing), 255)
+		size := 0
+		for _, l := range lacing[:count] {
+			size += int(l)
+		}
+		p := &oggPage{serial: serial, seq: seq, segments: lacing[:count], body: body[:size]}
+		if continued {
+			p.headerType = 0x01
+		}
+		// 没有任何包在本页结束时，按规范 granule position 应为 -1
+		ends := false
+		for _, l := range p.segments {
+			ends = ends || l < 255
+		}
+		if !ends {
+			p.granule = ^uint64(0)
+		}
+		pages = append(pages, p)
+		continued = lacing[count-1] == 255
+		lacing, body = lacing[count:], body[size:]
+		seq++
+	}
+	return pages
+}
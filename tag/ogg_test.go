@@ -0,0 +1,190 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+)
+
+var fakeOggAudio = [][]byte{bytes.Repeat([]byte{0xAA}, 300), bytes.Repeat([]byte{0xBB}, 40)}
+
+func buildOgg(ident, comment, setup []byte) []byte {
+	const serial = 0x1234
+	var out []byte
+	p0 := paginateOgg([][]byte{ident}, serial, 0)[0]
+	p0.headerType = 0x02
+	out = append(out, p0.encode()...)
+	headers := [][]byte{comment}
+	if setup != nil {
+		headers = append(headers, setup)
+	}
+	pages := paginateOgg(headers, serial, 1)
+	for _, p := range pages {
+		out = append(out, p.encode()...)
+	}
+	seq := uint32(1 + len(pages))
+	for i, pkt := range fakeOggAudio {
+		p := paginateOgg([][]byte{pkt}, serial, seq+uint32(i))[0]
+		p.granule = uint64(1024 * (i + 1))
+		if i == len(fakeOggAudio)-1 {
+			p.headerType = 0x04
+		}
+		out = append(out, p.encode()...)
+	}
+	return out
+}
+
+// readOggFile 校验每一页的 CRC 和序号，返回所有页
+func readOggFile(t *testing.T, path string) []*oggPage {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pages []*oggPage
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		start := len(data) - r.Len()
+		p, err := readOggPage(r)
+		if err != nil {
+			t.Fatalf("readOggPage: %v", err)
+		}
+		raw := append([]byte(nil), data[start:len(data)-r.Len()]...)
+		crc := binary.LittleEndian.Uint32(raw[22:])
+		copy(raw[22:26], []byte{0, 0, 0, 0})
+		if oggCRC(raw) != crc {
+			t.Errorf("page %d has bad crc", len(pages))
+		}
+		if p.seq != uint32(len(pages)) {
+			t.Errorf("page %d has sequence %d", len(pages), p.seq)
+		}
+		pages = append(pages, p)
+	}
+	return pages
+}
+
+// oggPackets 按 lacing 值把页重新拼成数据包
+func oggPackets(pages []*oggPage) [][]byte {
+	packets := [][]byte{nil}
+	for _, p := range pages {
+		pos := 0
+		for _, s := range p.segments {
+			packets[len(packets)-1] = append(packets[len(packets)-1], p.body[pos:pos+int(s)]...)
+			pos += int(s)
+			if s < 255 {
+				packets = append(packets, nil)
+			}
+		}
+	}
+	return packets[:len(packets)-1]
+}
+
+func TestWriteOggVorbis(t *testing.T) {
+	ident := append([]byte("\x01vorbis"), make([]byte, 23)...)
+	oldComment := append([]byte("\x03vorbis"), encodeVorbisComment([][2]string{{"TITLE", "旧标题"}, {"GENRE", "Pop"}}, nil)...)
+	oldComment = append(oldComment, 0x01)
+	setup := append([]byte("\x05vorbis"), bytes.Repeat([]byte{0x42}, 600)...)
+	path := writeTemp(t, "a.ogg", buildOgg(ident, oldComment, setup))
+
+	// 封面足够大，注释头需要跨越多页
+	cover := append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, bytes.Repeat([]byte{7}, 100000)...)
+	m := &Metadata{Title: "晴天", Artist: "周杰伦", Lyrics: "故事的小黄花", Cover: &Picture{Data: cover}}
+	if err := WriteFile(path, m); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	pages := readOggFile(t, path)
+	packets := oggPackets(pages)
+	if len(packets) != 5 {
+		t.Fatalf("got %d packets", len(packets))
+	}
+	if !bytes.Equal(packets[0], ident) || !bytes.Equal(packets[2], setup) {
+		t.Error("identification or setup header changed")
+	}
+	for i, want := range fakeOggAudio {
+		if !bytes.Equal(packets[3+i], want) {
+			t.Errorf("audio packet %d changed", i)
+		}
+	}
+	last := pages[len(pages)-1]
+	if last.granule != 2048 || last.headerType != 0x04 {
+		t.Errorf("last page granule/flags = %d/%x", last.granule, last.headerType)
+	}
+
+	comment := packets[1]
+	if !bytes.HasPrefix(comment, []byte("\x03vorbis")) || comment[len(comment)-1] != 0x01 {
+		t.Fatalf("bad comment header prefix/framing")
+	}
+	_, comments, ok := parseVorbisComment(comment[7:])
+	if !ok || len(comments) != 5 {
+		t.Fatalf("comments = %q", comments)
+	}
+	if comments[0] != "GENRE=Pop" || comments[1] != "TITLE=晴天" || comments[3] != "LYRICS=故事的小黄花" {
+		t.Errorf("comments = %q", comments[:4])
+	}
+	pic, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(comments[4], "METADATA_BLOCK_PICTURE="))
+	if err != nil || !bytes.Equal(pic, encodeFLACPicture(m.Cover)) {
+		t.Errorf("bad METADATA_BLOCK_PICTURE: %v", err)
+	}
+}
+
+func TestWriteOggOpus(t *testing.T) {
+	ident := append([]byte("OpusHead"), 1, 2, 0x38, 1, 0x80, 0xBB, 0, 0, 0, 0, 0)
+	oldComment := append([]byte("OpusTags"), encodeVorbisComment(nil, nil)...)
+	path := writeTemp(t, "a.opus", buildOgg(ident, oldComment, nil))
+
+	if err := WriteOgg(path, &Metadata{Title: "晴天", Album: "叶惠美"}); err != nil {
+		t.Fatalf("WriteOgg: %v", err)
+	}
+	packets := oggPackets(readOggFile(t, path))
+	if len(packets) != 4 || !bytes.Equal(packets[2], fakeOggAudio[0]) {
+		t.Fatalf("got %d packets", len(packets))
+	}
+	if !bytes.HasPrefix(packets[1], []byte("OpusTags")) {
+		t.Fatal("bad OpusTags header")
+	}
+	if _, comments, _ := parseVorbisComment(packets[1][8:]); len(comments) != 2 || comments[1] != "ALBUM=叶惠美" {
+		t.Errorf("comments = %q", comments)
+	}
+}
+
+func TestWriteOggRejectsUnknownCodec(t *testing.T) {
+	path := writeTemp(t, "a.ogg", buildOgg([]byte("\x80theora"), []byte("\x81theora"), nil))
+	if err := WriteOgg(path, &Metadata{Title: "x"}); err == nil {
+		t.Fatal("expected error for theora stream")
+	}
+}
+
+func TestWriteOggMultiPageComment(t *testing.T) {
+	ident := append([]byte("OpusHead"), 1, 2, 0x38, 1, 0x80, 0xBB, 0, 0, 0, 0, 0)
+	oldComment := append([]byte("OpusTags"), encodeVorbisComment(nil, nil)...)
+	path := writeTemp(t, "a.opus", buildOgg(ident, oldComment, nil))
+
+	cover := append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, bytes.Repeat([]byte{7}, 200000)...)
+	if err := WriteOgg(path, &Metadata{Title: "晴天", Cover: &Picture{Data: cover}}); err != nil {
+		t.Fatalf("WriteOgg: %v", err)
+	}
+	pages := readOggFile(t, path)
+	headerPages := len(pages) - len(fakeOggAudio)
+	if headerPages < 4 {
+		t.Fatalf("comment header should span several pages, got %d header pages", headerPages)
+	}
+	for i, p := range pages[1:headerPages] {
+		var want uint64
+		if p.segments[len(p.segments)-1] == 255 {
+			want = ^uint64(0)
+		}
+		if p.granule != want {
+			t.Errorf("header page %d granule = %#x, want %#x", i+1, p.granule, want)
+		}
+	}
+	if pages[headerPages-1].granule != 0 {
+		t.Errorf("page ending the comment header has granule %#x", pages[headerPages-1].granule)
+	}
+	if len(oggPackets(pages)) != 4 {
+		t.Errorf("got %d packets", len(oggPackets(pages)))
+	}
+}
@@ -0,0 +1,253 @@
+// Package tag 把 model.Song 中已有的信息 (歌名、歌手、专辑、封面、歌词) 写入下载好的音频文件。
+// 只改写元数据，不会重新编码音频。
+package tag
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guohuiyuan/music-lib/audio"
+	"github.com/guohuiyuan/music-lib/lyrics"
+	"github.com/guohuiyuan/music-lib/model"
+	"github.com/guohuiyuan/music-lib/utils"
+)
+
+// Picture 是内嵌的封面图片
+type Picture struct {
+	MIME        string
+	Description string
+	Data        []byte
+}
+
+// SyncedLine 是一行带时间的歌词
+type SyncedLine struct {
+	Time time.Duration
+	Text string
+}
+
+// Metadata 是写入音频文件的标签
+type Metadata struct {
+	Title  string
+	Artist string
+	Album  string
+	// Date 发行日期，格式为 YYYY 或 YYYY-MM-DD
+	Date string
+	// Track 音轨号，格式为 "3" 或 "3/12"
+	Track string
+	// Lyrics 不带时间轴的歌词文本
+	Lyrics string
+	// SyncedLyrics 逐行的时间轴歌词
+	SyncedLyrics []SyncedLine
+	Cover        *Picture
+	// Comments 平台相关的附加字段 (例如 NETEASE_ID)，支持自定义字段的格式会原样写入
+	Comments map[string]string
+}
+
+var dateRe = regexp.MustCompile(`^(\d{4})(?:[-./](\d{1,2})(?:[-./](\d{1,2}))?)?`)
+
+// FromSong 由歌曲信息和 GetLyrics 返回的 LRC 歌词生成标签，lrc 可以为空。
+// 封面只记录在 Song.Cover 中的地址，需要时调用 FetchCover 下载。
+func FromSong(s *model.Song, lrc string) *Metadata {
+	m := &Metadata{
+		Title:    s.Name,
+		Artist:   s.Artist,
+		Album:    s.Album,
+		Comments: map[string]string{},
+	}
+	if s.Extra != nil {
+		for _, key := range []string{"release_date", "publish_date", "publish_time"} {
+			if date := normalizeDate(s.Extra[key]); date != "" {
+				m.Date = date
+				break
+			}
+		}
+		m.Track = strings.TrimSpace(s.Extra["track"])
+	}
+	if s.Source != "" && s.ID != "" {
+		m.Comments[strings.ToUpper(s.Source)+"_ID"] = s.ID
+	}
+	m.SetLyrics(lrc)
+	return m
+}
+
+// SetLyrics 解析 LRC 歌词，同时填充 Lyrics 和 SyncedLyrics。
+// 逐字歌词会合并为整行，没有时间轴的歌词只写入 Lyrics。
+func (m *Metadata) SetLyrics(lrc string) {
+	m.Lyrics, m.SyncedLyrics = "", nil
+	lrc = strings.TrimSpace(lrc)
+	if lrc == "" {
+		return
+	}
+	_, data := lyrics.ParseLRC(lrc)
+	if len(data) == 0 {
+		m.Lyrics = lrc
+		return
+	}
+	lines := make([]string, 0, len(data))
+	for _, line := range data {
+		var text strings.Builder
+		for _, w := range line.Words {
+			text.WriteString(w.Text)
+		}
+		t := strings.TrimSpace(text.String())
+		lines = append(lines, t)
+		m.SyncedLyrics = append(m.SyncedLyrics, SyncedLine{
+			Time: time.Duration(line.Start.MS) * time.Millisecond,
+			Text: t,
+		})
+	}
+	m.Lyrics = strings.Join(lines, "\n")
+}
+
+// 各平台的发行时间戳都是北京时间零点
+var releaseZone = time.FixedZone("CST", 8*3600)
+
+// normalizeDate 兼容秒/毫秒时间戳和 "2020-01-02" 之类的日期字符串
+func normalizeDate(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" || v == "0" {
+		return ""
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil && len(v) >= 9 {
+		if len(v) >= 12 {
+			n /= 1000
+		}
+		return time.Unix(n, 0).In(releaseZone).Format("2006-01-02")
+	}
+	m := dateRe.FindStringSubmatch(v)
+	if m == nil {
+		return ""
+	}
+	if m[2] == "" {
+		return m[1]
+	}
+	month, _ := strconv.Atoi(m[2])
+	if m[3] == "" {
+		return fmt.Sprintf("%s-%02d", m[1], month)
+	}
+	day, _ := strconv.Atoi(m[3])
+	return fmt.Sprintf("%s-%02d-%02d", m[1], month, day)
+}
+
+// FetchCover 下载封面图片，client 为 nil 时使用默认客户端
+func FetchCover(ctx context.Context, client *utils.Client, coverURL string) (*Picture, error) {
+	if strings.TrimSpace(coverURL) == "" {
+		return nil, errors.New("cover url is empty")
+	}
+	if client == nil {
+		client = utils.NewClient()
+	}
+	data, err := client.GetContext(ctx, coverURL)
+	if err != nil {
+		return nil, err
+	}
+	mime := DetectImageMIME(data)
+	if mime == "" {
+		return nil, errors.New("cover is not a jpeg or png image")
+	}
+	return &Picture{MIME: mime, Data: data}, nil
+}
+
+// DetectImageMIME 按文件头识别封面格式，无法识别时返回空字符串
+func DetectImageMIME(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return "image/jpeg"
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return "image/webp"
+	}
+	return ""
+}
+
+// WriteFile 按文件内容识别格式并写入标签
+func WriteFile(path string, m *Metadata) error {
+	head := make([]byte, 12)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	n, _ := io.ReadFull(f, head)
+	f.Close()
+
+	switch ext := audio.DetectExt(head[:n]); ext {
+	case "mp3":
+		return WriteID3(path, m, ID3v24)
+	case "flac":
+		return WriteFLAC(path, m)
+	case "m4a":
+		return WriteMP4(path, m)
+	case "ogg":
+		return WriteOgg(path, m)
+	default:
+		return fmt.Errorf("tag: unsupported audio format %q", ext)
+	}
+}
+
+// replaceFile 把 write 生成的内容写入同目录的临时文件，成功后替换 path，
+// 写入途中出错不会破坏原文件。src 是 write 读取的原文件，会在替换前关闭，
+// Windows 上无法重命名覆盖仍被打开的文件；不需要时传 nil。
+func replaceFile(path string, src io.Closer, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tag-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if src != nil {
+		src.Close()
+	}
+	if info, err := os.Stat(path); err == nil {
+		os.Chmod(tmpPath, info.Mode())
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// lrcText 把时间轴歌词还原为 LRC 文本，没有时间轴时返回纯文本歌词。
+// FLAC/Ogg/MP4 没有单独的同步歌词字段，播放器普遍从 LYRICS 中识别 LRC。
+func (m *Metadata) lrcText() string {
+	if len(m.SyncedLyrics) == 0 {
+		return m.Lyrics
+	}
+	var b strings.Builder
+	for _, line := range m.SyncedLyrics {
+		ms := line.Time.Milliseconds()
+		fmt.Fprintf(&b, "[%02d:%02d.%02d]%s\n", ms/60000, ms/1000%60, ms%1000/10, line.Text)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
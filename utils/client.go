@@ -0,0 +1,269 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client 是单个平台实例使用的网络栈。
+// 每个平台的 New 都可以接收 ClientOption，从而为不同实例配置各自的
+// HTTP 客户端、代理、UA 或 Base URL，互不影响。
+type Client struct {
+	httpClient *http.Client
+	err        error
+}
+
+// ClientOption 定义 Client 的配置项
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	proxyURL   string
+	userAgent  string
+	baseURL    string
+	retry      *RetryPolicy
+	limiter    *RateLimiter
+	limiterSet bool
+}
+
+// WithHTTPClient 使用自定义的 http.Client (超时、Cookie Jar、Transport 等均沿用)
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = c
+	}
+}
+
+// WithTransport 替换底层 Transport，例如注入录制/回放用的 RoundTripper
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.transport = rt
+	}
+}
+
+// WithProxy 设置代理地址，支持 http/https/socks5，例如 "socks5://127.0.0.1:1080"
+func WithProxy(proxyURL string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.proxyURL = proxyURL
+	}
+}
+
+// WithUserAgent 覆盖所有请求的 User-Agent，优先级高于各平台内置的 UA
+func WithUserAgent(ua string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.userAgent = ua
+	}
+}
+
+// WithBaseURL 将所有请求的 scheme 和 host 改写为 baseURL，原路径拼接在 baseURL 路径之后。
+// 常用于反向代理或测试时指向本地的 mock 服务。
+func WithBaseURL(baseURL string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.baseURL = baseURL
+	}
+}
+
+// WithRetryPolicy 替换默认的重试策略，传入 NoRetry 可关闭重试
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retry = &p
+	}
+}
+
+// WithRateLimiter 使用指定的限流器代替 DefaultRateLimiter，多个实例可以共用同一个限流器；
+// 传入 nil 关闭限流
+func WithRateLimiter(l *RateLimiter) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.limiter = l
+		cfg.limiterSet = true
+	}
+}
+
+// NewClient 根据选项创建 Client，不传选项时与包级 Get/Post 共用默认客户端
+func NewClient(opts ...ClientOption) *Client {
+	if len(opts) == 0 {
+		return defaultRequester
+	}
+
+	cfg := &clientConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	base := defaultClient
+	if cfg.httpClient != nil {
+		base = cfg.httpClient
+	}
+	hc := *base
+
+	transport := hc.Transport
+	if cfg.transport != nil {
+		transport = cfg.transport
+	}
+
+	if strings.TrimSpace(cfg.proxyURL) != "" {
+		proxy, err := url.Parse(strings.TrimSpace(cfg.proxyURL))
+		if err != nil {
+			return &Client{httpClient: &hc, err: fmt.Errorf("invalid proxy url: %w", err)}
+		}
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		t, ok := transport.(*http.Transport)
+		if !ok {
+			return &Client{httpClient: &hc, err: errors.New("proxy requires an *http.Transport")}
+		}
+		t = t.Clone()
+		t.Proxy = http.ProxyURL(proxy)
+		transport = t
+	}
+
+	if cfg.userAgent != "" || cfg.baseURL != "" {
+		rewrite := &rewriteTransport{next: transport, userAgent: cfg.userAgent}
+		if cfg.baseURL != "" {
+			u, err := url.Parse(strings.TrimSpace(cfg.baseURL))
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return &Client{httpClient: &hc, err: fmt.Errorf("invalid base url: %q", cfg.baseURL)}
+			}
+			rewrite.baseURL = u
+		}
+		transport = rewrite
+	}
+
+	limiter := DefaultRateLimiter
+	if cfg.limiterSet {
+		limiter = cfg.limiter
+	}
+	hc.Transport = &retryTransport{next: transport, policy: cfg.retry, limiter: limiter}
+	return &Client{httpClient: &hc}
+}
+
+var defaultRequester = &Client{httpClient: &http.Client{
+	Timeout:   defaultClient.Timeout,
+	Transport: &retryTransport{limiter: DefaultRateLimiter},
+}}
+
+// HTTPClient 返回底层 http.Client 的副本，调用方可以放心修改
+// CheckRedirect、Timeout 等字段而不影响其他请求。代理、UA 和 Base URL 改写仍然生效。
+// 代理或 Base URL 配置有误时，返回的客户端发出的每个请求都会失败并返回该错误，不会绕过代理直连。
+func (c *Client) HTTPClient() *http.Client {
+	hc := *c.httpClient
+	if c.err != nil {
+		hc.Transport = errTransport{err: c.err}
+	}
+	return &hc
+}
+
+// errTransport 让所有请求都返回 NewClient 时记录的配置错误
+type errTransport struct {
+	err error
+}
+
+func (t errTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// Do 发送请求
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.httpClient.Do(req)
+}
+
+// GetContext 发送 HTTP GET 请求
+func (c *Client) GetContext(ctx context.Context, url string, opts ...RequestOption) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 默认 UA (模拟 Chrome)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+
+	// 应用额外选项
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, &StatusError{Method: http.MethodGet, StatusCode: resp.StatusCode}
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// PostContext 发送 HTTP POST 请求
+func (c *Client) PostContext(ctx context.Context, url string, body io.Reader, opts ...RequestOption) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// 默认 UA (模拟 Chrome)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, &StatusError{Method: http.MethodPost, StatusCode: resp.StatusCode}
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// rewriteTransport 在发出请求前改写 UA 和目标地址
+type rewriteTransport struct {
+	next      http.RoundTripper
+	userAgent string
+	baseURL   *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTripper 不应修改原请求，这里改写副本
+	r := req.Clone(req.Context())
+	if t.userAgent != "" {
+		r.Header.Set("User-Agent", t.userAgent)
+	}
+	if t.baseURL != nil {
+		r.URL.Scheme = t.baseURL.Scheme
+		r.URL.Host = t.baseURL.Host
+		if p := strings.TrimRight(t.baseURL.Path, "/"); p != "" {
+			r.URL.Path = p + r.URL.Path
+			if r.URL.RawPath != "" {
+				r.URL.RawPath = p + r.URL.RawPath
+			}
+		}
+		r.Host = ""
+	}
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(r)
+}
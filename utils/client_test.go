@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientRewritesBaseURLAndUserAgent(t *testing.T) {
+	var gotPath, gotUA string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.RequestURI()
+		gotUA = r.Header.Get("User-Agent")
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/mock"), WithUserAgent("music-lib-test"))
+	body, err := client.GetContext(context.Background(), "https://music.163.com/api/search?s=1",
+		WithHeader("User-Agent", "platform-ua"),
+	)
+	if err != nil {
+		t.Fatalf("GetContext failed: %v", err)
+	}
+	if string(body) != "ok" {
+		t.Fatalf("unexpected body %q", body)
+	}
+	if gotPath != "/mock/api/search?s=1" {
+		t.Fatalf("expected rewritten path, got %q", gotPath)
+	}
+	if gotUA != "music-lib-test" {
+		t.Fatalf("expected overridden user agent, got %q", gotUA)
+	}
+}
+
+func TestClientInvalidProxy(t *testing.T) {
+	client := NewClient(WithProxy("://bad"))
+	if _, err := client.GetContext(context.Background(), "http://example.com"); err == nil {
+		t.Fatal("expected invalid proxy error")
+	}
+}
+
+func TestClientInvalidProxyHTTPClient(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	hc := NewClient(WithProxy("://bad")).HTTPClient()
+	resp, err := hc.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected invalid proxy error from HTTPClient")
+	}
+	if !strings.Contains(err.Error(), "invalid proxy url") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if called {
+		t.Fatal("request bypassed the misconfigured proxy")
+	}
+}
+
+func TestClientWithTransport(t *testing.T) {
+	called := false
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return httptest.NewRecorder().Result(), nil
+	})))
+	if _, err := client.GetContext(context.Background(), "http://example.com"); err != nil {
+		t.Fatalf("GetContext failed: %v", err)
+	}
+	if !called {
+		t.Fatal("custom transport was not used")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
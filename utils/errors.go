@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// StatusError 是请求返回非 2xx 状态码时的错误
+type StatusError struct {
+	Method     string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	if e.Method == http.MethodPost {
+		return fmt.Sprintf("http post failed: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("http request failed: status %d", e.StatusCode)
+}
+
+// Is 让 errors.Is 可以按 RegisterStatusKind 注册的分类判断状态码错误
+func (e *StatusError) Is(target error) bool {
+	kind, ok := statusKinds[e.StatusCode]
+	return ok && kind == target
+}
+
+var statusKinds = map[int]error{}
+
+// RegisterStatusKind 把 HTTP 状态码归入错误分类 kind，只应在 init 中调用。
+// utils 不依赖 model，分类由 model 包注册。
+func RegisterStatusKind(statusCode int, kind error) {
+	statusKinds[statusCode] = kind
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 默认每个域名每秒 10 个请求，允许 20 个突发请求，足以应付正常使用，
+// 又能避免批量解析歌单时瞬间打出大量请求触发平台风控。
+const (
+	DefaultRateLimit = 10
+	DefaultRateBurst = 20
+)
+
+// DefaultRateLimiter 是所有未单独配置限流器的 Client 共用的限流器，
+// 可以通过 SetHostLimit 针对容易触发风控的平台调低速率，例如：
+//
+//	utils.DefaultRateLimiter.SetHostLimit("kugou.com", 2, 2)
+var DefaultRateLimiter = NewRateLimiter(DefaultRateLimit, DefaultRateBurst)
+
+// RateLimiter 按域名分别限速的令牌桶
+type RateLimiter struct {
+	mu      sync.Mutex
+	def     rateLimit
+	hosts   map[string]rateLimit
+	buckets map[string]*tokenBucket
+}
+
+type rateLimit struct {
+	rate  float64 // 每秒补充的令牌数，<= 0 表示不限速
+	burst int
+}
+
+type tokenBucket struct {
+	limit  rateLimit
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 创建限流器，rate 为每个域名每秒允许的请求数，burst 为桶容量。
+// rate <= 0 表示默认不限速，仍可通过 SetHostLimit 单独限制某些域名。
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		def:     newRateLimit(rate, burst),
+		hosts:   make(map[string]rateLimit),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func newRateLimit(rate float64, burst int) rateLimit {
+	if burst < 1 {
+		burst = 1
+	}
+	return rateLimit{rate: rate, burst: burst}
+}
+
+// SetHostLimit 单独设置 host 及其子域名的速率，例如 "kugou.com" 同时作用于
+// "www.kugou.com" 和 "complexsearch.kugou.com"，这些子域名共用一个令牌桶。
+func (l *RateLimiter) SetHostLimit(host string, rate float64, burst int) {
+	host = strings.ToLower(strings.TrimPrefix(host, "."))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hosts[host] = newRateLimit(rate, burst)
+	delete(l.buckets, host)
+}
+
+// Wait 阻塞直到 host 有可用令牌，ctx 结束时返回 ctx.Err()
+func (l *RateLimiter) Wait(ctx context.Context, host string) error {
+	delay := l.reserve(strings.ToLower(host))
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve 取走一个令牌并返回需要等待的时间，令牌不足时预支，后续请求依次排队
+func (l *RateLimiter) reserve(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key, limit := l.match(host)
+	if limit.rate <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{limit: limit, tokens: float64(limit.burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.rate
+	if b.tokens > float64(b.limit.burst) {
+		b.tokens = float64(b.limit.burst)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.limit.rate * float64(time.Second))
+}
+
+// match 返回 host 对应的令牌桶键和速率，优先匹配 SetHostLimit 设置的最长后缀
+func (l *RateLimiter) match(host string) (string, rateLimit) {
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	for h := host; h != ""; {
+		if limit, ok := l.hosts[h]; ok {
+			return h, limit
+		}
+		i := strings.Index(h, ".")
+		if i < 0 {
+			break
+		}
+		h = h[i+1:]
+	}
+	return host, l.def
+}
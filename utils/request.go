@@ -1,9 +1,9 @@
 package utils
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
-	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -25,60 +25,23 @@ func WithHeader(key, value string) RequestOption {
 
 // Get 发送 HTTP GET 请求
 func Get(url string, opts ...RequestOption) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// 默认 UA (模拟 Chrome)
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
-
-	// 应用额外选项
-	for _, opt := range opts {
-		opt(req)
-	}
-
-	resp, err := defaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("http request failed: status %d", resp.StatusCode)
-	}
+	return GetContext(context.Background(), url, opts...)
+}
 
-	return io.ReadAll(resp.Body)
+// GetContext 发送 HTTP GET 请求，ctx 取消或超时后请求立即中止
+func GetContext(ctx context.Context, url string, opts ...RequestOption) ([]byte, error) {
+	return defaultRequester.GetContext(ctx, url, opts...)
 }
 
 // Post 发送 HTTP POST 请求
 // body 这里的类型是 io.Reader，可以传 strings.NewReader(form.Encode())
 func Post(url string, body io.Reader, opts ...RequestOption) ([]byte, error) {
-	req, err := http.NewRequest("POST", url, body)
-	if err != nil {
-		return nil, err
-	}
-
-	// 默认 UA (模拟 Chrome)
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
-
-	// 默认 content type 如果用户没传，通常默认 json 或 form，这里留给 opts 控制
-
-	for _, opt := range opts {
-		opt(req)
-	}
-
-	resp, err := defaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("http post failed: status %d", resp.StatusCode)
-	}
+	return PostContext(context.Background(), url, body, opts...)
+}
 
-	return io.ReadAll(resp.Body)
+// PostContext 发送 HTTP POST 请求，ctx 取消或超时后请求立即中止
+func PostContext(ctx context.Context, url string, body io.Reader, opts ...RequestOption) ([]byte, error) {
+	return defaultRequester.PostContext(ctx, url, body, opts...)
 }
 
 // MD5 计算字符串哈希
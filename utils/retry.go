@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy 控制请求失败后的重试。
+// 遇到 429、5xx、网络超时或连接被重置/拒绝时按指数退避加随机抖动重试，服务端返回 Retry-After 时以它为准。
+// POST 等非幂等请求可能已经被服务端处理 (登录、扫码状态、weapi 等)，只有 429 或带 Retry-After 的响应
+// 才会重试，5xx 和网络错误需要通过 AllowRetry 或 Idempotency-Key 请求头显式声明可以重试。
+type RetryPolicy struct {
+	// MaxAttempts 总尝试次数 (含第一次)，<= 1 表示不重试
+	MaxAttempts int
+	// BaseDelay 第一次重试前的等待时间，之后每次翻倍
+	BaseDelay time.Duration
+	// MaxDelay 单次等待的上限。Retry-After 超过该值时不再重试，直接返回该响应
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy 是未通过 WithRetryPolicy 单独配置时使用的重试策略，
+// 需要调整时应在发出请求前修改
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// NoRetry 关闭重试
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// backoff 返回第 attempt 次重试 (从 1 开始) 前的等待时间，在 [d/2, d] 之间随机抖动，
+// 避免大量并发请求在同一时刻重试
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// retryableStatus 判断状态码是否值得重试
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry 标记 ctx 中发出的请求可以安全重放，非幂等请求 (例如只读的 POST 查询接口)
+// 遇到 5xx 或网络错误时也会重试
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+// idempotent 判断请求重放是否安全：幂等方法、带 Idempotency-Key 请求头或通过 AllowRetry 声明的请求
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	if req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != "" {
+		return true
+	}
+	allowed, _ := req.Context().Value(allowRetryKey{}).(bool)
+	return allowed
+}
+
+// retryableError 只重试网络超时、连接被重置或拒绝以及连接意外关闭这类临时错误
+func retryableError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// parseRetryAfter 解析 Retry-After，支持秒数和 HTTP 日期两种格式
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// retryTransport 在底层 Transport 外层实现限流和重试，
+// 所有经过 Client 的请求 (包括 HTTPClient() 返回的副本) 都会经过这里。
+type retryTransport struct {
+	next    http.RoundTripper
+	policy  *RetryPolicy // 为 nil 时使用 DefaultRetryPolicy
+	limiter *RateLimiter
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	policy := DefaultRetryPolicy
+	if t.policy != nil {
+		policy = *t.policy
+	}
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx, req.URL.Host); err != nil {
+				return nil, err
+			}
+		}
+
+		r := req
+		if attempt > 1 {
+			var err error
+			if r, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := next.RoundTrip(r)
+		if attempt >= policy.MaxAttempts || !canRewind(req) {
+			return resp, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			// 请求可能已经发到服务端，只有能安全重放的请求才重试
+			if !retryableError(err) || !idempotent(req) || ctx.Err() != nil {
+				return nil, err
+			}
+			delay = policy.backoff(attempt)
+		case retryableStatus(resp.StatusCode):
+			delay = policy.backoff(attempt)
+			after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			// 429 和 Retry-After 表示服务端没有处理该请求，任何方法都可以重试
+			if !ok && resp.StatusCode != http.StatusTooManyRequests && !idempotent(req) {
+				return resp, nil
+			}
+			if ok {
+				if policy.MaxDelay > 0 && after > policy.MaxDelay {
+					return resp, nil
+				}
+				delay = after
+			}
+			// 丢弃响应体以便复用连接
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canRewind 判断请求体能否重放，没有请求体或设置了 GetBody 的请求才能重试
+func canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
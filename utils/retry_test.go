@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	var calls int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(fastRetry), WithRateLimiter(nil))
+	body, err := client.PostContext(AllowRetry(context.Background()), server.URL, strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("PostContext failed: %v", err)
+	}
+	if string(body) != "ok" || calls != 3 {
+		t.Fatalf("unexpected result %q after %d calls", body, calls)
+	}
+	for _, b := range bodies {
+		if b != "payload" {
+			t.Fatalf("request body not replayed: %q", bodies)
+		}
+	}
+}
+
+func TestClientDoesNotRetryUnsafePost(t *testing.T) {
+	var calls, status int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		switch code := int(atomic.LoadInt32(&status)); {
+		case code == 0:
+			// 直接断开连接，客户端只会看到 EOF
+			hj, _ := w.(http.Hijacker)
+			conn, _, _ := hj.Hijack()
+			conn.Close()
+		case n == 1:
+			w.WriteHeader(code)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(fastRetry), WithRateLimiter(nil))
+	post := func(code int) ([]byte, int32, error) {
+		atomic.StoreInt32(&calls, 0)
+		atomic.StoreInt32(&status, int32(code))
+		body, err := client.PostContext(context.Background(), server.URL, strings.NewReader("login"))
+		return body, atomic.LoadInt32(&calls), err
+	}
+
+	if _, n, err := post(http.StatusServiceUnavailable); err == nil || n != 1 {
+		t.Fatalf("POST should not be retried on 503, got %v after %d calls", err, n)
+	}
+	if _, n, err := post(0); err == nil || n != 1 {
+		t.Fatalf("POST should not be retried on EOF, got %v after %d calls", err, n)
+	}
+	// 429 说明服务端没有处理请求，POST 也会重试
+	if body, n, err := post(http.StatusTooManyRequests); err != nil || string(body) != "ok" || n != 2 {
+		t.Fatalf("POST should be retried on 429, got %q, %v after %d calls", body, err, n)
+	}
+}
+
+func TestClientGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(fastRetry), WithRateLimiter(nil))
+	_, err := client.GetContext(context.Background(), server.URL)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502 status error, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+
+	calls = 0
+	client = NewClient(WithRetryPolicy(NoRetry), WithRateLimiter(nil))
+	client.GetContext(context.Background(), server.URL)
+	if calls != 1 {
+		t.Fatalf("NoRetry should not retry, got %d attempts", calls)
+	}
+}
+
+func TestClientHonorsRetryAfter(t *testing.T) {
+	var calls int32
+	var first, second time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			second = time.Now()
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second}
+	client := NewClient(WithRetryPolicy(policy), WithRateLimiter(nil))
+	if _, err := client.GetContext(context.Background(), server.URL); err != nil {
+		t.Fatalf("GetContext failed: %v", err)
+	}
+	if wait := second.Sub(first); wait < 900*time.Millisecond {
+		t.Fatalf("Retry-After not honored, waited %v", wait)
+	}
+
+	// Retry-After 超过 MaxDelay 时直接返回 429
+	calls = 0
+	policy.MaxDelay = 100 * time.Millisecond
+	client = NewClient(WithRetryPolicy(policy), WithRateLimiter(nil))
+	if _, err := client.GetContext(context.Background(), server.URL); err == nil || calls != 1 {
+		t.Fatalf("expected immediate 429, got %v after %d calls", err, calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if d, ok := parseRetryAfter("3", now); !ok || d != 3*time.Second {
+		t.Fatalf("seconds: got %v %v", d, ok)
+	}
+	if d, ok := parseRetryAfter(now.Add(5*time.Second).Format(http.TimeFormat), now); !ok || d != 5*time.Second {
+		t.Fatalf("http date: got %v %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Fatal("invalid value should be ignored")
+	}
+}
+
+func TestRateLimiterPerHost(t *testing.T) {
+	l := NewRateLimiter(0, 0)
+	l.SetHostLimit("kugou.com", 20, 1)
+
+	start := time.Now()
+	for _, host := range []string{"www.kugou.com", "complexsearch.kugou.com:443", "www.kugou.com"} {
+		if err := l.Wait(context.Background(), host); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("subdomains should share the kugou bucket, took %v", elapsed)
+	}
+
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		l.Wait(context.Background(), "music.163.com")
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("unlimited host should not wait, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, "www.kugou.com"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRetryableError(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: "http://example.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", err)}}
+	}
+	for _, c := range []struct {
+		err  error
+		want bool
+	}{
+		{wrap(syscall.ECONNRESET), true},
+		{wrap(syscall.ECONNREFUSED), true},
+		{wrap(io.ErrUnexpectedEOF), true},
+		{wrap(syscall.EACCES), false},
+		{errors.New("tls: bad certificate"), false},
+	} {
+		if got := retryableError(c.err); got != c.want {
+			t.Errorf("retryableError(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}